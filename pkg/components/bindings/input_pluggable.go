@@ -21,6 +21,7 @@ import (
 	"sync/atomic"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/dapr/pkg/components"
 	"github.com/dapr/dapr/pkg/components/pluggable"
 	proto "github.com/dapr/dapr/pkg/proto/components/v1"
 	"github.com/dapr/kit/logger"
@@ -38,19 +39,56 @@ type grpcInputBinding struct {
 }
 
 // Init initializes the grpc inputbinding passing out the metadata to the grpc component.
+// Repeated init calls for the same component instance are no-ops, disabled components are not dialed.
+// The connection is closed when the given context is done before the init completes.
 func (b *grpcInputBinding) Init(ctx context.Context, metadata bindings.Metadata) error {
+	return b.InitOrAbort(ctx, func() error {
+		return b.InitOrDisable(metadata.Name, metadata.Properties, func() error {
+			return b.init(ctx, metadata)
+		})
+	})
+}
+
+// Reinit re-initializes the component with the given metadata over the existing connection,
+// an ErrReinitUnsupported error means that the component must be reconnected instead.
+func (b *grpcInputBinding) Reinit(ctx context.Context, metadata bindings.Metadata) error {
+	return b.ReinitWith(metadata.Name, metadata.Properties, func() error {
+		return b.initComponent(metadata)
+	})
+}
+
+// init dials and initializes the grpc component.
+func (b *grpcInputBinding) init(ctx context.Context, metadata bindings.Metadata) error {
 	if err := b.Dial(metadata.Name); err != nil {
 		return err
 	}
+	b.CaptureInfo()
 
-	protoMetadata := &proto.MetadataRequest{
-		Properties: metadata.Properties,
-	}
+	return b.initComponent(metadata)
+}
 
-	_, err := b.Client.Init(b.Context, &proto.InputBindingInitRequest{
-		Metadata: protoMetadata,
+// initComponent sends the init request to the component.
+func (b *grpcInputBinding) initComponent(metadata bindings.Metadata) error {
+	if err := b.ValidateMetadata(metadata.Properties); err != nil {
+		return err
+	}
+	protoMetadata := pluggable.InitMetadata(metadata.Properties)
+
+	err := b.ObserveInit(func() error {
+		resp, initErr := b.Client.Init(b.Context, &proto.InputBindingInitRequest{
+			Metadata: protoMetadata,
+		})
+		if initErr == nil {
+			b.ReportInitWarnings(resp.GetWarnings())
+		}
+		return initErr
 	})
-	return err
+	if err != nil {
+		return err
+	}
+
+	b.Warmup()
+	return nil
 }
 
 type readHandler = func(*proto.ReadResponse)
@@ -167,15 +205,15 @@ func NewGRPCInputBinding(l logger.Logger, socket string) *grpcInputBinding {
 }
 
 // newGRPCInputBinding creates a new input binding for the given pluggable component.
-func newGRPCInputBinding(dialer pluggable.GRPCConnectionDialer) func(l logger.Logger) bindings.InputBinding {
+func newGRPCInputBinding(dialer pluggable.GRPCConnectionDialer, opts ...pluggable.Option) func(l logger.Logger) bindings.InputBinding {
 	return func(l logger.Logger) bindings.InputBinding {
-		return inputFromConnector(l, pluggable.NewGRPCConnectorWithDialer(dialer, proto.NewInputBindingClient))
+		return inputFromConnector(l, pluggable.NewGRPCConnectorWithDialer(dialer, proto.NewInputBindingClient, opts...))
 	}
 }
 
 func init() {
 	//nolint:nosnakecase
 	pluggable.AddServiceDiscoveryCallback(proto.InputBinding_ServiceDesc.ServiceName, func(name string, dialer pluggable.GRPCConnectionDialer) {
-		DefaultRegistry.RegisterInputBinding(newGRPCInputBinding(dialer), name)
+		DefaultRegistry.RegisterInputBinding(newGRPCInputBinding(dialer, pluggable.WithPluggable(components.Pluggable{Type: components.CategoryBindings, Name: name})), name)
 	})
 }
@@ -15,8 +15,10 @@ package bindings
 
 import (
 	"context"
+	"sync"
 
 	"github.com/dapr/components-contrib/bindings"
+	"github.com/dapr/dapr/pkg/components"
 	"github.com/dapr/dapr/pkg/components/pluggable"
 	proto "github.com/dapr/dapr/pkg/proto/components/v1"
 	"github.com/dapr/kit/logger"
@@ -26,21 +28,56 @@ import (
 type grpcOutputBinding struct {
 	*pluggable.GRPCConnector[proto.OutputBindingClient]
 	bindings.OutputBinding
-	operations []bindings.OperationKind
+	// operationsLock guards the operations, they are replaced on reinit.
+	operationsLock sync.RWMutex
+	operations     []bindings.OperationKind
 }
 
 // Init initializes the grpc outputbinding passing out the metadata to the grpc component.
+// Repeated init calls for the same component instance are no-ops, disabled components are not dialed.
+// The connection is closed when the given context is done before the init completes.
 func (b *grpcOutputBinding) Init(ctx context.Context, metadata bindings.Metadata) error {
+	return b.InitOrAbort(ctx, func() error {
+		return b.InitOrDisable(metadata.Name, metadata.Properties, func() error {
+			return b.init(ctx, metadata)
+		})
+	})
+}
+
+// Reinit re-initializes the component with the given metadata over the existing connection.
+// The binding operations are refreshed only when the component init succeeds,
+// an ErrReinitUnsupported error means that the component must be reconnected instead.
+func (b *grpcOutputBinding) Reinit(ctx context.Context, metadata bindings.Metadata) error {
+	return b.ReinitWith(metadata.Name, metadata.Properties, func() error {
+		return b.initComponent(metadata)
+	})
+}
+
+// init dials and initializes the grpc component.
+func (b *grpcOutputBinding) init(ctx context.Context, metadata bindings.Metadata) error {
 	if err := b.Dial(metadata.Name); err != nil {
 		return err
 	}
+	b.CaptureInfo()
 
-	protoMetadata := &proto.MetadataRequest{
-		Properties: metadata.Properties,
-	}
+	return b.initComponent(metadata)
+}
 
-	_, err := b.Client.Init(b.Context, &proto.OutputBindingInitRequest{
-		Metadata: protoMetadata,
+// initComponent sends the init request to the component and fetches its operations.
+func (b *grpcOutputBinding) initComponent(metadata bindings.Metadata) error {
+	if err := b.ValidateMetadata(metadata.Properties); err != nil {
+		return err
+	}
+	protoMetadata := pluggable.InitMetadata(metadata.Properties)
+
+	err := b.ObserveInit(func() error {
+		resp, initErr := b.Client.Init(b.Context, &proto.OutputBindingInitRequest{
+			Metadata: protoMetadata,
+		})
+		if initErr == nil {
+			b.ReportInitWarnings(resp.GetWarnings())
+		}
+		return initErr
 	})
 	if err != nil {
 		return err
@@ -57,13 +94,18 @@ func (b *grpcOutputBinding) Init(ctx context.Context, metadata bindings.Metadata
 	for idx, op := range operationsList {
 		ops[idx] = bindings.OperationKind(op)
 	}
+	b.operationsLock.Lock()
 	b.operations = ops
+	b.operationsLock.Unlock()
 
+	b.Warmup()
 	return nil
 }
 
 // Operations list bindings operations.
 func (b *grpcOutputBinding) Operations() []bindings.OperationKind {
+	b.operationsLock.RLock()
+	defer b.operationsLock.RUnlock()
 	return b.operations
 }
 
@@ -103,15 +145,15 @@ func NewGRPCOutputBinding(l logger.Logger, socket string) *grpcOutputBinding {
 }
 
 // newGRPCOutputBinding creates a new output binding for the given pluggable component.
-func newGRPCOutputBinding(dialer pluggable.GRPCConnectionDialer) func(l logger.Logger) bindings.OutputBinding {
+func newGRPCOutputBinding(dialer pluggable.GRPCConnectionDialer, opts ...pluggable.Option) func(l logger.Logger) bindings.OutputBinding {
 	return func(l logger.Logger) bindings.OutputBinding {
-		return outputFromConnector(l, pluggable.NewGRPCConnectorWithDialer(dialer, proto.NewOutputBindingClient))
+		return outputFromConnector(l, pluggable.NewGRPCConnectorWithDialer(dialer, proto.NewOutputBindingClient, opts...))
 	}
 }
 
 func init() {
 	//nolint:nosnakecase
 	pluggable.AddServiceDiscoveryCallback(proto.OutputBinding_ServiceDesc.ServiceName, func(name string, dialer pluggable.GRPCConnectionDialer) {
-		DefaultRegistry.RegisterOutputBinding(newGRPCOutputBinding(dialer), name)
+		DefaultRegistry.RegisterOutputBinding(newGRPCOutputBinding(dialer, pluggable.WithPluggable(components.Pluggable{Type: components.CategoryBindings, Name: name})), name)
 	})
 }
@@ -16,6 +16,7 @@ package bindings
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/dapr/components-contrib/bindings"
 	"github.com/dapr/dapr/pkg/components"
@@ -27,6 +28,8 @@ type Registry struct {
 	Logger         logger.Logger
 	inputBindings  map[string]func(logger.Logger) bindings.InputBinding
 	outputBindings map[string]func(logger.Logger) bindings.OutputBinding
+	// lock guards the registered components, which can be changed at runtime by the pluggable components health monitor.
+	lock sync.RWMutex
 }
 
 // DefaultRegistry is the singleton with the registry.
@@ -42,6 +45,9 @@ func NewRegistry() *Registry {
 
 // RegisterInputBinding adds a name input binding to the registry.
 func (b *Registry) RegisterInputBinding(componentFactory func(logger.Logger) bindings.InputBinding, names ...string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
 	for _, name := range names {
 		b.inputBindings[createFullName(name)] = componentFactory
 	}
@@ -49,6 +55,9 @@ func (b *Registry) RegisterInputBinding(componentFactory func(logger.Logger) bin
 
 // RegisterOutputBinding adds a name output binding to the registry.
 func (b *Registry) RegisterOutputBinding(componentFactory func(logger.Logger) bindings.OutputBinding, names ...string) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
 	for _, name := range names {
 		b.outputBindings[createFullName(name)] = componentFactory
 	}
@@ -83,6 +92,9 @@ func (b *Registry) HasOutputBinding(name, version string) bool {
 }
 
 func (b *Registry) getInputBinding(name, version, logName string) (func() bindings.InputBinding, bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
 	nameLower := strings.ToLower(name)
 	versionLower := strings.ToLower(version)
 	bindingFn, ok := b.inputBindings[nameLower+"/"+versionLower]
@@ -100,6 +112,9 @@ func (b *Registry) getInputBinding(name, version, logName string) (func() bindin
 }
 
 func (b *Registry) getOutputBinding(name, version, logName string) (func() bindings.OutputBinding, bool) {
+	b.lock.RLock()
+	defer b.lock.RUnlock()
+
 	nameLower := strings.ToLower(name)
 	versionLower := strings.ToLower(version)
 	bindingFn, ok := b.outputBindings[nameLower+"/"+versionLower]
@@ -0,0 +1,108 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	componentsV1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+)
+
+// ErrInvalidPluggable is returned when a pluggable component descriptor is not well-formed.
+var ErrInvalidPluggable = errors.New("invalid pluggable component")
+
+// pluggableNameRegexp matches the allowed pluggable component names and versions, they are used as part of socket file names.
+var pluggableNameRegexp = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+
+// Pluggable describes a pluggable component, a component that runs out of process and talks to daprd using gRPC over a unix domain socket.
+type Pluggable struct {
+	// Type is the pluggable component category.
+	Type Category
+	// Name is the pluggable component name, derived from its socket file name.
+	Name string
+	// Version is the pluggable component version.
+	Version string
+}
+
+// Validate checks that the pluggable component descriptor is well-formed.
+// The name is required and, as the version, it must not contain path separators or characters that are not allowed in socket file names.
+func (p Pluggable) Validate() error {
+	if err := validatePluggablePart("type", string(p.Type), true); err != nil {
+		return err
+	}
+	if err := validatePluggablePart("name", p.Name, true); err != nil {
+		return err
+	}
+	return validatePluggablePart("version", p.Version, false)
+}
+
+// validatePluggablePart checks that the given part of a pluggable component descriptor is allowed in socket file names.
+func validatePluggablePart(part, value string, required bool) error {
+	if value == "" {
+		if required {
+			return fmt.Errorf("%w: %s is required", ErrInvalidPluggable, part)
+		}
+		return nil
+	}
+	if !pluggableNameRegexp.MatchString(value) {
+		return fmt.Errorf("%w: %s '%s' must contain only alphanumeric characters, '.', '_' or '-'", ErrInvalidPluggable, part, value)
+	}
+	return nil
+}
+
+// SupportedPluggableTypes returns the component categories that can be implemented by pluggable components.
+func SupportedPluggableTypes() []Category {
+	return []Category{CategoryBindings, CategoryPubSub, CategorySecretStore, CategoryStateStore}
+}
+
+// ValidatePluggableComponent validates that the given component, when backed by a pluggable component, is consistent and won't produce an invalid socket path at runtime.
+// The component type is expected to be in the form '<category>.<name>', e.g. 'state.my-component'. It is meant to be used from a validating webhook.
+func ValidatePluggableComponent(component componentsV1alpha1.Component) field.ErrorList {
+	var errs field.ErrorList
+	typePath := field.NewPath("spec", "type")
+
+	category, name, found := strings.Cut(component.Spec.Type, ".")
+	if !found {
+		return append(errs, field.Invalid(typePath, component.Spec.Type, "must be in the form '<category>.<name>'"))
+	}
+
+	supported := SupportedPluggableTypes()
+	isSupported := false
+	for _, c := range supported {
+		if Category(category) == c {
+			isSupported = true
+			break
+		}
+	}
+	if !isSupported {
+		values := make([]string, len(supported))
+		for i, c := range supported {
+			values[i] = string(c)
+		}
+		errs = append(errs, field.NotSupported(typePath, category, values))
+	}
+
+	if err := validatePluggablePart("name", name, true); err != nil {
+		errs = append(errs, field.Invalid(typePath, component.Spec.Type, err.Error()))
+	}
+	if err := validatePluggablePart("version", component.Spec.Version, false); err != nil {
+		errs = append(errs, field.Invalid(field.NewPath("spec", "version"), component.Spec.Version, err.Error()))
+	}
+	return errs
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"sync"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+	"github.com/dapr/dapr/utils"
+)
+
+const (
+	// AppIDMetadataKey is the init metadata property holding the app-id of the app served by the sidecar.
+	AppIDMetadataKey = "dapr.io/app-id"
+	// NamespaceMetadataKey is the init metadata property holding the namespace of the app served by the sidecar.
+	NamespaceMetadataKey = "dapr.io/namespace"
+	// SendAppIdentityMetadataKey is the component metadata key used to also send the app-id and the namespace as gRPC metadata on every rpc.
+	SendAppIdentityMetadataKey = "dapr.io/send-app-identity"
+
+	// appIDGRPCMetadataKey and namespaceGRPCMetadataKey are the gRPC metadata keys of the app identity.
+	appIDGRPCMetadataKey     = "dapr-app-id"
+	namespaceGRPCMetadataKey = "dapr-namespace"
+)
+
+var (
+	appIdentityLock sync.RWMutex
+	appID           string
+	appNamespace    string
+)
+
+// SetAppIdentity sets the app-id and the namespace of the app served by the sidecar, they are sent to every pluggable component on init.
+func SetAppIdentity(id, namespace string) {
+	appIdentityLock.Lock()
+	defer appIdentityLock.Unlock()
+	appID = id
+	appNamespace = namespace
+}
+
+// getAppIdentity returns the app-id and the namespace of the app served by the sidecar.
+func getAppIdentity() (string, string) {
+	appIdentityLock.RLock()
+	defer appIdentityLock.RUnlock()
+	return appID, appNamespace
+}
+
+// InitMetadata returns the metadata request sent to the component on init with the given properties,
+// the app identity properties are added when set, see AppIDMetadataKey and NamespaceMetadataKey.
+// The given properties are not modified.
+func InitMetadata(properties map[string]string) *proto.MetadataRequest {
+	id, namespace := getAppIdentity()
+	if id == "" && namespace == "" {
+		return &proto.MetadataRequest{Properties: properties}
+	}
+
+	withIdentity := make(map[string]string, len(properties)+2)
+	for k, v := range properties {
+		withIdentity[k] = v
+	}
+	if id != "" {
+		withIdentity[AppIDMetadataKey] = id
+	}
+	if namespace != "" {
+		withIdentity[NamespaceMetadataKey] = namespace
+	}
+	return &proto.MetadataRequest{Properties: withIdentity}
+}
+
+// appIdentityGRPCMetadata returns the app identity gRPC metadata key-value pairs when enabled by the given component metadata properties.
+func appIdentityGRPCMetadata(properties map[string]string) []string {
+	if !utils.IsTruthy(properties[SendAppIdentityMetadataKey]) {
+		return nil
+	}
+	id, namespace := getAppIdentity()
+	pairs := make([]string, 0, 4)
+	if id != "" {
+		pairs = append(pairs, appIDGRPCMetadataKey, id)
+	}
+	if namespace != "" {
+		pairs = append(pairs, namespaceGRPCMetadataKey, namespace)
+	}
+	return pairs
+}
@@ -0,0 +1,52 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppIdentity(t *testing.T) {
+	t.Cleanup(func() { SetAppIdentity("", "") })
+
+	t.Run("init metadata should be kept as is when the app identity is not set", func(t *testing.T) {
+		SetAppIdentity("", "")
+		properties := map[string]string{"connectionString": "fake"}
+		assert.Equal(t, properties, InitMetadata(properties).Properties)
+	})
+
+	t.Run("init metadata should include the app identity without changing the given properties", func(t *testing.T) {
+		SetAppIdentity("myapp", "mynamespace")
+		properties := map[string]string{"connectionString": "fake"}
+
+		assert.Equal(t, map[string]string{
+			"connectionString":   "fake",
+			AppIDMetadataKey:     "myapp",
+			NamespaceMetadataKey: "mynamespace",
+		}, InitMetadata(properties).Properties)
+		assert.Len(t, properties, 1)
+	})
+
+	t.Run("app identity should be sent as gRPC metadata only when enabled", func(t *testing.T) {
+		SetAppIdentity("myapp", "mynamespace")
+
+		assert.Empty(t, grpcMetadataOf(map[string]string{}))
+		assert.Equal(t, []string{"authorization", "token", "dapr-app-id", "myapp", "dapr-namespace", "mynamespace"}, grpcMetadataOf(map[string]string{
+			GRPCMetadataPrefix + "authorization": "token",
+			SendAppIdentityMetadataKey:           "true",
+		}))
+	})
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// FeatureBulkGet is the feature advertised by components that implement the bulk get operation natively.
+	FeatureBulkGet = "BULK_GET"
+	// FeatureBulkSet is the feature advertised by components that implement the bulk set operation natively.
+	FeatureBulkSet = "BULK_SET"
+	// FeatureBulkDelete is the feature advertised by components that implement the bulk delete operation natively.
+	FeatureBulkDelete = "BULK_DELETE"
+)
+
+// BulkFallback decorates a bulk operation so that it is emulated through the single-item operations when the component doesn't support it,
+// callers don't need to check the component features themselves.
+// Components advertising the feature always use the native operation, the others are tried natively and emulated from then on
+// when they reply with an Unimplemented status, so components implementing the operation without advertising it keep working.
+type BulkFallback struct {
+	feature  string
+	features func() FeatureSet
+	// unimplemented is set once the component replied with an Unimplemented status to the native operation.
+	unimplemented atomic.Bool
+}
+
+// NewBulkFallback creates a bulk fallback for the given feature, the component features are read on every call.
+func NewBulkFallback(feature string, features func() FeatureSet) *BulkFallback {
+	return &BulkFallback{
+		feature:  feature,
+		features: features,
+	}
+}
+
+// Do calls the native bulk operation when supported by the component, or its emulation otherwise.
+// The operations results are expected to be captured by the given functions.
+func (b *BulkFallback) Do(native, emulated func() error) error {
+	if b.features().Has(b.feature) {
+		return native()
+	}
+	if b.unimplemented.Load() {
+		return emulated()
+	}
+
+	err := native()
+	if status.Code(err) == codes.Unimplemented || errors.Is(err, ErrFeatureNotSupported) {
+		log.Debugf("pluggable component does not implement %s, emulating it through single-item operations", b.feature)
+		b.unimplemented.Store(true)
+		return emulated()
+	}
+	return err
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+func TestBulkFallback(t *testing.T) {
+	const feature = "BULK_FAKE"
+
+	featuresOf := func(features ...string) func() FeatureSet {
+		return func() FeatureSet {
+			return NewFeatureSet(&proto.FeaturesResponse{Features: features})
+		}
+	}
+
+	// calls returns the native and emulated operations counting their calls, the native operation fails with the given error.
+	calls := func(nativeErr error) (native, emulated func() error, nativeCalls, emulatedCalls *int) {
+		nativeCalls, emulatedCalls = new(int), new(int)
+		return func() error {
+				*nativeCalls++
+				return nativeErr
+			}, func() error {
+				*emulatedCalls++
+				return nil
+			}, nativeCalls, emulatedCalls
+	}
+
+	t.Run("advertised operations should always be called natively", func(t *testing.T) {
+		unimplemented := status.Error(codes.Unimplemented, "not implemented")
+		native, emulated, nativeCalls, emulatedCalls := calls(unimplemented)
+		fallback := NewBulkFallback(feature, featuresOf(feature))
+
+		assert.Equal(t, unimplemented, fallback.Do(native, emulated))
+		assert.Equal(t, 1, *nativeCalls)
+		assert.Equal(t, 0, *emulatedCalls)
+	})
+
+	t.Run("not advertised operations should be called natively when implemented", func(t *testing.T) {
+		native, emulated, nativeCalls, emulatedCalls := calls(nil)
+		fallback := NewBulkFallback(feature, featuresOf())
+
+		assert.NoError(t, fallback.Do(native, emulated))
+		assert.NoError(t, fallback.Do(native, emulated))
+		assert.Equal(t, 2, *nativeCalls)
+		assert.Equal(t, 0, *emulatedCalls)
+	})
+
+	t.Run("not advertised operations should be emulated once known to be unimplemented", func(t *testing.T) {
+		native, emulated, nativeCalls, emulatedCalls := calls(&FeatureNotSupportedError{Feature: feature})
+		fallback := NewBulkFallback(feature, featuresOf())
+
+		assert.NoError(t, fallback.Do(native, emulated))
+		assert.NoError(t, fallback.Do(native, emulated))
+		assert.Equal(t, 1, *nativeCalls)
+		assert.Equal(t, 2, *emulatedCalls)
+	})
+
+	t.Run("other native errors should be returned without emulating", func(t *testing.T) {
+		errFailed := errors.New("failed")
+		native, emulated, _, emulatedCalls := calls(errFailed)
+		fallback := NewBulkFallback(feature, featuresOf())
+
+		assert.Equal(t, errFailed, fallback.Do(native, emulated))
+		assert.Equal(t, 0, *emulatedCalls)
+	})
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+)
+
+// DefaultCallTimeout is the max amount of time a unary data plane call made to the component can take when WithCallTimeout is not set.
+var DefaultCallTimeout = 30 * time.Second
+
+// callTimeoutOrDefault returns the configured call timeout, or the default one. Zero or negative means no timeout.
+func (o *connectorOptions) callTimeoutOrDefault() time.Duration {
+	if o.callTimeout == 0 {
+		return DefaultCallTimeout
+	}
+	return o.callTimeout
+}
+
+// callTimeoutUnaryInterceptor returns a grpc client unary interceptor that bounds the data plane calls by the call timeout, if any.
+// the calls whose context has a shorter deadline keep it. Streams are long lived and the lifecycle calls such as init may legitimately
+// take long, so they are not bounded.
+func (g *GRPCConnector[TClient]) callTimeoutUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if timeout := g.options.callTimeoutOrDefault(); timeout > 0 && !isLifecycleMethod(method) {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+// slowPublishServer is a pubsub server whose publish replies after the given delay, or when the call is cancelled.
+type slowPublishServer struct {
+	pingServer
+	delay       time.Duration
+	deadlineSet atomic.Bool
+}
+
+func (s *slowPublishServer) Publish(ctx context.Context, _ *proto.PublishRequest) (*proto.PublishResponse, error) {
+	_, deadlineSet := ctx.Deadline()
+	s.deadlineSet.Store(deadlineSet)
+	select {
+	case <-time.After(s.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &proto.PublishResponse{}, nil
+}
+
+func TestCallTimeout(t *testing.T) {
+	connectorFor := func(t *testing.T, svc *slowPublishServer, opts ...Option) *GRPCConnector[proto.PubSubClient] {
+		t.Helper()
+		connector := testConnectorFor(t, func(s *grpc.Server, svc *slowPublishServer) {
+			proto.RegisterPubSubServer(s, svc)
+		}, svc, proto.NewPubSubClient, opts...)
+		require.NoError(t, connector.Dial("my-component"))
+		return connector
+	}
+
+	t.Run("calls should be bounded by the default call timeout", func(t *testing.T) {
+		defaultCallTimeout := DefaultCallTimeout
+		DefaultCallTimeout = 50 * time.Millisecond
+		t.Cleanup(func() {
+			DefaultCallTimeout = defaultCallTimeout
+		})
+		svc := &slowPublishServer{delay: 5 * time.Second}
+		connector := connectorFor(t, svc)
+
+		start := time.Now()
+		_, err := connector.Client.Publish(context.Background(), &proto.PublishRequest{})
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Less(t, time.Since(start), time.Second)
+		assert.True(t, svc.deadlineSet.Load())
+	})
+
+	t.Run("calls should not be bounded when the call timeout is disabled", func(t *testing.T) {
+		svc := &slowPublishServer{delay: 10 * time.Millisecond}
+		_, err := connectorFor(t, svc, WithCallTimeout(-1)).Client.Publish(context.Background(), &proto.PublishRequest{})
+		require.NoError(t, err)
+		assert.False(t, svc.deadlineSet.Load())
+	})
+
+	t.Run("slow unary calls should be cut off at the call timeout", func(t *testing.T) {
+		connector := connectorFor(t, &slowPublishServer{delay: 5 * time.Second}, WithCallTimeout(50*time.Millisecond))
+
+		start := time.Now()
+		_, err := connector.Client.Publish(context.Background(), &proto.PublishRequest{})
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("fast unary calls should succeed within the call timeout", func(t *testing.T) {
+		connector := connectorFor(t, &slowPublishServer{delay: 10 * time.Millisecond}, WithCallTimeout(time.Second))
+
+		_, err := connector.Client.Publish(context.Background(), &proto.PublishRequest{})
+		require.NoError(t, err)
+	})
+
+	t.Run("a shorter deadline set by the caller should be kept", func(t *testing.T) {
+		connector := connectorFor(t, &slowPublishServer{delay: 5 * time.Second}, WithCallTimeout(time.Minute))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		_, err := connector.Client.Publish(ctx, &proto.PublishRequest{})
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("lifecycle calls should not be bounded", func(t *testing.T) {
+		var deadlineSet bool
+		svc := &slowPublishServer{pingServer: pingServer{onPing: func(ctx context.Context) {
+			_, deadlineSet = ctx.Deadline()
+		}}}
+		connector := connectorFor(t, svc, WithCallTimeout(50*time.Millisecond))
+
+		require.NoError(t, connector.PingContext(context.Background(), false))
+		assert.False(t, deadlineSet)
+	})
+}
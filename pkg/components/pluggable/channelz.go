@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	channelzpb "google.golang.org/grpc/channelz/grpc_channelz_v1"
+	channelzsvc "google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// channelzRegistry holds the targets of the pluggable component connections registered with channelz, see WithChannelz.
+type channelzRegistry struct {
+	lock sync.RWMutex
+	// targets counts the connections registered per target, connections can be shared by many connectors.
+	targets map[string]int
+}
+
+// channelzConns is the registry of the pluggable component connections exposed through channelz.
+var channelzConns = &channelzRegistry{targets: map[string]int{}}
+
+func (r *channelzRegistry) add(target string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.targets[target]++
+}
+
+func (r *channelzRegistry) remove(target string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	if r.targets[target] <= 1 {
+		delete(r.targets, target)
+		return
+	}
+	r.targets[target]--
+}
+
+func (r *channelzRegistry) has(target string) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.targets[target] > 0
+}
+
+// serviceCapture is a service registrar that captures the registered service implementation.
+type serviceCapture struct {
+	impl any
+}
+
+func (c *serviceCapture) RegisterService(_ *grpc.ServiceDesc, impl any) {
+	c.impl = impl
+}
+
+// RegisterChannelzService registers the channelz service to the given server. It exposes the channel, subchannel and socket stats
+// of the pluggable component connections dialed with channelz enabled, see WithChannelz. Other channels and servers are not exposed.
+func RegisterChannelzService(s grpc.ServiceRegistrar) {
+	capture := &serviceCapture{}
+	channelzsvc.RegisterChannelzServiceToServer(capture)
+	channelzpb.RegisterChannelzServer(s, &pluggableChannelzServer{
+		ChannelzServer: capture.impl.(channelzpb.ChannelzServer),
+		registry:       channelzConns,
+	})
+}
+
+// pluggableChannelzServer is a channelz server restricted to the pluggable component connections.
+type pluggableChannelzServer struct {
+	channelzpb.ChannelzServer
+	registry *channelzRegistry
+}
+
+func (s *pluggableChannelzServer) GetTopChannels(ctx context.Context, req *channelzpb.GetTopChannelsRequest) (*channelzpb.GetTopChannelsResponse, error) {
+	resp, err := s.ChannelzServer.GetTopChannels(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	channels := make([]*channelzpb.Channel, 0, len(resp.GetChannel()))
+	for _, ch := range resp.GetChannel() {
+		if s.registry.has(ch.GetData().GetTarget()) {
+			channels = append(channels, ch)
+		}
+	}
+	resp.Channel = channels
+	return resp, nil
+}
+
+func (s *pluggableChannelzServer) GetChannel(ctx context.Context, req *channelzpb.GetChannelRequest) (*channelzpb.GetChannelResponse, error) {
+	resp, err := s.ChannelzServer.GetChannel(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if !s.registry.has(resp.GetChannel().GetData().GetTarget()) {
+		return nil, status.Errorf(codes.NotFound, "requested channel %d not found", req.GetChannelId())
+	}
+	return resp, nil
+}
+
+func (s *pluggableChannelzServer) GetServers(context.Context, *channelzpb.GetServersRequest) (*channelzpb.GetServersResponse, error) {
+	return &channelzpb.GetServersResponse{End: true}, nil
+}
+
+func (s *pluggableChannelzServer) GetServer(_ context.Context, req *channelzpb.GetServerRequest) (*channelzpb.GetServerResponse, error) {
+	return nil, status.Errorf(codes.NotFound, "requested server %d not found", req.GetServerId())
+}
+
+func (s *pluggableChannelzServer) GetServerSockets(_ context.Context, req *channelzpb.GetServerSocketsRequest) (*channelzpb.GetServerSocketsResponse, error) {
+	return nil, status.Errorf(codes.NotFound, "requested server %d not found", req.GetServerId())
+}
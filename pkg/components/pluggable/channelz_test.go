@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	channelzpb "google.golang.org/grpc/channelz/grpc_channelz_v1"
+	channelzsvc "google.golang.org/grpc/channelz/service"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// channelzServer returns the channelz server registered by RegisterChannelzService.
+func channelzServer(t *testing.T) channelzpb.ChannelzServer {
+	t.Helper()
+	capture := &serviceCapture{}
+	RegisterChannelzService(capture)
+	srv, ok := capture.impl.(channelzpb.ChannelzServer)
+	require.True(t, ok)
+	return srv
+}
+
+// rawChannelzServer returns a channelz server exposing every channel of the process, unlike the one registered by RegisterChannelzService.
+func rawChannelzServer(t *testing.T) channelzpb.ChannelzServer {
+	t.Helper()
+	capture := &serviceCapture{}
+	channelzsvc.RegisterChannelzServiceToServer(capture)
+	srv, ok := capture.impl.(channelzpb.ChannelzServer)
+	require.True(t, ok)
+	return srv
+}
+
+// topChannels returns the top channels exposed by the given channelz server, following the pagination.
+func topChannels(t *testing.T, srv channelzpb.ChannelzServer) []*channelzpb.Channel {
+	t.Helper()
+	var channels []*channelzpb.Channel
+	start := int64(0)
+	for {
+		resp, err := srv.GetTopChannels(context.Background(), &channelzpb.GetTopChannelsRequest{StartChannelId: start})
+		require.NoError(t, err)
+		channels = append(channels, resp.GetChannel()...)
+		if resp.GetEnd() || len(resp.GetChannel()) == 0 {
+			return channels
+		}
+		start = resp.GetChannel()[len(resp.GetChannel())-1].GetRef().GetChannelId() + 1
+	}
+}
+
+// channelIDs returns the ids of the top channels exposed by the given channelz server.
+func channelIDs(t *testing.T, srv channelzpb.ChannelzServer) map[int64]bool {
+	t.Helper()
+	ids := make(map[int64]bool)
+	for _, ch := range topChannels(t, srv) {
+		ids[ch.GetRef().GetChannelId()] = true
+	}
+	return ids
+}
+
+// newChannelIDs returns the ids of the top channels exposed by the given channelz server that are not in the given known ids,
+// so that the channels created by other tests are ignored.
+func newChannelIDs(t *testing.T, srv channelzpb.ChannelzServer, known map[int64]bool) []int64 {
+	t.Helper()
+	var ids []int64
+	for id := range channelIDs(t, srv) {
+		if !known[id] {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+func TestChannelz(t *testing.T) {
+	srv, raw := channelzServer(t), rawChannelzServer(t)
+
+	t.Run("connections should not be registered with channelz by default", func(t *testing.T) {
+		known := channelIDs(t, raw)
+		connector := testPubSubConnectorFor(t, &pingServer{})
+		require.NoError(t, connector.Dial("my-component"))
+
+		assert.Empty(t, connector.channelzTarget)
+		own := newChannelIDs(t, raw, known)
+		require.NotEmpty(t, own)
+		exposed := channelIDs(t, srv)
+		for _, id := range own {
+			assert.False(t, exposed[id])
+		}
+	})
+
+	t.Run("connections should be registered with channelz when enabled", func(t *testing.T) {
+		known := channelIDs(t, raw)
+		connector := testPubSubConnectorFor(t, &pingServer{}, WithChannelz(true))
+		require.NoError(t, connector.Dial("my-component"))
+		require.NoError(t, connector.Ping())
+
+		own := newChannelIDs(t, srv, known)
+		require.Len(t, own, 1)
+		channel, err := srv.GetChannel(context.Background(), &channelzpb.GetChannelRequest{ChannelId: own[0]})
+		require.NoError(t, err)
+		assert.Equal(t, connector.conn.Target(), channel.GetChannel().GetData().GetTarget())
+		assert.NotZero(t, channel.GetChannel().GetData().GetCallsSucceeded())
+
+		require.NoError(t, connector.Close())
+		assert.False(t, channelIDs(t, srv)[own[0]])
+	})
+
+	t.Run("connections other than the pluggable component ones should not be exposed", func(t *testing.T) {
+		known := channelIDs(t, raw)
+		conn, err := grpc.Dial("passthrough:///not-a-pluggable-component", grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		t.Cleanup(func() { conn.Close() })
+
+		own := newChannelIDs(t, raw, known)
+		require.Len(t, own, 1)
+		assert.False(t, channelIDs(t, srv)[own[0]])
+
+		servers, err := srv.GetServers(context.Background(), &channelzpb.GetServersRequest{})
+		require.NoError(t, err)
+		assert.Empty(t, servers.GetServer())
+	})
+}
@@ -0,0 +1,90 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+// connectionStates counts the pluggable component connections by connectivity state.
+type connectionStates struct {
+	lock   sync.Mutex
+	counts map[connectivity.State]int64
+	// record reports the current count of the given state.
+	record func(state connectivity.State, count int64)
+}
+
+// newConnectionStates creates a new connection states counter that reports the counts using the given function.
+func newConnectionStates(record func(state connectivity.State, count int64)) *connectionStates {
+	return &connectionStates{
+		counts: make(map[connectivity.State]int64),
+		record: record,
+	}
+}
+
+// trackedConnections holds the connectivity states of all connections opened by the connectors.
+var trackedConnections = newConnectionStates(func(state connectivity.State, count int64) {
+	diag.DefaultPluggableComponentMonitoring.ConnectionsChanged(context.Background(), state.String(), count)
+})
+
+// transition moves a connection from the given state to the next one, a nil from state means a new connection.
+func (c *connectionStates) transition(from *connectivity.State, to connectivity.State) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if from != nil {
+		c.counts[*from]--
+		c.record(*from, c.counts[*from])
+	}
+	c.counts[to]++
+	c.record(to, c.counts[to])
+}
+
+// count returns the number of connections in the given state.
+func (c *connectionStates) count(state connectivity.State) int64 {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.counts[state]
+}
+
+// watch tracks the connectivity state changes of the given connection until it is shut down or the context is done.
+// the given observers are called with every state the connection goes through, starting from its current one.
+func (c *connectionStates) watch(ctx context.Context, conn *grpc.ClientConn, observers ...func(connectivity.State)) {
+	observe := func(state connectivity.State) {
+		for _, observer := range observers {
+			observer(state)
+		}
+	}
+
+	state := conn.GetState()
+	c.transition(nil, state)
+	observe(state)
+	for state != connectivity.Shutdown {
+		if !conn.WaitForStateChange(ctx, state) { // the connector is closing.
+			c.transition(&state, connectivity.Shutdown)
+			observe(connectivity.Shutdown)
+			return
+		}
+		next := conn.GetState()
+		c.transition(&state, next)
+		observe(next)
+		state = next
+	}
+}
@@ -0,0 +1,109 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+	testingGrpc "github.com/dapr/dapr/pkg/testing/grpc"
+)
+
+func TestConnectionStates(t *testing.T) {
+	t.Run("transition should move connections between states and report the counts", func(t *testing.T) {
+		recorded := map[connectivity.State]int64{}
+		states := newConnectionStates(func(state connectivity.State, count int64) {
+			recorded[state] = count
+		})
+
+		idle, ready := connectivity.Idle, connectivity.Ready
+		states.transition(nil, connectivity.Idle)
+		states.transition(nil, connectivity.Idle)
+		states.transition(&idle, connectivity.Ready)
+		assert.Equal(t, map[connectivity.State]int64{connectivity.Idle: 1, connectivity.Ready: 1}, recorded)
+
+		states.transition(&ready, connectivity.Shutdown)
+		assert.Equal(t, map[connectivity.State]int64{connectivity.Idle: 1, connectivity.Ready: 0, connectivity.Shutdown: 1}, recorded)
+	})
+
+	t.Run("watch should follow the connection states until it is shut down", func(t *testing.T) {
+		var lock sync.Mutex
+		recorded := map[connectivity.State]int64{}
+		states := newConnectionStates(func(state connectivity.State, count int64) {
+			lock.Lock()
+			defer lock.Unlock()
+			recorded[state] = count
+		})
+		recordedOf := func(state connectivity.State) int64 {
+			lock.Lock()
+			defer lock.Unlock()
+			return recorded[state]
+		}
+
+		dialer, cleanup, err := testingGrpc.TestServerWithDialer(testLogger, func(s *grpc.Server, svc *pingServer) {
+			proto.RegisterPubSubServer(s, svc)
+		})(&pingServer{})
+		require.NoError(t, err)
+		defer cleanup()
+
+		conn, err := dialer(context.Background())
+		require.NoError(t, err)
+
+		watched := make(chan struct{})
+		go func() {
+			defer close(watched)
+			states.watch(context.Background(), conn)
+		}()
+
+		_, err = proto.NewPubSubClient(conn).Ping(context.Background(), &proto.PingRequest{}, grpc.WaitForReady(true))
+		require.NoError(t, err)
+		assert.Eventually(t, func() bool {
+			return states.count(connectivity.Ready) == 1 && recordedOf(connectivity.Ready) == 1
+		}, time.Second, time.Millisecond)
+
+		require.NoError(t, conn.Close())
+		<-watched
+		assert.Equal(t, int64(0), states.count(connectivity.Ready))
+		assert.Equal(t, int64(1), states.count(connectivity.Shutdown))
+		assert.Equal(t, int64(1), recordedOf(connectivity.Shutdown))
+	})
+
+	t.Run("dial should track the connection until the connector is closed", func(t *testing.T) {
+		dialer, cleanup, err := testingGrpc.TestServerWithDialer(testLogger, func(s *grpc.Server, svc *pingServer) {
+			proto.RegisterPubSubServer(s, svc)
+		})(&pingServer{})
+		require.NoError(t, err)
+		defer cleanup()
+
+		shutdownBefore := trackedConnections.count(connectivity.Shutdown)
+		connector := NewGRPCConnectorWithDialer(func(ctx context.Context, _ string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+			return dialer(ctx, opts...)
+		}, proto.NewPubSubClient)
+		require.NoError(t, connector.Dial("my-component"))
+		require.NoError(t, connector.Ping())
+
+		require.NoError(t, connector.Close())
+		assert.Eventually(t, func() bool {
+			return trackedConnections.count(connectivity.Shutdown) == shutdownBefore+1
+		}, time.Second, time.Millisecond)
+	})
+}
@@ -0,0 +1,59 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"sync"
+)
+
+// dialLimiter bounds the number of pluggable component dials in progress at the same time, the others are queued.
+type dialLimiter struct {
+	lock sync.RWMutex
+	// slots holds a token per dial in progress, nil means unlimited.
+	slots chan struct{}
+}
+
+// dials is the limiter shared by all the pluggable component connectors.
+var dials = &dialLimiter{}
+
+// SetMaxConcurrentDials limits the number of pluggable component dials in progress at the same time to n, so that mass startups
+// don't overwhelm the components sharing a process. The dials above the limit wait for a dial in progress to complete.
+// Zero or a negative value means unlimited, the default. Dials already waiting keep waiting on the previous limit.
+func SetMaxConcurrentDials(n int) {
+	dials.lock.Lock()
+	defer dials.lock.Unlock()
+	if n <= 0 {
+		dials.slots = nil
+		return
+	}
+	dials.slots = make(chan struct{}, n)
+}
+
+// acquire waits for a dial slot and returns the function that releases it, it returns the context error when done while waiting.
+func (l *dialLimiter) acquire(ctx context.Context) (func(), error) {
+	l.lock.RLock()
+	slots := l.slots
+	l.lock.RUnlock()
+	if slots == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case slots <- struct{}{}:
+		return func() { <-slots }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+var errFakeDial = errors.New("fake dial error")
+
+func TestMaxConcurrentDials(t *testing.T) {
+	// dialConcurrently dials the given number of connectors at the same time and returns the max number of dials in progress at once.
+	dialConcurrently := func(t *testing.T, connectors int) int64 {
+		t.Helper()
+		var inProgress, maxInProgress atomic.Int64
+		dialer := func(context.Context, string, ...grpc.DialOption) (*grpc.ClientConn, error) {
+			current := inProgress.Add(1)
+			defer inProgress.Add(-1)
+			for {
+				highest := maxInProgress.Load()
+				if current <= highest || maxInProgress.CompareAndSwap(highest, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			return nil, errFakeDial
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < connectors; i++ {
+			connector := NewGRPCConnectorWithDialer(dialer, proto.NewPubSubClient, withRegistry(NewRegistry()))
+			t.Cleanup(func() { connector.Close() })
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				assert.ErrorIs(t, connector.Dial("my-component"), errFakeDial)
+			}()
+		}
+		wg.Wait()
+		return maxInProgress.Load()
+	}
+
+	t.Run("dials should not be limited by default", func(t *testing.T) {
+		assert.Greater(t, dialConcurrently(t, 6), int64(1))
+	})
+
+	t.Run("the dials in progress should never exceed the limit", func(t *testing.T) {
+		SetMaxConcurrentDials(2)
+		t.Cleanup(func() { SetMaxConcurrentDials(0) })
+
+		assert.LessOrEqual(t, dialConcurrently(t, 6), int64(2))
+	})
+
+	t.Run("dials waiting for a slot should fail when the connector is closed", func(t *testing.T) {
+		SetMaxConcurrentDials(1)
+		t.Cleanup(func() { SetMaxConcurrentDials(0) })
+		release, err := dials.acquire(context.Background())
+		require.NoError(t, err)
+		defer release()
+
+		connector := NewGRPCConnectorWithDialer(func(context.Context, string, ...grpc.DialOption) (*grpc.ClientConn, error) {
+			return nil, errFakeDial
+		}, proto.NewPubSubClient, withRegistry(NewRegistry()))
+		dialed := make(chan error, 1)
+		go func() { dialed <- connector.Dial("my-component") }()
+		require.NoError(t, connector.Close())
+
+		select {
+		case err := <-dialed:
+			require.ErrorIs(t, err, context.Canceled)
+		case <-time.After(time.Second):
+			require.Fail(t, "the dial should not wait for a slot once the connector is closed")
+		}
+	})
+}
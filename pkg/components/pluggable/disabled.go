@@ -0,0 +1,79 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/dapr/dapr/utils"
+)
+
+// DisabledMetadataKey is the component metadata key used to disable a pluggable component without removing it.
+const DisabledMetadataKey = "dapr.io/component-disabled"
+
+// IsDisabled returns true when the given component metadata properties mark the component as disabled.
+func IsDisabled(properties map[string]string) bool {
+	return utils.IsTruthy(properties[DisabledMetadataKey])
+}
+
+// disabledConn is a client connection that fails every call with ErrComponentDisabled.
+type disabledConn struct{}
+
+func (disabledConn) Invoke(context.Context, string, any, any, ...grpc.CallOption) error {
+	return ErrComponentDisabled
+}
+
+func (disabledConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, ErrComponentDisabled
+}
+
+// Disable replaces the component client by a stub that returns ErrComponentDisabled on every call, without dialing the component socket.
+func (g *GRPCConnector[TClient]) Disable(name string) {
+	g.logger.Warnf("pluggable component instance '%s' is disabled by the '%s' metadata, skipping dial", name, DisabledMetadataKey)
+	g.Client = g.clientFactory(disabledConn{})
+	g.loaded(name)
+}
+
+// InitOrDisable calls the given init function only once, see InitOnce.
+// When the component metadata marks it as disabled the component is not dialed and its operations return ErrComponentDisabled.
+// The gRPC metadata set through the component metadata, see GRPCMetadataPrefix, is sent on every rpc.
+// The socket path set through the component metadata, see SocketPathMetadataKey, is dialed instead of the connector socket.
+// The component logs are forwarded to the sidecar logger once initialized when enabled, see ForwardLogsMetadataKey.
+// The connector options set through the component metadata are applied before dialing, see optionsFromMetadata.
+func (g *GRPCConnector[TClient]) InitOrDisable(name string, properties map[string]string, init func() error) error {
+	return g.InitOnce(name, func() error {
+		if IsDisabled(properties) {
+			g.Disable(name)
+			return nil
+		}
+		socket, err := socketPathOf(properties)
+		if err != nil {
+			return err
+		}
+		g.socketPath = socket
+		if err := g.applyMetadataOptions(properties); err != nil {
+			return err
+		}
+		g.setGRPCMetadata(properties)
+		if err := init(); err != nil {
+			return err
+		}
+		if ForwardsLogs(properties) {
+			go g.forwardLogs(name)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+func TestInitOrDisable(t *testing.T) {
+	t.Run("disabled component should not dial and return disabled errors", func(t *testing.T) {
+		dialCalled := 0
+		connector := NewGRPCConnectorWithDialer(func(context.Context, string, ...grpc.DialOption) (*grpc.ClientConn, error) {
+			dialCalled++
+			return nil, nil
+		}, proto.NewStateStoreClient)
+
+		initCalled := 0
+		err := connector.InitOrDisable("my-component", map[string]string{DisabledMetadataKey: "true"}, func() error {
+			initCalled++
+			return connector.Dial("my-component")
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 0, initCalled)
+		assert.Equal(t, 0, dialCalled)
+
+		_, err = connector.Client.Get(context.Background(), &proto.GetRequest{Key: "key"})
+		assert.ErrorIs(t, err, ErrComponentDisabled)
+		assert.ErrorIs(t, connector.PingContext(context.Background(), false), ErrComponentDisabled)
+		assert.NoError(t, connector.Close())
+	})
+
+	t.Run("enabled component should be initialized", func(t *testing.T) {
+		connector := NewGRPCConnectorWithConn(&fakeClient{}, &grpc.ClientConn{})
+
+		initCalled := 0
+		init := func() error {
+			initCalled++
+			return nil
+		}
+		require.NoError(t, connector.InitOrDisable("my-component", map[string]string{DisabledMetadataKey: "false"}, init))
+		assert.Equal(t, 1, initCalled)
+		assert.True(t, connector.Initialized())
+	})
+}
+
+func TestIsDisabled(t *testing.T) {
+	assert.True(t, IsDisabled(map[string]string{DisabledMetadataKey: "true"}))
+	assert.True(t, IsDisabled(map[string]string{DisabledMetadataKey: "1"}))
+	assert.False(t, IsDisabled(map[string]string{DisabledMetadataKey: "false"}))
+	assert.False(t, IsDisabled(map[string]string{}))
+	assert.False(t, IsDisabled(nil))
+}
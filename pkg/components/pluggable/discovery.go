@@ -18,6 +18,9 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"unicode"
 
 	"github.com/jhump/protoreflect/grpcreflect"
 	"google.golang.org/grpc"
@@ -41,6 +44,16 @@ func AddServiceDiscoveryCallback(serviceName string, callbackFunc func(name stri
 	onServiceDiscovered[serviceName] = callbackFunc
 }
 
+// SupportedServices returns the sorted gRPC service names of the pluggable component types supported by the runtime.
+func SupportedServices() []string {
+	services := make([]string, 0, len(onServiceDiscovered))
+	for service := range onServiceDiscovered {
+		services = append(services, service)
+	}
+	sort.Strings(services)
+	return services
+}
+
 // removeExt removes file extension
 func removeExt(fileName string) string {
 	return fileName[:len(fileName)-len(filepath.Ext(fileName))]
@@ -56,6 +69,72 @@ func GetSocketFolderPath() string {
 	return utils.GetEnvOrElse(SocketFolderEnvVar, defaultSocketFolder)
 }
 
+// SocketClassFolderEnvVarPrefix is the prefix of the environment variables holding the sockets folder of each socket volume class,
+// e.g. DAPR_PLUGGABLE_SOCKETS_FOLDER_FAST=/tmp/dapr-components-sockets-fast, as set by the sidecar injector.
+// components are discovered from these folders in addition to the shared sockets folder.
+const SocketClassFolderEnvVarPrefix = "DAPR_PLUGGABLE_SOCKETS_FOLDER_"
+
+// socketFolder is a folder holding pluggable components sockets.
+type socketFolder struct {
+	path string
+	// envVar is the environment variable that sets the folder.
+	envVar string
+}
+
+// socketFolders returns the folders to discover the pluggable components from: the shared sockets folder
+// followed by the sockets folder of each socket volume class, sorted by their environment variable name.
+func socketFolders() []socketFolder {
+	classes := []socketFolder{}
+	for _, env := range os.Environ() {
+		key, folder, ok := strings.Cut(env, "=")
+		if !ok || folder == "" || !strings.HasPrefix(key, SocketClassFolderEnvVarPrefix) {
+			continue
+		}
+		classes = append(classes, socketFolder{path: folder, envVar: key})
+	}
+	sort.Slice(classes, func(i, j int) bool {
+		return classes[i].envVar < classes[j].envVar
+	})
+
+	shared := socketFolder{path: GetSocketFolderPath(), envVar: SocketFolderEnvVar}
+	return append([]socketFolder{shared}, classes...)
+}
+
+// SocketNameEnvVarPrefix is the prefix of the environment variables that override the socket file name of a component,
+// e.g. DAPR_PLUGGABLE_SOCKET_NAME_MY_COMPONENT=mycomp.sock makes the 'mycomp.sock' socket be used as the 'my-component' component.
+// it is meant for local development where component authors want a fixed socket name.
+const SocketNameEnvVarPrefix = "DAPR_PLUGGABLE_SOCKET_NAME_"
+
+// socketNameEnvVar returns the socket name override environment variable for the given component name.
+func socketNameEnvVar(componentName string) string {
+	return SocketNameEnvVarPrefix + strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToUpper(r)
+		}
+		return '_'
+	}, componentName)
+}
+
+// socketFileFor returns the socket file name of the given component, using the overridden name when set.
+func socketFileFor(componentName string) string {
+	return utils.GetEnvOrElse(socketNameEnvVar(componentName), componentName+requiredSocketExt)
+}
+
+// socketNameOverrides returns the component names indexed by their overridden socket file names.
+// component names are derived from the environment variable suffix as lower case words separated by '-'.
+func socketNameOverrides() map[string]string {
+	overrides := make(map[string]string)
+	for _, env := range os.Environ() {
+		key, socketName, ok := strings.Cut(env, "=")
+		if !ok || socketName == "" || !strings.HasPrefix(key, SocketNameEnvVarPrefix) {
+			continue
+		}
+		componentName := strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(key, SocketNameEnvVarPrefix), "_", "-"))
+		overrides[socketName] = componentName
+	}
+	return overrides
+}
+
 type service struct {
 	// protoRef is the proto service name
 	protoRef string
@@ -63,6 +142,8 @@ type service struct {
 	componentName string
 	// dialer is the used grpc connectiondialer.
 	dialer GRPCConnectionDialer
+	// socket is the component socket path.
+	socket string
 }
 
 type reflectServiceClient interface {
@@ -78,23 +159,88 @@ type grpcConnectionCloser interface {
 // uses gRPC reflection package to list implemented services.
 func serviceDiscovery(reflectClientFactory func(string) (reflectServiceClient, func(), error)) ([]service, error) {
 	services := []service{}
-	componentsSocketPath := GetSocketFolderPath()
-	_, err := os.Stat(componentsSocketPath)
 
+	// componentSockets holds the sockets of each component, a component can have many replicas.
+	componentSockets := make(map[string][]string)
+	componentServices := make(map[string][]string)
+	componentNames := []string{}
+	overrides := socketNameOverrides()
+	for _, folder := range socketFolders() {
+		sockets, err := listSockets(folder)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, socket := range sockets {
+			refctClient, cleanup, err := reflectClientFactory(socket)
+			if err != nil {
+				return nil, err
+			}
+			defer cleanup()
+
+			serviceList, err := refctClient.ListServices()
+			if err != nil {
+				return nil, fmt.Errorf("unable to list services: %w", err)
+			}
+
+			fileName := filepath.Base(socket)
+			componentName := componentNameOf(fileName)
+			if name, ok := overrides[fileName]; ok {
+				discoveryLog.Infof("using socket '%s' for component '%s' as overridden by the environment", socket, name)
+				componentName = name
+			}
+			if _, ok := componentSockets[componentName]; !ok {
+				componentNames = append(componentNames, componentName)
+				componentServices[componentName] = serviceList
+			}
+			componentSockets[componentName] = append(componentSockets[componentName], socket)
+		}
+	}
+
+	for _, componentName := range componentNames {
+		sockets := componentSockets[componentName]
+		dialer := socketDialer(sockets[0], grpc.WithBlock(), grpc.FailOnNonTempDialError(true))
+		if len(sockets) > 1 { // replicas of the same component are load balanced.
+			dialer = multiSocketDialer(sockets, grpc.WithBlock())
+		}
+
+		for _, svc := range componentServices[componentName] {
+			services = append(services, service{
+				componentName: componentName,
+				protoRef:      svc,
+				dialer:        dialer,
+				socket:        sockets[0],
+			})
+		}
+	}
+	log.Debugf("found %d pluggable component services", len(services)-1) // reflection api doesn't count.
+	return services, nil
+}
+
+// listSockets returns the usable unix domain sockets of the given folder, a missing folder has no sockets.
+func listSockets(folder socketFolder) ([]string, error) {
+	info, err := os.Stat(folder.path)
 	if os.IsNotExist(err) { // not exists is the same as empty.
-		return services, nil
+		if _, overridden := os.LookupEnv(folder.envVar); overridden {
+			log.Warnf("the pluggable components sockets folder '%s' set through %s does not exist, no pluggable component will be discovered from it, make sure the folder is mounted", folder.path, folder.envVar)
+		}
+		return nil, nil
 	}
 
-	log.Debugf("loading pluggable components under path %s", componentsSocketPath)
+	log.Debugf("loading pluggable components under path %s", folder.path)
 	if err != nil {
 		return nil, err
 	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("the pluggable components sockets folder '%s' is not a directory, check the %s environment variable", folder.path, folder.envVar)
+	}
 
-	files, err := os.ReadDir(componentsSocketPath)
+	files, err := os.ReadDir(folder.path)
 	if err != nil {
 		return nil, fmt.Errorf("could not list pluggable components unix sockets: %w", err)
 	}
 
+	sockets := make([]string, 0, len(files))
 	for _, dirEntry := range files {
 		if dirEntry.IsDir() { // skip dirs
 			continue
@@ -105,35 +251,19 @@ func serviceDiscovery(reflectClientFactory func(string) (reflectServiceClient, f
 			return nil, err
 		}
 
-		socket := filepath.Join(componentsSocketPath, f.Name())
+		socket := filepath.Join(folder.path, f.Name())
 		if !utils.IsSocket(f) {
 			discoveryLog.Warnf("could not use socket for file %s", socket)
 			continue
 		}
 
-		refctClient, cleanup, err := reflectClientFactory(socket)
-		if err != nil {
-			return nil, err
-		}
-		defer cleanup()
-
-		serviceList, err := refctClient.ListServices()
-		if err != nil {
-			return nil, fmt.Errorf("unable to list services: %w", err)
-		}
-		dialer := socketDialer(socket, grpc.WithBlock(), grpc.FailOnNonTempDialError(true))
-
-		componentName := removeExt(f.Name())
-		for _, svc := range serviceList {
-			services = append(services, service{
-				componentName: componentName,
-				protoRef:      svc,
-				dialer:        dialer,
-			})
+		if err := checkSocketNamespace(socket); err != nil {
+			discoveryLog.Warnf("skipping socket %s: %v", socket, err)
+			continue
 		}
+		sockets = append(sockets, socket)
 	}
-	log.Debugf("found %d pluggable component services", len(services)-1) // reflection api doesn't count.
-	return services, nil
+	return sockets, nil
 }
 
 // callback invoke callback function for each given service
@@ -175,5 +305,6 @@ func Discover(ctx context.Context) error {
 	}
 
 	callback(services)
+	setDiscoveredServices(services)
 	return nil
 }
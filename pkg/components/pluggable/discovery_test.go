@@ -14,6 +14,7 @@ limitations under the License.
 package pluggable
 
 import (
+	"bytes"
 	"errors"
 	"net"
 	"os"
@@ -23,6 +24,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/kit/logger"
 )
 
 type fakeReflectService struct {
@@ -64,6 +67,18 @@ func TestServiceCallback(t *testing.T) {
 	})
 }
 
+func TestSupportedServices(t *testing.T) {
+	t.Run("supported services should list the registered services sorted", func(t *testing.T) {
+		AddServiceDiscoveryCallback("fake.z.Service", func(string, GRPCConnectionDialer) {})
+		AddServiceDiscoveryCallback("fake.a.Service", func(string, GRPCConnectionDialer) {})
+
+		services := SupportedServices()
+		assert.Contains(t, services, "fake.z.Service")
+		assert.Contains(t, services, "fake.a.Service")
+		assert.IsIncreasing(t, services)
+	})
+}
+
 func TestConnectionCloser(t *testing.T) {
 	t.Run("connection closer should call grpc close and client reset", func(t *testing.T) {
 		const close, reset = "close", "reset"
@@ -100,6 +115,37 @@ func TestComponentDiscovery(t *testing.T) {
 		require.NoError(t, err)
 		assert.Empty(t, services)
 	})
+	t.Run("serviceDiscovery should warn when the overridden sockets folder does not exist", func(t *testing.T) {
+		buf := &bytes.Buffer{}
+		log.SetOutput(buf)
+		log.SetOutputLevel(logger.WarnLevel)
+		t.Cleanup(func() {
+			log.SetOutput(os.Stdout)
+			log.SetOutputLevel(logger.InfoLevel)
+		})
+
+		const missingSocketFolder = "/tmp/dapr-missing-sockets-folder"
+		t.Setenv(SocketFolderEnvVar, missingSocketFolder)
+
+		services, err := serviceDiscovery(func(string) (reflectServiceClient, func(), error) {
+			return &fakeReflectService{}, func() {}, nil
+		})
+		require.NoError(t, err)
+		assert.Empty(t, services)
+		assert.Contains(t, buf.String(), missingSocketFolder)
+	})
+	t.Run("serviceDiscovery should return an error when the sockets folder is not a directory", func(t *testing.T) {
+		f, err := os.CreateTemp("/tmp", "not-a-folder")
+		require.NoError(t, err)
+		f.Close()
+		defer os.Remove(f.Name())
+		t.Setenv(SocketFolderEnvVar, f.Name())
+
+		_, err = serviceDiscovery(func(string) (reflectServiceClient, func(), error) {
+			return &fakeReflectService{}, func() {}, nil
+		})
+		assert.ErrorContains(t, err, "not a directory")
+	})
 	t.Run("serviceDiscovery should not connect to service that isn't a unix domain socket", func(t *testing.T) {
 		const fakeSocketFolder, pattern = "/tmp/test", "fake"
 		err := os.MkdirAll(fakeSocketFolder, os.ModePerm)
@@ -186,6 +232,87 @@ func TestComponentDiscovery(t *testing.T) {
 		assert.Len(t, services, len(svcList))
 		assert.Equal(t, int64(1), reflectService.listServicesCalled.Load())
 	})
+	t.Run("serviceDiscovery should group the replicas sockets of the same component", func(t *testing.T) {
+		const fakeSocketFolder = "/tmp/test"
+		err := os.MkdirAll(fakeSocketFolder, os.ModePerm)
+		defer os.RemoveAll(fakeSocketFolder)
+		require.NoError(t, err)
+		t.Setenv(SocketFolderEnvVar, fakeSocketFolder)
+
+		for _, fileName := range []string{"/comp@1.sock", "/comp@2.sock", "/other.sock"} {
+			listener, err := net.Listen("unix", fakeSocketFolder+fileName)
+			require.NoError(t, err)
+			defer listener.Close()
+		}
+
+		reflectService := &fakeReflectService{
+			listServicesResp: []string{"svcA"},
+		}
+
+		services, err := serviceDiscovery(func(string) (reflectServiceClient, func(), error) {
+			return reflectService, func() {}, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), reflectService.listServicesCalled.Load())
+		require.Len(t, services, 2)
+		assert.Equal(t, "comp", services[0].componentName)
+		assert.Equal(t, fakeSocketFolder+"/comp@1.sock", services[0].socket)
+		assert.Equal(t, "other", services[1].componentName)
+	})
+	t.Run("serviceDiscovery should discover the components of the socket volume classes folders", func(t *testing.T) {
+		const fakeSocketFolder, fastSocketFolder = "/tmp/test", "/tmp/test-fast"
+		for _, folder := range []string{fakeSocketFolder, fastSocketFolder} {
+			err := os.MkdirAll(folder, os.ModePerm)
+			defer os.RemoveAll(folder)
+			require.NoError(t, err)
+		}
+		t.Setenv(SocketFolderEnvVar, fakeSocketFolder)
+		t.Setenv(SocketClassFolderEnvVarPrefix+"FAST", fastSocketFolder)
+		t.Setenv(SocketClassFolderEnvVarPrefix+"MISSING", "/tmp/test-missing")
+
+		for _, socket := range []string{fakeSocketFolder + "/shared.sock", fastSocketFolder + "/fast.sock"} {
+			listener, err := net.Listen("unix", socket)
+			require.NoError(t, err)
+			defer listener.Close()
+		}
+
+		services, err := serviceDiscovery(func(string) (reflectServiceClient, func(), error) {
+			return &fakeReflectService{listServicesResp: []string{"svcA"}}, func() {}, nil
+		})
+		require.NoError(t, err)
+		require.Len(t, services, 2)
+		assert.Equal(t, "shared", services[0].componentName)
+		assert.Equal(t, "fast", services[1].componentName)
+		assert.Equal(t, fastSocketFolder+"/fast.sock", services[1].socket)
+	})
+	t.Run("serviceDiscovery should skip the sockets scoped to other namespaces", func(t *testing.T) {
+		const fakeSocketFolder = "/tmp/test"
+		err := os.MkdirAll(fakeSocketFolder, os.ModePerm)
+		defer os.RemoveAll(fakeSocketFolder)
+		require.NoError(t, err)
+		t.Setenv(SocketFolderEnvVar, fakeSocketFolder)
+		SetAppIdentity("", "my-namespace")
+		t.Cleanup(func() { SetAppIdentity("", "") })
+
+		for _, fileName := range []string{"/ns~my-namespace~comp.sock", "/ns~other-namespace~other.sock", "/shared.sock"} {
+			listener, err := net.Listen("unix", fakeSocketFolder+fileName)
+			require.NoError(t, err)
+			defer listener.Close()
+		}
+
+		reflectService := &fakeReflectService{
+			listServicesResp: []string{"svcA"},
+		}
+
+		services, err := serviceDiscovery(func(string) (reflectServiceClient, func(), error) {
+			return reflectService, func() {}, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), reflectService.listServicesCalled.Load())
+		require.Len(t, services, 2)
+		assert.Equal(t, "comp", services[0].componentName)
+		assert.Equal(t, "shared", services[1].componentName)
+	})
 }
 
 func TestRemoveExt(t *testing.T) {
@@ -207,3 +334,47 @@ func TestGetSocketFolder(t *testing.T) {
 		assert.Equal(t, GetSocketFolderPath(), fakeSocketFolder)
 	})
 }
+
+func TestSocketFileFor(t *testing.T) {
+	t.Run("socket file should be computed from the component name when not overridden", func(t *testing.T) {
+		assert.Equal(t, "my-component.sock", socketFileFor("my-component"))
+	})
+	t.Run("socket file should use the env var when set", func(t *testing.T) {
+		t.Setenv("DAPR_PLUGGABLE_SOCKET_NAME_MY_COMPONENT", "mycomp.sock")
+		assert.Equal(t, "mycomp.sock", socketFileFor("my-component"))
+	})
+}
+
+func TestSocketNameOverrides(t *testing.T) {
+	t.Setenv("DAPR_PLUGGABLE_SOCKET_NAME_MY_COMPONENT", "mycomp.sock")
+	t.Setenv("DAPR_PLUGGABLE_SOCKET_NAME_EMPTY", "")
+
+	overrides := socketNameOverrides()
+	assert.Equal(t, "my-component", overrides["mycomp.sock"])
+	assert.NotContains(t, overrides, "")
+
+	t.Run("serviceDiscovery should use the overridden component name", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			return
+		}
+		const fakeSocketFolder = "/tmp/test"
+		err := os.MkdirAll(fakeSocketFolder, os.ModePerm)
+		defer os.RemoveAll(fakeSocketFolder)
+		require.NoError(t, err)
+		t.Setenv(SocketFolderEnvVar, fakeSocketFolder)
+
+		for _, fileName := range []string{"/mycomp.sock", "/other.sock"} {
+			listener, err := net.Listen("unix", fakeSocketFolder+fileName)
+			require.NoError(t, err)
+			defer listener.Close()
+		}
+
+		services, err := serviceDiscovery(func(string) (reflectServiceClient, func(), error) {
+			return &fakeReflectService{listServicesResp: []string{"svcA"}}, func() {}, nil
+		})
+		require.NoError(t, err)
+		require.Len(t, services, 2)
+		assert.Equal(t, "my-component", services[0].componentName)
+		assert.Equal(t, "other", services[1].componentName)
+	})
+}
@@ -14,10 +14,301 @@ limitations under the License.
 package pluggable
 
 import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+	"time"
+
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+var (
+	// ErrSocketNotFound is returned when the pluggable component socket file does not exist.
+	ErrSocketNotFound = errors.New("pluggable component socket not found")
+	// ErrSocketNotReady is returned when the pluggable component socket file was not created in time.
+	ErrSocketNotReady = errors.New("pluggable component not ready in time")
+	// ErrIncompatibleProtocolVersion is returned when the pluggable component protocol version is not supported by the runtime.
+	ErrIncompatibleProtocolVersion = errors.New("incompatible pluggable component protocol version")
+	// ErrComponentUnavailable is returned when the pluggable component was evicted for being unhealthy.
+	ErrComponentUnavailable = errors.New("pluggable component unavailable")
+	// ErrComponentDisabled is returned when the pluggable component was disabled through its metadata.
+	ErrComponentDisabled = errors.New("pluggable component disabled")
+	// ErrRequiredComponentNotReady is returned when a required pluggable component was not ready in time during startup.
+	ErrRequiredComponentNotReady = errors.New("required pluggable component not ready")
+	// ErrReinitUnsupported is returned when the pluggable component cannot be re-initialized in place and must be reconnected instead.
+	ErrReinitUnsupported = errors.New("pluggable component does not support reinit")
+	// ErrSocketPermissionDenied is returned when the sidecar is not allowed to connect to the pluggable component socket.
+	ErrSocketPermissionDenied = errors.New("pluggable component socket permission denied")
+	// ErrSocketPathNotAllowed is returned when the socket path set through the component metadata is not within the sockets folder.
+	ErrSocketPathNotAllowed = errors.New("pluggable component socket path not allowed")
+	// ErrMetadataSchemaViolation is returned when the component metadata doesn't match the schema advertised by the component.
+	ErrMetadataSchemaViolation = errors.New("pluggable component metadata does not match its schema")
+	// ErrSocketNamespaceMismatch is returned when the pluggable component socket is scoped to a namespace other than the sidecar one.
+	ErrSocketNamespaceMismatch = errors.New("pluggable component socket namespace mismatch")
+	// ErrFeatureNotSupported is returned when the pluggable component does not implement an optional operation.
+	ErrFeatureNotSupported = errors.New("feature not supported by the pluggable component")
+	// ErrBackendFailure matches the errors of the backend underlying the pluggable component, e.g. a database being down, see ClassifyError.
+	ErrBackendFailure = errors.New("pluggable component backend failure")
+	// ErrComponentFailure matches the errors of the pluggable component itself, e.g. a bug or a protocol violation, see ClassifyError.
+	ErrComponentFailure = errors.New("pluggable component failure")
+	// ErrInvalidMetadataOption is returned when a connector option set through the component metadata has an invalid value, see optionsFromMetadata.
+	ErrInvalidMetadataOption = errors.New("invalid pluggable component metadata option")
 )
 
+// ErrorClass tells whether a pluggable component error was caused by its backend or by the component itself.
+type ErrorClass int
+
+const (
+	// UnclassifiedError is the class of the errors that are neither backend nor component failures, e.g. not found or invalid argument errors.
+	UnclassifiedError ErrorClass = iota
+	// BackendError is the class of the errors of the backend underlying the component, reported with the Unavailable or DeadlineExceeded codes.
+	BackendError
+	// ComponentError is the class of the errors of the component itself, reported with the Internal, Unknown or DataLoss codes.
+	ComponentError
+)
+
+func (c ErrorClass) String() string {
+	switch c {
+	case BackendError:
+		return "backend"
+	case ComponentError:
+		return "component"
+	default:
+		return "unclassified"
+	}
+}
+
+// ClassifyError returns the class of the given pluggable component error by its status code. By convention components report the failures
+// of their backend with the Unavailable or DeadlineExceeded codes, and their own failures with the Internal code.
+// Note that the component connection failures are reported by gRPC as Unavailable too.
+func ClassifyError(err error) ErrorClass {
+	var classified *ClassifiedError
+	if errors.As(err, &classified) {
+		return classified.Class
+	}
+	s, ok := status.FromError(err)
+	if !ok || s == nil {
+		return UnclassifiedError
+	}
+	switch s.Code() {
+	case codes.Unavailable, codes.DeadlineExceeded:
+		return BackendError
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		return ComponentError
+	default:
+		return UnclassifiedError
+	}
+}
+
+// ClassifiedError is a pluggable component error along with its class, see ClassifyError.
+type ClassifiedError struct {
+	// Class is the error class.
+	Class ErrorClass
+	// Err is the component error.
+	Err error
+}
+
+func (e *ClassifiedError) Error() string {
+	return e.Err.Error()
+}
+
+// Is allows matching ClassifiedError against ErrBackendFailure or ErrComponentFailure according to its class.
+func (e *ClassifiedError) Is(target error) bool {
+	switch e.Class {
+	case BackendError:
+		return target == ErrBackendFailure
+	case ComponentError:
+		return target == ErrComponentFailure
+	default:
+		return false
+	}
+}
+
+// Unwrap returns the component error.
+func (e *ClassifiedError) Unwrap() error {
+	return e.Err
+}
+
+// withClass returns the given error along with its class, it is returned as is when unclassified.
+func withClass(err error) error {
+	class := ClassifyError(err)
+	if class == UnclassifiedError {
+		return err
+	}
+	return &ClassifiedError{Class: class, Err: err}
+}
+
+// SocketPermissionError is returned when dialing a pluggable component whose socket file cannot be accessed by the sidecar,
+// it usually means that the component created its socket with a mode or owner that doesn't match the sidecar user.
+type SocketPermissionError struct {
+	// Socket is the socket path.
+	Socket string
+	// Mode is the socket file mode.
+	Mode fs.FileMode
+	// OwnerUID and OwnerGID are the socket file owner user and group IDs.
+	OwnerUID, OwnerGID int
+	// SidecarUID is the user ID of the sidecar process.
+	SidecarUID int
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *SocketPermissionError) Error() string {
+	return fmt.Sprintf("%s: the socket file '%s' has mode %s and is owned by %d:%d, but the sidecar runs as user %d, make sure the component creates its socket accessible to the sidecar user", ErrSocketPermissionDenied, e.Socket, e.Mode, e.OwnerUID, e.OwnerGID, e.SidecarUID)
+}
+
+// Is allows matching SocketPermissionError against ErrSocketPermissionDenied.
+func (e *SocketPermissionError) Is(target error) bool {
+	return target == ErrSocketPermissionDenied
+}
+
+// Unwrap returns the underlying error.
+func (e *SocketPermissionError) Unwrap() error {
+	return e.Err
+}
+
+// MetadataFieldError is a component metadata key that doesn't match the schema advertised by the component.
+type MetadataFieldError struct {
+	// Key is the metadata key.
+	Key string
+	// Reason describes why the key doesn't match the schema.
+	Reason string
+}
+
+func (e MetadataFieldError) Error() string {
+	return fmt.Sprintf("metadata '%s': %s", e.Key, e.Reason)
+}
+
+// MetadataSchemaError is returned when the component metadata doesn't match the schema advertised by the component, see ValidateMetadata.
+type MetadataSchemaError struct {
+	// Fields are the invalid metadata keys, sorted by key.
+	Fields []MetadataFieldError
+}
+
+func (e *MetadataSchemaError) Error() string {
+	fields := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		fields[i] = field.Error()
+	}
+	return fmt.Sprintf("%s: %s", ErrMetadataSchemaViolation, strings.Join(fields, "; "))
+}
+
+// Is allows matching MetadataSchemaError against ErrMetadataSchemaViolation.
+func (e *MetadataSchemaError) Is(target error) bool {
+	return target == ErrMetadataSchemaViolation
+}
+
+// FeatureNotSupportedError is returned when the pluggable component replies with an Unimplemented status to an optional operation.
+type FeatureNotSupportedError struct {
+	// Feature is the feature of the unimplemented operation.
+	Feature string
+}
+
+func (e *FeatureNotSupportedError) Error() string {
+	return fmt.Sprintf("feature %s not supported by the pluggable component", e.Feature)
+}
+
+// Is allows matching FeatureNotSupportedError against ErrFeatureNotSupported.
+func (e *FeatureNotSupportedError) Is(target error) bool {
+	return target == ErrFeatureNotSupported
+}
+
+// NotSupportedConverter returns an error converter that maps the Unimplemented status of an optional operation to a FeatureNotSupportedError of the given feature.
+func NotSupportedConverter(feature string) MethodErrorConverter {
+	return MethodErrorConverter{
+		codes.Unimplemented: func(status.Status) error {
+			return &FeatureNotSupportedError{Feature: feature}
+		},
+	}
+}
+
+// SocketNotFoundError is returned when dialing a pluggable component whose socket file does not exist,
+// it usually means that the component container has not started or has not created its socket.
+type SocketNotFoundError struct {
+	// Socket is the expected socket path.
+	Socket string
+	// ComponentName is the component being dialed.
+	ComponentName string
+	// Err is the underlying error, if any.
+	Err error
+}
+
+func (e *SocketNotFoundError) Error() string {
+	return fmt.Sprintf("%s: the socket file '%s' of component '%s' is not present, make sure the component container is running and created the socket", ErrSocketNotFound, e.Socket, e.ComponentName)
+}
+
+// Is allows matching SocketNotFoundError against ErrSocketNotFound.
+func (e *SocketNotFoundError) Is(target error) bool {
+	return target == ErrSocketNotFound
+}
+
+// Unwrap returns the underlying error.
+func (e *SocketNotFoundError) Unwrap() error {
+	return e.Err
+}
+
+// ExtractDetails returns the ComponentError detail carried by the given gRPC status error, if any.
+func ExtractDetails(err error) (*proto.ComponentError, bool) {
+	s, ok := status.FromError(err)
+	if !ok || s == nil {
+		return nil, false
+	}
+	for _, detail := range s.Details() {
+		if componentErr, ok := detail.(*proto.ComponentError); ok {
+			return componentErr, true
+		}
+	}
+	return nil, false
+}
+
+// DetailedError is a component error enriched with the structured details sent by the component.
+type DetailedError struct {
+	// Err is the component error.
+	Err error
+	// Details are the structured error details.
+	Details *proto.ComponentError
+}
+
+func (e *DetailedError) Error() string {
+	details := make([]string, 0, 3)
+	if kind := e.Details.GetKind(); kind != "" {
+		details = append(details, "kind: "+kind)
+	}
+	if resource := e.Details.GetResource(); resource != "" {
+		details = append(details, "resource: "+resource)
+	}
+	if retryAfter := e.RetryAfter(); retryAfter > 0 {
+		details = append(details, "retry after: "+retryAfter.String())
+	}
+	if len(details) == 0 {
+		return e.Err.Error()
+	}
+	return fmt.Sprintf("%s (%s)", e.Err, strings.Join(details, ", "))
+}
+
+// Unwrap returns the component error.
+func (e *DetailedError) Unwrap() error {
+	return e.Err
+}
+
+// RetryAfter returns the amount of time suggested by the component to wait before retrying, zero when not set.
+func (e *DetailedError) RetryAfter() time.Duration {
+	return e.Details.GetRetryAfter().AsDuration()
+}
+
+// withDetails returns the given error enriched with the component error details carried by its status, if any.
+func withDetails(err error) error {
+	details, ok := ExtractDetails(err)
+	if !ok {
+		return err
+	}
+	return &DetailedError{Err: err, Details: details}
+}
+
 type ErrorConverter func(status.Status) error
 
 // Compose together two errors converters by applying the inner first and if the error was not converted, then it applies to the outer.
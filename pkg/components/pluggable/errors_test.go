@@ -16,11 +16,15 @@ package pluggable
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
 )
 
 func TestComposeErrorsConverters(t *testing.T) {
@@ -87,3 +91,109 @@ func TestErrorsMerge(t *testing.T) {
 		assert.Equal(t, 1, outerCalled)
 	})
 }
+
+func TestNotSupportedConverter(t *testing.T) {
+	convert := NewConverterFunc(NotSupportedConverter("TRANSACTIONAL"))
+
+	t.Run("unimplemented status should be converted to a feature not supported error", func(t *testing.T) {
+		err := convert(status.Error(codes.Unimplemented, "method Transact not implemented"))
+
+		require.ErrorIs(t, err, ErrFeatureNotSupported)
+		assert.Equal(t, "feature TRANSACTIONAL not supported by the pluggable component", err.Error())
+	})
+
+	t.Run("other errors should be kept as is", func(t *testing.T) {
+		original := status.Error(codes.NotFound, "boom")
+		assert.Equal(t, original, convert(original))
+		assert.NoError(t, convert(nil))
+	})
+
+	t.Run("other errors should be classified as backend or component failures", func(t *testing.T) {
+		original := status.Error(codes.Internal, "boom")
+		err := convert(original)
+		require.ErrorIs(t, err, original)
+		require.ErrorIs(t, err, ErrComponentFailure)
+		assert.Equal(t, original.Error(), err.Error())
+		assert.Equal(t, codes.Internal, status.Code(err))
+	})
+}
+
+func TestClassifyError(t *testing.T) {
+	for _, tc := range []struct {
+		code     codes.Code
+		expected ErrorClass
+	}{
+		{codes.Unavailable, BackendError},
+		{codes.DeadlineExceeded, BackendError},
+		{codes.Internal, ComponentError},
+		{codes.Unknown, ComponentError},
+		{codes.DataLoss, ComponentError},
+		{codes.NotFound, UnclassifiedError},
+		{codes.InvalidArgument, UnclassifiedError},
+		{codes.FailedPrecondition, UnclassifiedError},
+		{codes.Unimplemented, UnclassifiedError},
+	} {
+		t.Run(tc.code.String()+" should be classified as "+tc.expected.String(), func(t *testing.T) {
+			assert.Equal(t, tc.expected, ClassifyError(status.Error(tc.code, "boom")))
+		})
+	}
+
+	t.Run("errors without status should not be classified", func(t *testing.T) {
+		assert.Equal(t, UnclassifiedError, ClassifyError(errors.New("boom")))
+		assert.Equal(t, UnclassifiedError, ClassifyError(nil))
+	})
+
+	t.Run("converted errors should keep their class", func(t *testing.T) {
+		err := NewConverterFunc(MethodErrorConverter{})(status.Error(codes.Unavailable, "database is down"))
+
+		assert.Equal(t, BackendError, ClassifyError(err))
+		require.ErrorIs(t, err, ErrBackendFailure)
+		assert.NotErrorIs(t, err, ErrComponentFailure)
+	})
+}
+
+func TestExtractDetails(t *testing.T) {
+	detailedStatus := func(t *testing.T, details *proto.ComponentError) error {
+		s, err := status.New(codes.ResourceExhausted, "too many requests").WithDetails(details)
+		require.NoError(t, err)
+		return s.Err()
+	}
+
+	t.Run("details should be extracted from the status", func(t *testing.T) {
+		details, ok := ExtractDetails(detailedStatus(t, &proto.ComponentError{
+			Kind:       "THROTTLED",
+			Resource:   "my-key",
+			RetryAfter: durationpb.New(time.Second),
+		}))
+
+		require.True(t, ok)
+		assert.Equal(t, "THROTTLED", details.GetKind())
+		assert.Equal(t, "my-key", details.GetResource())
+		assert.Equal(t, time.Second, details.GetRetryAfter().AsDuration())
+	})
+
+	t.Run("errors without details should have no details", func(t *testing.T) {
+		_, ok := ExtractDetails(status.Error(codes.Internal, "boom"))
+		assert.False(t, ok)
+		_, ok = ExtractDetails(errors.New("boom"))
+		assert.False(t, ok)
+		_, ok = ExtractDetails(nil)
+		assert.False(t, ok)
+	})
+
+	t.Run("converter should enrich the unconverted errors with their details", func(t *testing.T) {
+		original := detailedStatus(t, &proto.ComponentError{
+			Kind:       "THROTTLED",
+			Resource:   "my-key",
+			RetryAfter: durationpb.New(time.Second),
+		})
+
+		err := NewConverterFunc(MethodErrorConverter{})(original)
+
+		var detailedErr *DetailedError
+		require.ErrorAs(t, err, &detailedErr)
+		assert.Equal(t, time.Second, detailedErr.RetryAfter())
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Contains(t, err.Error(), "kind: THROTTLED, resource: my-key, retry after: 1s")
+	})
+}
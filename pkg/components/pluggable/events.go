@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/dapr/dapr/pkg/components"
+)
+
+// ConnectionEventType is the kind of connection transition of a pluggable component.
+type ConnectionEventType string
+
+const (
+	// EventConnected is emitted the first time the component connection becomes ready.
+	EventConnected ConnectionEventType = "connected"
+	// EventDisconnected is emitted when a ready component connection is lost or closed.
+	EventDisconnected ConnectionEventType = "disconnected"
+	// EventReconnected is emitted when the component connection becomes ready again after being disconnected.
+	EventReconnected ConnectionEventType = "reconnected"
+)
+
+// ConnectionEvent is a connection transition of a pluggable component instance.
+type ConnectionEvent struct {
+	// Type is the kind of transition.
+	Type ConnectionEventType
+	// Pluggable is the descriptor of the component.
+	Pluggable components.Pluggable
+	// Name is the component instance name.
+	Name string
+	// Time is when the transition was observed.
+	Time time.Time
+}
+
+// EventBus dispatches the pluggable component connection events to its subscribers.
+type EventBus struct {
+	lock        sync.RWMutex
+	nextID      int
+	subscribers map[int]func(ConnectionEvent)
+}
+
+// NewEventBus creates a new event bus without subscribers.
+func NewEventBus() *EventBus {
+	return &EventBus{
+		subscribers: make(map[int]func(ConnectionEvent)),
+	}
+}
+
+// DefaultEventBus is the event bus the connectors emit their connection events to. The runtime subscribes to it to log the events,
+// applications embedding the runtime can subscribe to it as well e.g. to export them as metrics.
+var DefaultEventBus = NewEventBus()
+
+// withEventBus emits the component connection events to the given event bus instead of the DefaultEventBus.
+func withEventBus(b *EventBus) Option {
+	return func(o *connectorOptions) {
+		o.eventBus = b
+	}
+}
+
+// Subscribe registers the given handler to be called on every connection event, it returns a function that unregisters it.
+// Handlers are called synchronously from the goroutine watching the connection, so they should not block.
+func (b *EventBus) Subscribe(handler func(ConnectionEvent)) (unsubscribe func()) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	id := b.nextID
+	b.nextID++
+	b.subscribers[id] = handler
+	return func() {
+		b.lock.Lock()
+		defer b.lock.Unlock()
+		delete(b.subscribers, id)
+	}
+}
+
+// publish calls every subscriber with the given event. Handlers are called without holding the lock,
+// so they can subscribe or unsubscribe handlers themselves.
+func (b *EventBus) publish(event ConnectionEvent) {
+	b.lock.RLock()
+	handlers := make([]func(ConnectionEvent), 0, len(b.subscribers))
+	for _, handler := range b.subscribers {
+		handlers = append(handlers, handler)
+	}
+	b.lock.RUnlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// connectionEvents returns a connection state observer that emits the connection events of the given component instance.
+// entering the ready state means connected, or reconnected when it was connected before, leaving it means disconnected.
+func (g *GRPCConnector[TClient]) connectionEvents(name string) func(connectivity.State) {
+	var connected, ready bool
+	return func(state connectivity.State) {
+		var eventType ConnectionEventType
+		switch {
+		case state == connectivity.Ready && !ready && connected:
+			eventType = EventReconnected
+		case state == connectivity.Ready && !ready:
+			eventType = EventConnected
+		case state != connectivity.Ready && ready:
+			eventType = EventDisconnected
+		default:
+			return
+		}
+		ready = state == connectivity.Ready
+		connected = connected || ready
+
+		g.options.eventBus.publish(ConnectionEvent{
+			Type:      eventType,
+			Pluggable: g.options.pluggable,
+			Name:      name,
+			Time:      time.Now(),
+		})
+	}
+}
@@ -0,0 +1,153 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/connectivity"
+
+	"github.com/dapr/dapr/pkg/components"
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+// eventRecorder records the connection events it receives.
+type eventRecorder struct {
+	lock   sync.Mutex
+	events []ConnectionEvent
+}
+
+func (r *eventRecorder) record(event ConnectionEvent) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.events = append(r.events, event)
+}
+
+// types returns the types of the recorded events, in order.
+func (r *eventRecorder) types() []ConnectionEventType {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	types := make([]ConnectionEventType, 0, len(r.events))
+	for _, event := range r.events {
+		types = append(types, event.Type)
+	}
+	return types
+}
+
+func TestConnectionEvents(t *testing.T) {
+	pc := components.Pluggable{Type: components.CategoryStateStore, Name: "my-component", Version: "v1"}
+
+	t.Run("subscribers should receive the transitions of the connection", func(t *testing.T) {
+		bus := NewEventBus()
+		recorder := &eventRecorder{}
+		bus.Subscribe(recorder.record)
+
+		connector := NewGRPCConnector("", proto.NewStateStoreClient, WithPluggable(pc), withEventBus(bus))
+		observe := connector.connectionEvents("primary")
+		for _, state := range []connectivity.State{
+			connectivity.Idle,
+			connectivity.Connecting,
+			connectivity.Ready,
+			connectivity.Ready,
+			connectivity.Idle,
+			connectivity.Connecting,
+			connectivity.TransientFailure,
+			connectivity.Connecting,
+			connectivity.Ready,
+			connectivity.Shutdown,
+		} {
+			observe(state)
+		}
+
+		assert.Equal(t, []ConnectionEventType{EventConnected, EventDisconnected, EventReconnected, EventDisconnected}, recorder.types())
+		for _, event := range recorder.events {
+			assert.Equal(t, pc, event.Pluggable)
+			assert.Equal(t, "primary", event.Name)
+			assert.False(t, event.Time.IsZero())
+		}
+	})
+
+	t.Run("connections that never became ready should not emit events", func(t *testing.T) {
+		bus := NewEventBus()
+		recorder := &eventRecorder{}
+		bus.Subscribe(recorder.record)
+
+		observe := NewGRPCConnector("", proto.NewStateStoreClient, withEventBus(bus)).connectionEvents("primary")
+		observe(connectivity.Connecting)
+		observe(connectivity.TransientFailure)
+		observe(connectivity.Shutdown)
+
+		assert.Empty(t, recorder.types())
+	})
+
+	t.Run("unsubscribed handlers should not be called anymore", func(t *testing.T) {
+		bus := NewEventBus()
+		first, second := &eventRecorder{}, &eventRecorder{}
+		unsubscribe := bus.Subscribe(first.record)
+		bus.Subscribe(second.record)
+
+		bus.publish(ConnectionEvent{Type: EventConnected})
+		unsubscribe()
+		bus.publish(ConnectionEvent{Type: EventDisconnected})
+
+		assert.Equal(t, []ConnectionEventType{EventConnected}, first.types())
+		assert.Equal(t, []ConnectionEventType{EventConnected, EventDisconnected}, second.types())
+	})
+
+	t.Run("handlers should be able to subscribe and unsubscribe while handling an event", func(t *testing.T) {
+		bus := NewEventBus()
+		recorder := &eventRecorder{}
+		var unsubscribe func()
+		unsubscribe = bus.Subscribe(func(event ConnectionEvent) {
+			bus.Subscribe(recorder.record)
+			unsubscribe()
+		})
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			bus.publish(ConnectionEvent{Type: EventConnected})
+			bus.publish(ConnectionEvent{Type: EventDisconnected})
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			require.Fail(t, "publish deadlocked")
+		}
+		assert.Equal(t, []ConnectionEventType{EventDisconnected}, recorder.types())
+	})
+
+	t.Run("dialed connectors should emit connected and disconnected events", func(t *testing.T) {
+		bus := NewEventBus()
+		recorder := &eventRecorder{}
+		bus.Subscribe(recorder.record)
+
+		connector := testPubSubConnectorFor(t, &pingServer{}, WithPluggable(pc), withEventBus(bus))
+		require.NoError(t, connector.Dial("primary"))
+		require.NoError(t, connector.Ping())
+		assert.Eventually(t, func() bool {
+			return len(recorder.types()) == 1
+		}, time.Second, time.Millisecond)
+
+		require.NoError(t, connector.Close())
+		assert.Eventually(t, func() bool {
+			return len(recorder.types()) == 2
+		}, time.Second, time.Millisecond)
+		assert.Equal(t, []ConnectionEventType{EventConnected, EventDisconnected}, recorder.types())
+	})
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+// FeatureSet holds the features reported by a component, both as a flat list and grouped by category.
+type FeatureSet struct {
+	flat       map[string]struct{}
+	categories map[string]map[string]struct{}
+}
+
+// NewFeatureSet creates a feature set from the given component features response.
+// Categorized features are also part of the flat features, so components can be queried in both forms.
+func NewFeatureSet(resp *proto.FeaturesResponse) FeatureSet {
+	fs := FeatureSet{
+		flat:       make(map[string]struct{}, len(resp.GetFeatures())),
+		categories: make(map[string]map[string]struct{}, len(resp.GetCategories())),
+	}
+	for _, f := range resp.GetFeatures() {
+		fs.flat[f] = struct{}{}
+	}
+	for category, list := range resp.GetCategories() {
+		features := make(map[string]struct{}, len(list.GetFeatures()))
+		for _, f := range list.GetFeatures() {
+			features[f] = struct{}{}
+			fs.flat[f] = struct{}{}
+		}
+		fs.categories[category] = features
+	}
+	return fs
+}
+
+// Has returns true when the component reports the given feature, in any category.
+func (fs FeatureSet) Has(feature string) bool {
+	_, ok := fs.flat[feature]
+	return ok
+}
+
+// HasInCategory returns true when the component reports the given feature under the given category.
+func (fs FeatureSet) HasInCategory(category, feature string) bool {
+	_, ok := fs.categories[category][feature]
+	return ok
+}
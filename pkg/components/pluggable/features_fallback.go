@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+const (
+	// defaultFeaturesRetries is the default number of times a failed features call is retried.
+	defaultFeaturesRetries = 3
+	// defaultFeaturesBackoff is the default initial interval between features call attempts.
+	defaultFeaturesBackoff = 100 * time.Millisecond
+)
+
+// FeaturesFallback is the feature set assumed for a component whose features call keeps failing.
+type FeaturesFallback int
+
+const (
+	// FeaturesFallbackConservative assumes the component supports no optional feature.
+	FeaturesFallbackConservative FeaturesFallback = iota
+	// FeaturesFallbackOptimistic assumes the component supports every optional feature of its type.
+	FeaturesFallbackOptimistic
+)
+
+// String returns the fallback name as used in logs.
+func (f FeaturesFallback) String() string {
+	if f == FeaturesFallbackOptimistic {
+		return "optimistic"
+	}
+	return "conservative"
+}
+
+// featuresProvider is a client of a component service reporting its features.
+type featuresProvider interface {
+	Features(ctx context.Context, in *proto.FeaturesRequest, opts ...grpc.CallOption) (*proto.FeaturesResponse, error)
+}
+
+// featuresRetriesOrDefault returns the configured number of features call retries, or the default one.
+// a negative number disables the retries.
+func (o *connectorOptions) featuresRetriesOrDefault() int {
+	switch {
+	case o.featuresRetries < 0:
+		return 0
+	case o.featuresRetries == 0:
+		return defaultFeaturesRetries
+	default:
+		return o.featuresRetries
+	}
+}
+
+// featuresBackoffOrDefault returns the configured initial interval between features call attempts, or the default one.
+func (o *connectorOptions) featuresBackoffOrDefault() time.Duration {
+	if o.featuresBackoff > 0 {
+		return o.featuresBackoff
+	}
+	return defaultFeaturesBackoff
+}
+
+// FetchFeatures calls the component features rpc, retrying with exponential backoff bounded by the retry budget when it fails,
+// and checks the reported protocol version. When every attempt fails the configured fallback feature set is returned instead
+// and a warning is logged, the optimistic fallback assumes the given features: the component is still usable, only the optional
+// features are affected, but its protocol version is not verified. Components that don't implement the features rpc are not retried.
+// The connector context error is returned when it is done, no fallback is assumed then.
+func (g *GRPCConnector[TClient]) FetchFeatures(optimistic ...string) (*proto.FeaturesResponse, error) {
+	client, ok := any(g.Client).(featuresProvider)
+	if !ok {
+		return g.featuresFallback(optimistic), nil
+	}
+
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = g.options.featuresBackoffOrDefault()
+	bo.MaxElapsedTime = 0
+
+	attempt := 0
+	var resp *proto.FeaturesResponse
+	err := backoff.Retry(func() error {
+		attempt++
+		var err error
+		resp, err = client.Features(g.Context, &proto.FeaturesRequest{})
+		if err == nil {
+			return nil
+		}
+		g.logger.Debugf("features attempt %d failed: %v", attempt, err)
+		if status.Code(err) == codes.Unimplemented {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, backoff.WithContext(g.BudgetedBackOff(backoff.WithMaxRetries(bo, uint64(g.options.featuresRetriesOrDefault()))), g.Context))
+	if err != nil {
+		if ctxErr := g.Context.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		g.logger.Warnf("pluggable component features could not be fetched after %d attempts, assuming the %s feature set, its protocol version is unverified: %v", attempt, g.options.featuresFallback, err)
+		return g.featuresFallback(optimistic), nil
+	}
+
+	if err = g.CheckProtocolVersion(resp.GetProtocolVersion()); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// featuresFallback returns the features response assumed according to the configured fallback.
+func (g *GRPCConnector[TClient]) featuresFallback(optimistic []string) *proto.FeaturesResponse {
+	if g.options.featuresFallback == FeaturesFallbackOptimistic {
+		return &proto.FeaturesResponse{Features: optimistic}
+	}
+	return &proto.FeaturesResponse{}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+// featuresServer is a pubsub server whose features call fails the first failures times.
+type featuresServer struct {
+	proto.UnimplementedPubSubServer
+	featuresCalled atomic.Int64
+	failures       int64
+	featuresErr    error
+	features       []string
+}
+
+func (s *featuresServer) Features(context.Context, *proto.FeaturesRequest) (*proto.FeaturesResponse, error) {
+	if s.featuresCalled.Add(1) <= s.failures {
+		return nil, s.featuresErr
+	}
+	return &proto.FeaturesResponse{Features: s.features, ProtocolVersion: ProtocolVersion}, nil
+}
+
+func TestFetchFeatures(t *testing.T) {
+	connectorFor := func(t *testing.T, svc *featuresServer, opts ...Option) *GRPCConnector[proto.PubSubClient] {
+		t.Helper()
+		connector := testConnectorFor(t, func(s *grpc.Server, svc *featuresServer) {
+			proto.RegisterPubSubServer(s, svc)
+		}, svc, proto.NewPubSubClient, append([]Option{WithFeaturesRetries(3, time.Millisecond)}, opts...)...)
+		require.NoError(t, connector.Dial("my-component"))
+		return connector
+	}
+	unavailable := status.Error(codes.Unavailable, "component restarting")
+
+	t.Run("features should be returned when the call succeeds", func(t *testing.T) {
+		svc := &featuresServer{features: []string{"ETAG"}}
+		resp, err := connectorFor(t, svc).FetchFeatures("TTL")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ETAG"}, resp.GetFeatures())
+		assert.Equal(t, int64(1), svc.featuresCalled.Load())
+	})
+
+	t.Run("failed calls should be retried", func(t *testing.T) {
+		svc := &featuresServer{failures: 2, featuresErr: unavailable, features: []string{"ETAG"}}
+		resp, err := connectorFor(t, svc).FetchFeatures("TTL")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ETAG"}, resp.GetFeatures())
+		assert.Equal(t, int64(3), svc.featuresCalled.Load())
+	})
+
+	t.Run("no feature should be assumed by default when every attempt fails", func(t *testing.T) {
+		svc := &featuresServer{failures: 100, featuresErr: unavailable}
+		resp, err := connectorFor(t, svc).FetchFeatures("TTL")
+		require.NoError(t, err)
+		assert.Empty(t, resp.GetFeatures())
+		assert.Equal(t, int64(4), svc.featuresCalled.Load())
+	})
+
+	t.Run("the given features should be assumed with the optimistic fallback when every attempt fails", func(t *testing.T) {
+		svc := &featuresServer{failures: 100, featuresErr: unavailable}
+		resp, err := connectorFor(t, svc, WithFeaturesFallback(FeaturesFallbackOptimistic)).FetchFeatures("TTL", "ETAG")
+		require.NoError(t, err)
+		assert.Equal(t, []string{"TTL", "ETAG"}, resp.GetFeatures())
+		assert.Equal(t, int64(4), svc.featuresCalled.Load())
+	})
+
+	t.Run("unimplemented features should not be retried", func(t *testing.T) {
+		svc := &featuresServer{failures: 100, featuresErr: status.Error(codes.Unimplemented, "not implemented")}
+		resp, err := connectorFor(t, svc).FetchFeatures("TTL")
+		require.NoError(t, err)
+		assert.Empty(t, resp.GetFeatures())
+		assert.Equal(t, int64(1), svc.featuresCalled.Load())
+	})
+
+	t.Run("negative retries should disable the retries", func(t *testing.T) {
+		svc := &featuresServer{failures: 100, featuresErr: unavailable}
+		_, err := connectorFor(t, svc, WithFeaturesRetries(-1, 0)).FetchFeatures()
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), svc.featuresCalled.Load())
+	})
+
+	t.Run("the fallback should be logged as not verifying the protocol version", func(t *testing.T) {
+		var buf bytes.Buffer
+		log.SetOutput(&buf)
+		defer log.SetOutput(os.Stdout)
+
+		svc := &featuresServer{failures: 100, featuresErr: unavailable}
+		_, err := connectorFor(t, svc).FetchFeatures()
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), "protocol version is unverified")
+	})
+
+	t.Run("the context error should be returned when the connector context is done", func(t *testing.T) {
+		svc := &featuresServer{failures: 100, featuresErr: unavailable}
+		connector := connectorFor(t, svc)
+		connector.Cancel()
+
+		resp, err := connector.FetchFeatures("TTL")
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("incompatible protocol versions should still be rejected", func(t *testing.T) {
+		defaultMinProtocolVersion := minProtocolVersion
+		defer func() {
+			minProtocolVersion = defaultMinProtocolVersion
+		}()
+		minProtocolVersion = ProtocolVersion + 1
+
+		_, err := connectorFor(t, &featuresServer{}).FetchFeatures()
+		assert.ErrorIs(t, err, ErrIncompatibleProtocolVersion)
+	})
+}
@@ -0,0 +1,58 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+func TestFeatureSet(t *testing.T) {
+	t.Run("flat features should be queryable", func(t *testing.T) {
+		fs := NewFeatureSet(&proto.FeaturesResponse{Features: []string{"TRANSACTIONAL", "ETAG"}})
+
+		assert.True(t, fs.Has("TRANSACTIONAL"))
+		assert.True(t, fs.Has("ETAG"))
+		assert.False(t, fs.Has("TTL"))
+		assert.False(t, fs.HasInCategory("state", "TRANSACTIONAL"))
+	})
+
+	t.Run("categorized features should be queryable by category and as flat features", func(t *testing.T) {
+		fs := NewFeatureSet(&proto.FeaturesResponse{
+			Features: []string{"ETAG"},
+			Categories: map[string]*proto.FeatureList{
+				"state": {Features: []string{"TRANSACTIONAL", "TTL"}},
+				"query": {Features: []string{"SORT"}},
+			},
+		})
+
+		assert.True(t, fs.HasInCategory("state", "TRANSACTIONAL"))
+		assert.True(t, fs.HasInCategory("state", "TTL"))
+		assert.True(t, fs.HasInCategory("query", "SORT"))
+		assert.False(t, fs.HasInCategory("query", "TTL"))
+		assert.False(t, fs.HasInCategory("unknown", "SORT"))
+		assert.True(t, fs.Has("ETAG"))
+		assert.True(t, fs.Has("SORT"))
+	})
+
+	t.Run("an empty response should have no features", func(t *testing.T) {
+		fs := NewFeatureSet(nil)
+
+		assert.False(t, fs.Has("ETAG"))
+		assert.False(t, fs.HasInCategory("state", "ETAG"))
+	})
+}
@@ -15,13 +15,23 @@ package pluggable
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	"github.com/dapr/kit/logger"
 
+	"github.com/dapr/dapr/pkg/components"
 	proto "github.com/dapr/dapr/pkg/proto/components/v1"
 
+	"github.com/cenkalti/backoff/v4"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
@@ -36,7 +46,8 @@ type GRPCClient interface {
 }
 
 // NewConverterFunc returns a function that maps from any error to a business error.
-// if the error is unknown it is kept as is, otherwise a converter function will be used.
+// if the error is unknown it is kept as is, enriched with the component error details when present and classified as a backend
+// or a component failure, see ClassifyError. Otherwise a converter function will be used.
 func NewConverterFunc(errorsConverters MethodErrorConverter) func(error) error {
 	return func(err error) error {
 		s, ok := status.FromError(err)
@@ -45,7 +56,7 @@ func NewConverterFunc(errorsConverters MethodErrorConverter) func(error) error {
 		}
 		convert, ok := errorsConverters[s.Code()]
 		if !ok {
-			return err
+			return withClass(withDetails(err))
 		}
 		return convert(*s)
 	}
@@ -71,6 +82,64 @@ type GRPCConnector[TClient GRPCClient] struct {
 	dialer        GRPCConnectionDialer
 	conn          *grpc.ClientConn
 	clientFactory func(grpc.ClientConnInterface) TClient
+	options       connectorOptions
+	// logger is the connector logger enriched with the pluggable component fields.
+	logger logger.Logger
+	// initGate guards the component initialization against duplicate init calls.
+	initGate initGate
+	infoLock sync.RWMutex
+	// info is the component version info captured from its last ping.
+	info ComponentInfo
+	// lastPing is the time of the last successful ping.
+	lastPing time.Time
+	// initWarnings are the warnings reported by the component on its last init, see ReportInitWarnings.
+	initWarnings []string
+	// name is the component instance name, set once dialed or disabled.
+	name string
+	// socket is the socket file the connector dials, empty when the socket is not known by the connector.
+	socket string
+	// closeOnce guards the connector against closing the connection more than once.
+	closeOnce sync.Once
+	// grpcMetadata holds the gRPC metadata key-value pairs sent on every rpc, see GRPCMetadataPrefix.
+	grpcMetadata     []string
+	grpcMetadataLock sync.RWMutex
+	// operations tracks the in-flight calls made with an operation id, see CancelOperation.
+	operations inFlightOperations
+	// socketPath is the socket path set through the component metadata, empty means the connector dialer is used, see SocketPathMetadataKey.
+	socketPath string
+	// channelzTarget is the target of the connection exposed through channelz, empty when not exposed, see WithChannelz.
+	channelzTarget string
+	// unavailable is set while the component is evicted by the health monitor, see MonitorHealth.
+	unavailable atomic.Bool
+}
+
+// ComponentInfo is the version info reported by the component on ping.
+// components that don't report it have an empty info.
+type ComponentInfo struct {
+	// Version is the component implementation version.
+	Version string
+	// BuildSHA is the component build commit SHA.
+	BuildSHA string
+	// ProtocolVersion is the pluggable components protocol version implemented by the component.
+	ProtocolVersion uint32
+}
+
+// initGate holds the result of the first initialization of a component instance.
+type initGate struct {
+	lock sync.Mutex
+	// key identifies the initialized component instance by its name and version.
+	key  string
+	done bool
+	err  error
+}
+
+// componentLogger returns a child logger enriched with the given pluggable component fields.
+func componentLogger(pc components.Pluggable) logger.Logger {
+	return log.WithFields(map[string]any{
+		"component_type":    string(pc.Type),
+		"component_name":    pc.Name,
+		"component_version": pc.Version,
+	})
 }
 
 // metadataInstanceID is used to differentiate between multiples instance of the same component.
@@ -92,9 +161,53 @@ func instanceIDStreamInterceptor(instanceID string) grpc.StreamClientInterceptor
 	}
 }
 
+// ProtocolVersion is the pluggable components protocol version implemented by the runtime.
+const ProtocolVersion uint32 = 1
+
+// minProtocolVersion is the minimum component protocol version supported by the runtime.
+// components that don't report their protocol version are considered as the version 0.
+var minProtocolVersion uint32
+
+// socketWaitTimeout is the max amount of time to wait for the component to create its socket file.
+var socketWaitTimeout = 5 * time.Second
+
+// WaitForSocket waits until the given socket file exists, polling it with backoff until the context is done.
+// It returns an ErrSocketNotReady error when the socket was not created in time.
+func WaitForSocket(ctx context.Context, socket string) error {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = 10 * time.Millisecond
+	bo.MaxInterval = 500 * time.Millisecond
+	bo.MaxElapsedTime = 0
+
+	err := backoff.Retry(func() error {
+		_, err := os.Stat(socket)
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return backoff.Permanent(err)
+		}
+		return err
+	}, backoff.WithContext(bo, ctx))
+	if err != nil && ctx.Err() != nil {
+		return fmt.Errorf("%w: socket '%s' was not created: %v", ErrSocketNotReady, socket, err)
+	}
+	return err
+}
+
 // socketDialer creates a dialer for the given socket.
+// it waits for the socket file to be created and returns a SocketNotFoundError when the socket file was not created in time,
+// or a SocketPermissionError when the sidecar is not allowed to connect to it.
 func socketDialer(socket string, additionalOpts ...grpc.DialOption) GRPCConnectionDialer {
 	return func(ctx context.Context, name string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+		waitCtx, cancel := context.WithTimeout(ctx, socketWaitTimeout)
+		defer cancel()
+		if err := WaitForSocket(waitCtx, socket); err != nil {
+			if errors.Is(err, ErrSocketNotReady) {
+				return nil, &SocketNotFoundError{Socket: socket, ComponentName: name, Err: err}
+			}
+			return nil, err
+		}
+		if err := checkSocketPermissions(socket); err != nil {
+			return nil, err
+		}
 		additionalOpts = append(additionalOpts, grpc.WithStreamInterceptor(instanceIDStreamInterceptor(name)), grpc.WithUnaryInterceptor(instanceIDUnaryInterceptor(name)))
 		return SocketDial(ctx, socket, append(additionalOpts, opts...)...)
 	}
@@ -113,46 +226,411 @@ func SocketDial(ctx context.Context, socket string, additionalOpts ...grpc.DialO
 	return grpcConn, nil
 }
 
+// contextDialer returns a dialer that connects to the given address through the given dial function instead of the unix socket dialer, see WithContextDialer.
+func contextDialer(address string, dial func(context.Context, string) (net.Conn, error)) GRPCConnectionDialer {
+	return func(ctx context.Context, name string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+		opts = append([]grpc.DialOption{
+			grpc.WithContextDialer(dial),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithStreamInterceptor(instanceIDStreamInterceptor(name)),
+			grpc.WithUnaryInterceptor(instanceIDUnaryInterceptor(name)),
+		}, opts...)
+		grpcConn, err := grpc.DialContext(ctx, "passthrough:///"+address, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open GRPC connection to '%s' using the context dialer: %w", address, err)
+		}
+		return grpcConn, nil
+	}
+}
+
 // Dial opens a grpcConnection and creates a new client instance.
+// The pluggable component descriptor, when set, is validated before dialing.
+// The socket path set through the component metadata, when set, is dialed instead of the connector socket, see SocketPathMetadataKey.
+// The context dialer, when set, is used instead of the connector dialer, see WithContextDialer.
+// The number of dials in progress at the same time is bounded, see SetMaxConcurrentDials.
+// Sockets scoped to a namespace other than the sidecar one are not dialed, see namespacedSocketPrefix.
+// Unary data plane calls are bounded by the call timeout, see WithCallTimeout.
 func (g *GRPCConnector[TClient]) Dial(name string) error {
-	grpcConn, err := g.dialer(g.Context, name)
+	if pc := g.options.pluggable; pc != (components.Pluggable{}) {
+		if err := pc.Validate(); err != nil {
+			return err
+		}
+	}
+	opts, err := g.options.dialOptions()
+	if err != nil {
+		return err
+	}
+	opts = append([]grpc.DialOption{
+		grpc.WithUserAgent(g.options.userAgentOrDefault()),
+		grpc.WithChainUnaryInterceptor(metricsUnaryInterceptor(g.options.pluggable), g.availabilityUnaryInterceptor(), g.callTimeoutUnaryInterceptor(), g.grpcMetadataUnaryInterceptor(), g.retryBudgetUnaryInterceptor(), g.operationsUnaryInterceptor(), g.rateLimitUnaryInterceptor()),
+		grpc.WithChainStreamInterceptor(metricsStreamInterceptor(g.options.pluggable), g.availabilityStreamInterceptor(), g.grpcMetadataStreamInterceptor(), g.retryBudgetStreamInterceptor(), g.rateLimitStreamInterceptor()),
+	}, opts...)
+
+	dialer := g.dialer
+	switch {
+	case g.options.contextDialer != nil:
+		address := g.socketPath
+		if address == "" {
+			address = g.socket
+		}
+		if address == "" {
+			address = name
+		}
+		dialer = contextDialer(address, g.options.contextDialer)
+	case g.socketPath != "":
+		if err := checkSocketNamespace(g.socketPath); err != nil {
+			return err
+		}
+		dialer = socketDialer(g.socketPath)
+	case g.socket != "":
+		if err := checkSocketNamespace(g.socket); err != nil {
+			return err
+		}
+	}
+
+	release, err := dials.acquire(g.Context)
+	if err != nil {
+		return fmt.Errorf("unable to open GRPC connection while waiting for a dial slot: %w", err)
+	}
+	g.logger.Debugf("dialing pluggable component instance '%s'", name)
+	grpcConn, err := dialer(g.Context, name, opts...)
+	release()
 	if err != nil {
 		return fmt.Errorf("unable to open GRPC connection using the dialer: %w", err)
 	}
+	if grpcConn != g.conn { // reused connections are tracked by their owners.
+		go trackedConnections.watch(g.Context, grpcConn, g.connectionEvents(name))
+	}
 	g.conn = grpcConn
+	if g.options.channelz && g.channelzTarget == "" {
+		g.channelzTarget = grpcConn.Target()
+		channelzConns.add(g.channelzTarget)
+	}
 
 	g.Client = g.clientFactory(grpcConn)
+	g.loaded(name)
 
+	if g.options.initialPingRetries > 0 {
+		return g.initialPing()
+	}
+	return nil
+}
+
+// initialPing pings the component retrying with exponential backoff when the component returns an error, bounded by the retry budget.
+// WaitForReady is used so that a socket not ready yet doesn't count as a failed attempt.
+func (g *GRPCConnector[TClient]) initialPing() error {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = g.options.initialPingBackoff
+	bo.MaxElapsedTime = 0
+
+	attempt := 0
+	err := backoff.Retry(func() error {
+		attempt++
+		err := g.PingContext(g.Context, true)
+		if err != nil {
+			g.logger.Debugf("initial ping attempt %d failed: %v", attempt, err)
+		}
+		return err
+	}, backoff.WithContext(g.BudgetedBackOff(backoff.WithMaxRetries(bo, uint64(g.options.initialPingRetries))), g.Context))
+	if err != nil {
+		return fmt.Errorf("pluggable component did not reply to the initial ping after %d attempts: %w", attempt, err)
+	}
 	return nil
 }
 
 // Ping pings the grpc component.
 // It uses "WaitForReady" avoiding failing in transient failures.
 func (g *GRPCConnector[TClient]) Ping() error {
-	_, err := g.Client.Ping(g.Context, &proto.PingRequest{}, grpc.WaitForReady(true))
+	return g.PingContext(g.Context, true)
+}
+
+// PingContext pings the grpc component bounded by the given context.
+// When waitForReady is false the ping fails fast with an Unavailable status if the component is not ready.
+// The component version info is captured from the ping response.
+// The ping function, when set, is called instead of the generic Ping, see WithPingFunc.
+func (g *GRPCConnector[TClient]) PingContext(ctx context.Context, waitForReady bool) error {
+	if g.options.pingFunc != nil {
+		if err := g.options.pingFunc(ctx, g.conn, grpc.WaitForReady(waitForReady)); err != nil {
+			return err
+		}
+		startup.markPinged(g.options.pluggable.Name)
+
+		g.infoLock.Lock()
+		defer g.infoLock.Unlock()
+		g.lastPing = time.Now()
+		return nil
+	}
+
+	resp, err := g.Client.Ping(ctx, &proto.PingRequest{}, grpc.WaitForReady(waitForReady))
+	if err != nil {
+		return err
+	}
+	startup.markPinged(g.options.pluggable.Name)
+
+	g.infoLock.Lock()
+	defer g.infoLock.Unlock()
+	g.lastPing = time.Now()
+	g.info = ComponentInfo{
+		Version:         resp.GetVersion(),
+		BuildSHA:        resp.GetBuildSha(),
+		ProtocolVersion: resp.GetProtocolVersion(),
+	}
+	return nil
+}
+
+// ReportInitWarnings logs the warnings reported by the component on init and captures them so they are listed in the component status.
+// the warnings replace the ones reported on a previous init, if any.
+func (g *GRPCConnector[TClient]) ReportInitWarnings(warnings []string) {
+	g.infoLock.Lock()
+	defer g.infoLock.Unlock()
+	for _, warning := range warnings {
+		g.logger.Warnf("pluggable component instance '%s' initialized with a warning: %s", g.name, warning)
+	}
+	g.initWarnings = append([]string(nil), warnings...)
+}
+
+// infoTimeout is the max amount of time to wait for the component version info.
+var infoTimeout = 5 * time.Second
+
+// CaptureInfo pings the component to capture and log its version info, it should be called after Dial.
+// failures are logged and ignored as the version info is only used for troubleshooting.
+func (g *GRPCConnector[TClient]) CaptureInfo() {
+	ctx, cancel := context.WithTimeout(g.Context, infoTimeout)
+	defer cancel()
+	if err := g.PingContext(ctx, true); err != nil {
+		g.logger.Debugf("could not capture the pluggable component version info: %v", err)
+		return
+	}
+	info := g.Info()
+	if info == (ComponentInfo{}) {
+		g.logger.Debug("pluggable component does not report its version info")
+		return
+	}
+	g.logger.Infof("pluggable component version '%s', build '%s', protocol version %d", info.Version, info.BuildSHA, info.ProtocolVersion)
+}
+
+// Info returns the component version info captured from its last successful ping.
+func (g *GRPCConnector[TClient]) Info() ComponentInfo {
+	g.infoLock.RLock()
+	defer g.infoLock.RUnlock()
+	return g.info
+}
+
+// loaded registers the component instance with the given name in the connector registry, DefaultRegistry unless overridden.
+func (g *GRPCConnector[TClient]) loaded(name string) {
+	g.infoLock.Lock()
+	g.name = name
+	g.infoLock.Unlock()
+	g.options.registry.register(g)
+}
+
+// Status returns the current status of the component instance, see Registry.Snapshot.
+func (g *GRPCConnector[TClient]) Status() PluggableStatus {
+	g.infoLock.RLock()
+	defer g.infoLock.RUnlock()
+
+	status := PluggableStatus{
+		Type:       string(g.options.pluggable.Type),
+		Name:       g.name,
+		Component:  g.options.pluggable.Name,
+		Version:    g.info.Version,
+		SocketPath: g.socket,
+		State:      stateDisabled,
+	}
+	if g.socketPath != "" {
+		status.SocketPath = g.socketPath
+	} else if status.SocketPath == "" && status.Component != "" {
+		status.SocketPath = discoveredSocketOf(status.Component)
+	}
+	if g.conn != nil {
+		status.State = g.conn.GetState().String()
+	}
+	if !g.lastPing.IsZero() {
+		lastPing := g.lastPing
+		status.LastPing = &lastPing
+	}
+	if len(g.initWarnings) > 0 {
+		status.InitWarnings = append([]string(nil), g.initWarnings...)
+	}
+	return status
+}
+
+// CheckProtocolVersion returns an ErrIncompatibleProtocolVersion error when the given component protocol version is below the runtime's minimum.
+func (g *GRPCConnector[TClient]) CheckProtocolVersion(version uint32) error {
+	if version < minProtocolVersion {
+		g.logger.Errorf("pluggable component protocol version %d is below the minimum supported version %d", version, minProtocolVersion)
+		return fmt.Errorf("%w: component version %d, minimum supported version %d", ErrIncompatibleProtocolVersion, version, minProtocolVersion)
+	}
+	if version > ProtocolVersion {
+		g.logger.Debugf("pluggable component protocol version %d is newer than the runtime version %d", version, ProtocolVersion)
+	}
+	return nil
+}
+
+// InitOnce calls the given init function only once for the given component instance name and the component version.
+// repeated init attempts are no-ops returning the first init result.
+// A successful init counts towards the startup of the required components, see StartupComplete.
+func (g *GRPCConnector[TClient]) InitOnce(name string, init func() error) error {
+	key := name + "@" + g.options.pluggable.Version
+
+	g.initGate.lock.Lock()
+	defer g.initGate.lock.Unlock()
+	if g.initGate.done && g.initGate.key == key {
+		g.logger.Debugf("pluggable component instance '%s' was already initialized, skipping", name)
+		return g.initGate.err
+	}
+
+	g.initGate.err = init()
+	g.initGate.key = key
+	g.initGate.done = true
+	if g.initGate.err == nil {
+		startup.markInitialized(g.options.pluggable.Name)
+	}
+	return g.initGate.err
+}
+
+// InitOrAbort calls the given init function, closing the connector when the given context is done before init returns,
+// e.g. when the component is removed while it is being initialized. The in-flight calls are then cancelled,
+// the half-open connection is closed and the context error is returned.
+func (g *GRPCConnector[TClient]) InitOrAbort(ctx context.Context, init func() error) error {
+	stop, stopped := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(stopped)
+		select {
+		case <-ctx.Done():
+			g.Close()
+		case <-stop:
+		}
+	}()
+
+	err := init()
+	close(stop)
+	<-stopped
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		g.Close()
+		return fmt.Errorf("pluggable component init aborted: %w", ctxErr)
+	}
+	return err
+}
+
+// ReinitWith re-initializes the component instance in place by calling the given reinit function on the existing connection.
+// It returns an ErrReinitUnsupported error when the component is not initialized, when the new properties disable it
+// or when the component replies with an Unimplemented status, callers are expected to reconnect the component instead.
+// The gRPC metadata set through the new properties is used from the reinit call onwards and reverted when it fails.
+func (g *GRPCConnector[TClient]) ReinitWith(name string, properties map[string]string, reinit func() error) error {
+	g.initGate.lock.Lock()
+	defer g.initGate.lock.Unlock()
+	if !g.initGate.done || g.initGate.err != nil || g.conn == nil {
+		return fmt.Errorf("%w: component instance '%s' is not initialized", ErrReinitUnsupported, name)
+	}
+	if IsDisabled(properties) {
+		return fmt.Errorf("%w: component instance '%s' is being disabled", ErrReinitUnsupported, name)
+	}
+
+	previousGRPCMetadata := g.setGRPCMetadata(properties)
+	err := reinit()
+	if err != nil {
+		g.grpcMetadataLock.Lock()
+		g.grpcMetadata = previousGRPCMetadata
+		g.grpcMetadataLock.Unlock()
+	}
+	if status.Code(err) == codes.Unimplemented {
+		return fmt.Errorf("%w: %v", ErrReinitUnsupported, err)
+	}
 	return err
 }
 
+// Initialized returns true when the component was successfully initialized.
+func (g *GRPCConnector[TClient]) Initialized() bool {
+	g.initGate.lock.Lock()
+	defer g.initGate.lock.Unlock()
+	return g.initGate.done && g.initGate.err == nil
+}
+
+// SubscribeDrainTimeout returns the max amount of time to wait for in-flight messages when a subscription stops.
+func (g *GRPCConnector[TClient]) SubscribeDrainTimeout() time.Duration {
+	if g.options.subscribeDrainTimeout <= 0 {
+		return DefaultSubscribeDrainTimeout
+	}
+	return g.options.subscribeDrainTimeout
+}
+
+// SubscribeBuffer returns the number of messages received ahead of the subscription handlers, see WithSubscribeBuffer.
+func (g *GRPCConnector[TClient]) SubscribeBuffer() int {
+	if g.options.subscribeBuffer < 0 {
+		return 0
+	}
+	return g.options.subscribeBuffer
+}
+
 // Close closes the underlying gRPC connection and cancel all inflight requests.
+// It is safe to call it more than once, repeated calls are no-ops returning nil.
+// The component is signaled through its optional shutdown rpc before the connection is closed, see CloseGracefully.
 func (g *GRPCConnector[TClient]) Close() error {
-	g.Cancel()
+	return g.CloseGracefully(context.Background())
+}
 
-	return g.conn.Close()
+// CloseGracefully calls the component shutdown rpc, so it can release its resources e.g. flushing buffers, before closing the connection as Close does.
+// The component is waited for up to the shutdown timeout bounded by the given context, see WithShutdownTimeout.
+// Components that don't implement the shutdown rpc are not waited for.
+func (g *GRPCConnector[TClient]) CloseGracefully(ctx context.Context) (err error) {
+	g.closeOnce.Do(func() {
+		g.shutdown(ctx)
+		g.Cancel()
+		g.options.registry.unregister(g)
+		if g.channelzTarget != "" {
+			channelzConns.remove(g.channelzTarget)
+		}
+
+		if g.conn == nil { // disabled or not dialed components have no connection.
+			return
+		}
+		err = g.conn.Close()
+	})
+	return err
 }
 
 // NewGRPCConnectorWithDialer creates a new grpc connector for the given client factory and dialer.
-func NewGRPCConnectorWithDialer[TClient GRPCClient](dialer GRPCConnectionDialer, factory func(grpc.ClientConnInterface) TClient) *GRPCConnector[TClient] {
+func NewGRPCConnectorWithDialer[TClient GRPCClient](dialer GRPCConnectionDialer, factory func(grpc.ClientConnInterface) TClient, opts ...Option) *GRPCConnector[TClient] {
 	ctx, cancel := context.WithCancel(context.Background())
 
-	return &GRPCConnector[TClient]{
+	connector := &GRPCConnector[TClient]{
 		Context:       ctx,
 		Cancel:        cancel,
 		dialer:        dialer,
 		clientFactory: factory,
 	}
+
+	for _, opt := range opts {
+		opt(&connector.options)
+	}
+	if connector.options.registry == nil {
+		connector.options.registry = DefaultRegistry
+	}
+	if connector.options.eventBus == nil {
+		connector.options.eventBus = DefaultEventBus
+	}
+	connector.logger = componentLogger(connector.options.pluggable)
+
+	return connector
+}
+
+// NewGRPCConnectorWithConn creates a new grpc connector that uses the given client and its already established connection.
+// Dial reuses the given connection instead of opening a new one, which allows wiring components to in-memory servers.
+func NewGRPCConnectorWithConn[TClient GRPCClient](client TClient, conn *grpc.ClientConn, opts ...Option) *GRPCConnector[TClient] {
+	connector := NewGRPCConnectorWithDialer(func(context.Context, string, ...grpc.DialOption) (*grpc.ClientConn, error) {
+		return conn, nil
+	}, func(grpc.ClientConnInterface) TClient {
+		return client
+	}, opts...)
+	connector.conn = conn
+	connector.Client = client
+	return connector
 }
 
 // NewGRPCConnector creates a new grpc connector for the given client factory and socket file, using the default socket dialer.
-func NewGRPCConnector[TClient GRPCClient](socket string, factory func(grpc.ClientConnInterface) TClient) *GRPCConnector[TClient] {
-	return NewGRPCConnectorWithDialer(socketDialer(socket), factory)
+func NewGRPCConnector[TClient GRPCClient](socket string, factory func(grpc.ClientConnInterface) TClient, opts ...Option) *GRPCConnector[TClient] {
+	connector := NewGRPCConnectorWithDialer(socketDialer(socket), factory, opts...)
+	connector.socket = socket
+	return connector
 }
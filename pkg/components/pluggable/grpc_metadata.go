@@ -0,0 +1,82 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// GRPCMetadataPrefix is the component metadata key prefix of the gRPC metadata sent on every rpc to the component,
+// e.g. 'grpcMetadata.authorization: Bearer token' adds the authorization header to every call.
+// it is meant for components fronted by proxies that authenticate the calls.
+const GRPCMetadataPrefix = "grpcMetadata."
+
+// grpcMetadataOf returns the gRPC metadata key-value pairs set through the given component metadata properties,
+// including the app identity when enabled, see SendAppIdentityMetadataKey.
+func grpcMetadataOf(properties map[string]string) []string {
+	keys := make([]string, 0)
+	for key := range properties {
+		if strings.HasPrefix(key, GRPCMetadataPrefix) && len(key) > len(GRPCMetadataPrefix) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys)*2)
+	for _, key := range keys {
+		pairs = append(pairs, strings.TrimPrefix(key, GRPCMetadataPrefix), properties[key])
+	}
+	return append(pairs, appIdentityGRPCMetadata(properties)...)
+}
+
+// setGRPCMetadata replaces the gRPC metadata sent on every rpc by the one set through the given component metadata properties.
+// it returns the previous metadata pairs so callers can restore them.
+func (g *GRPCConnector[TClient]) setGRPCMetadata(properties map[string]string) []string {
+	g.grpcMetadataLock.Lock()
+	defer g.grpcMetadataLock.Unlock()
+	previous := g.grpcMetadata
+	g.grpcMetadata = grpcMetadataOf(properties)
+	return previous
+}
+
+// withGRPCMetadata returns the given context with the component gRPC metadata appended to its outgoing metadata.
+func (g *GRPCConnector[TClient]) withGRPCMetadata(ctx context.Context) context.Context {
+	g.grpcMetadataLock.RLock()
+	pairs := g.grpcMetadata
+	g.grpcMetadataLock.RUnlock()
+
+	if len(pairs) == 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, pairs...)
+}
+
+// grpcMetadataUnaryInterceptor returns a grpc client unary interceptor that adds the component gRPC metadata on outgoing calls.
+func (g *GRPCConnector[TClient]) grpcMetadataUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(g.withGRPCMetadata(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// grpcMetadataStreamInterceptor returns a grpc client stream interceptor that adds the component gRPC metadata on outgoing streams.
+func (g *GRPCConnector[TClient]) grpcMetadataStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(g.withGRPCMetadata(ctx), desc, cc, method, opts...)
+	}
+}
@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+type metadataServer struct {
+	pingServer
+	lock     sync.Mutex
+	unaryMD  metadata.MD
+	streamMD metadata.MD
+}
+
+func (s *metadataServer) Ping(ctx context.Context, _ *proto.PingRequest) (*proto.PingResponse, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	s.lock.Lock()
+	s.unaryMD = md
+	s.lock.Unlock()
+	return &proto.PingResponse{}, nil
+}
+
+func (s *metadataServer) PullMessages(stream proto.PubSub_PullMessagesServer) error {
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	s.lock.Lock()
+	s.streamMD = md
+	s.lock.Unlock()
+	return nil
+}
+
+func TestGRPCMetadata(t *testing.T) {
+	const componentName = "proxied-component"
+
+	metadataConnectorFor := func(t *testing.T, svc *metadataServer) *GRPCConnector[proto.PubSubClient] {
+		return testConnectorFor(t, func(s *grpc.Server, svc *metadataServer) {
+			proto.RegisterPubSubServer(s, svc)
+		}, svc, proto.NewPubSubClient)
+	}
+
+	t.Run("grpc metadata should be parsed from the prefixed component metadata", func(t *testing.T) {
+		assert.Equal(t, []string{"authorization", "Bearer token", "x-tenant", "tenant-a"}, grpcMetadataOf(map[string]string{
+			"x-tenant":                           "ignored",
+			GRPCMetadataPrefix:                   "ignored",
+			GRPCMetadataPrefix + "x-tenant":      "tenant-a",
+			GRPCMetadataPrefix + "authorization": "Bearer token",
+		}))
+		assert.Empty(t, grpcMetadataOf(nil))
+	})
+
+	t.Run("grpc metadata should be sent on unary and stream calls", func(t *testing.T) {
+		svc := &metadataServer{}
+		connector := metadataConnectorFor(t, svc)
+
+		require.NoError(t, connector.InitOrDisable(componentName, map[string]string{
+			GRPCMetadataPrefix + "authorization": "Bearer token",
+		}, func() error {
+			return connector.Dial(componentName)
+		}))
+
+		require.NoError(t, connector.Ping())
+
+		stream, err := connector.Client.PullMessages(context.Background())
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.ErrorIs(t, err, io.EOF)
+
+		svc.lock.Lock()
+		defer svc.lock.Unlock()
+		assert.Equal(t, []string{"Bearer token"}, svc.unaryMD.Get("authorization"))
+		assert.Equal(t, []string{"Bearer token"}, svc.streamMD.Get("authorization"))
+	})
+
+	t.Run("no grpc metadata should be sent when not configured", func(t *testing.T) {
+		svc := &metadataServer{}
+		connector := metadataConnectorFor(t, svc)
+
+		require.NoError(t, connector.InitOrDisable(componentName, map[string]string{"authorization": "init-only"}, func() error {
+			return connector.Dial(componentName)
+		}))
+		require.NoError(t, connector.Ping())
+
+		svc.lock.Lock()
+		defer svc.lock.Unlock()
+		assert.Empty(t, svc.unaryMD.Get("authorization"))
+	})
+
+	t.Run("a failed reinit should keep the previous grpc metadata", func(t *testing.T) {
+		svc := &metadataServer{}
+		connector := metadataConnectorFor(t, svc)
+
+		require.NoError(t, connector.InitOrDisable(componentName, map[string]string{
+			GRPCMetadataPrefix + "authorization": "old-token",
+		}, func() error {
+			return connector.Dial(componentName)
+		}))
+		require.Error(t, connector.ReinitWith(componentName, map[string]string{
+			GRPCMetadataPrefix + "authorization": "new-token",
+		}, func() error {
+			return errors.New("reinit failed")
+		}))
+		require.NoError(t, connector.Ping())
+
+		svc.lock.Lock()
+		defer svc.lock.Unlock()
+		assert.Equal(t, []string{"old-token"}, svc.unaryMD.Get("authorization"))
+	})
+}
@@ -14,7 +14,10 @@ limitations under the License.
 package pluggable
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"os"
@@ -26,11 +29,15 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/dapr/dapr/pkg/components"
 	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+	testingGrpc "github.com/dapr/dapr/pkg/testing/grpc"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -190,4 +197,500 @@ func TestGRPCConnector(t *testing.T) {
 
 		assert.NotContains(t, notAcceptedStatus, connector.conn.GetState())
 	})
+
+	t.Run("dial should return socket not found error when the socket file does not exist", func(t *testing.T) {
+		const fakeSocketPath, componentName = "/tmp/not-found-socket.sock", "my-component"
+		os.RemoveAll(fakeSocketPath) // guarantee that does not exist.
+		defer func(timeout time.Duration) {
+			socketWaitTimeout = timeout
+		}(socketWaitTimeout)
+		socketWaitTimeout = 100 * time.Millisecond
+
+		connector := NewGRPCConnector(fakeSocketPath, func(grpc.ClientConnInterface) *fakeClient {
+			return &fakeClient{}
+		})
+		defer connector.Cancel()
+
+		err := connector.Dial(componentName)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrSocketNotFound)
+		assert.ErrorIs(t, err, ErrSocketNotReady)
+		var socketErr *SocketNotFoundError
+		require.ErrorAs(t, err, &socketErr)
+		assert.Equal(t, fakeSocketPath, socketErr.Socket)
+		assert.Equal(t, componentName, socketErr.ComponentName)
+		assert.Contains(t, err.Error(), fakeSocketPath)
+		assert.Contains(t, err.Error(), componentName)
+	})
+
+	t.Run("dial should not return socket not found error when the socket file exists", func(t *testing.T) {
+		const fakeSocketPath = "/tmp/socket.sock"
+		os.RemoveAll(fakeSocketPath) // guarantee that is not being used.
+		defer os.RemoveAll(fakeSocketPath)
+		listener, err := net.Listen("unix", fakeSocketPath)
+		require.NoError(t, err)
+		defer listener.Close()
+
+		connector := NewGRPCConnector(fakeSocketPath, func(grpc.ClientConnInterface) *fakeClient {
+			return &fakeClient{}
+		})
+		require.NoError(t, connector.Dial(""))
+		defer connector.Close()
+	})
+}
+
+func TestGRPCConnectorWithConn(t *testing.T) {
+	t.Run("dial should reuse the given connection and client", func(t *testing.T) {
+		clientFake := &fakeClient{}
+		connector := NewGRPCConnectorWithConn(clientFake, &grpc.ClientConn{})
+
+		require.NoError(t, connector.Dial("my-component"))
+		require.NoError(t, connector.Ping())
+		assert.Same(t, clientFake, connector.Client)
+		assert.Equal(t, int64(1), clientFake.pingCalled.Load())
+	})
+
+	t.Run("dial should fail when the pluggable descriptor is invalid", func(t *testing.T) {
+		dialCalled := 0
+		connector := NewGRPCConnectorWithDialer(func(context.Context, string, ...grpc.DialOption) (*grpc.ClientConn, error) {
+			dialCalled++
+			return nil, nil
+		}, func(grpc.ClientConnInterface) *fakeClient {
+			return &fakeClient{}
+		}, WithPluggable(components.Pluggable{Type: components.CategoryStateStore, Name: "../my-component"}))
+
+		assert.ErrorIs(t, connector.Dial("my-component"), components.ErrInvalidPluggable)
+		assert.Equal(t, 0, dialCalled)
+	})
+}
+
+func TestPingContext(t *testing.T) {
+	// deadConnector returns a connector whose in-memory server is no longer accepting connections.
+	deadConnector := func(t *testing.T) *GRPCConnector[proto.PubSubClient] {
+		dialer, cleanup, err := testingGrpc.TestServerWithDialer(testLogger, func(s *grpc.Server, svc *pingServer) {
+			proto.RegisterPubSubServer(s, svc)
+		})(&pingServer{})
+		require.NoError(t, err)
+		cleanup()
+
+		connector := NewGRPCConnectorWithDialer(func(ctx context.Context, _ string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+			return dialer(ctx, opts...)
+		}, proto.NewPubSubClient)
+		require.NoError(t, connector.Dial("my-component"))
+		t.Cleanup(func() { connector.Close() })
+		return connector
+	}
+
+	t.Run("ping context should call the component ping", func(t *testing.T) {
+		svc := &pingServer{}
+		connector := testPubSubConnectorFor(t, svc)
+		require.NoError(t, connector.Dial("my-component"))
+
+		require.NoError(t, connector.PingContext(context.Background(), false))
+		assert.Equal(t, int64(1), svc.pingCalled.Load())
+	})
+
+	t.Run("ping context should return promptly when the context is cancelled", func(t *testing.T) {
+		connector := deadConnector(t)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err := connector.PingContext(ctx, true)
+		require.Error(t, err)
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("ping context should fail fast when not waiting for ready", func(t *testing.T) {
+		connector := deadConnector(t)
+
+		start := time.Now()
+		err := connector.PingContext(context.Background(), false)
+		require.Error(t, err)
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
+
+// flakyPingServer fails the first pings with the given error.
+type flakyPingServer struct {
+	proto.UnimplementedPubSubServer
+	failures   int64
+	err        error
+	pingCalled atomic.Int64
+}
+
+func (s *flakyPingServer) Ping(context.Context, *proto.PingRequest) (*proto.PingResponse, error) {
+	if s.pingCalled.Add(1) <= s.failures {
+		return nil, s.err
+	}
+	return &proto.PingResponse{}, nil
+}
+
+func TestInitialPingRetries(t *testing.T) {
+	flakyConnectorFor := func(t *testing.T, svc *flakyPingServer, opts ...Option) *GRPCConnector[proto.PubSubClient] {
+		return testConnectorFor(t, func(s *grpc.Server, svc *flakyPingServer) {
+			proto.RegisterPubSubServer(s, svc)
+		}, svc, proto.NewPubSubClient, opts...)
+	}
+
+	t.Run("dial should not ping the component by default", func(t *testing.T) {
+		svc := &flakyPingServer{}
+		connector := flakyConnectorFor(t, svc)
+
+		require.NoError(t, connector.Dial("my-component"))
+		assert.Equal(t, int64(0), svc.pingCalled.Load())
+	})
+
+	t.Run("dial should retry the initial ping when the component returns transient errors", func(t *testing.T) {
+		svc := &flakyPingServer{failures: 2, err: status.Error(codes.Unavailable, "warming up")}
+		connector := flakyConnectorFor(t, svc, WithInitialPingRetries(3, time.Millisecond))
+
+		require.NoError(t, connector.Dial("my-component"))
+		assert.Equal(t, int64(3), svc.pingCalled.Load())
+	})
+
+	t.Run("dial should fail when the initial ping keeps failing after the retries", func(t *testing.T) {
+		svc := &flakyPingServer{failures: 2, err: status.Error(codes.Unavailable, "warming up")}
+		connector := flakyConnectorFor(t, svc, WithInitialPingRetries(1, time.Millisecond))
+
+		err := connector.Dial("my-component")
+		require.Error(t, err)
+		assert.Equal(t, codes.Unavailable, status.Code(errors.Unwrap(err)))
+		assert.Equal(t, int64(2), svc.pingCalled.Load())
+	})
+}
+
+func TestClose(t *testing.T) {
+	t.Run("close should be nil-safe when the component was not dialed", func(t *testing.T) {
+		connector := NewGRPCConnectorWithDialer(func(context.Context, string, ...grpc.DialOption) (*grpc.ClientConn, error) {
+			return nil, errors.New("dial failed")
+		}, func(grpc.ClientConnInterface) *fakeClient {
+			return &fakeClient{}
+		})
+
+		require.Error(t, connector.Dial("my-component"))
+		assert.NoError(t, connector.Close())
+		assert.ErrorIs(t, connector.Context.Err(), context.Canceled)
+	})
+
+	t.Run("close should be idempotent", func(t *testing.T) {
+		svc := &pingServer{}
+		connector := testPubSubConnectorFor(t, svc)
+		require.NoError(t, connector.Dial("my-component"))
+
+		require.NoError(t, connector.Close())
+		assert.NoError(t, connector.Close())
+		assert.ErrorIs(t, connector.Context.Err(), context.Canceled)
+	})
+}
+
+func TestInitOnce(t *testing.T) {
+	t.Run("init should be called once for the same component instance", func(t *testing.T) {
+		connector := NewGRPCConnectorWithConn(&fakeClient{}, &grpc.ClientConn{}, WithPluggable(components.Pluggable{Version: "v1"}))
+		assert.False(t, connector.Initialized())
+
+		initCalled := 0
+		init := func() error {
+			initCalled++
+			return nil
+		}
+		require.NoError(t, connector.InitOnce("my-component", init))
+		require.NoError(t, connector.InitOnce("my-component", init))
+		assert.Equal(t, 1, initCalled)
+		assert.True(t, connector.Initialized())
+	})
+
+	t.Run("repeated init calls should return the first init result", func(t *testing.T) {
+		connector := NewGRPCConnectorWithConn(&fakeClient{}, &grpc.ClientConn{})
+		fakeErr := errors.New("init failed")
+
+		initCalled := 0
+		init := func() error {
+			initCalled++
+			return fakeErr
+		}
+		assert.ErrorIs(t, connector.InitOnce("my-component", init), fakeErr)
+		assert.ErrorIs(t, connector.InitOnce("my-component", init), fakeErr)
+		assert.Equal(t, 1, initCalled)
+		assert.False(t, connector.Initialized())
+	})
+
+	t.Run("init should be called again for a different component instance", func(t *testing.T) {
+		connector := NewGRPCConnectorWithConn(&fakeClient{}, &grpc.ClientConn{})
+
+		initCalled := 0
+		init := func() error {
+			initCalled++
+			return nil
+		}
+		require.NoError(t, connector.InitOnce("my-component", init))
+		require.NoError(t, connector.InitOnce("my-other-component", init))
+		assert.Equal(t, 2, initCalled)
+	})
+}
+
+func TestInitOrAbort(t *testing.T) {
+	t.Run("init should be aborted and the connection closed when the context is cancelled mid-init", func(t *testing.T) {
+		registry := NewRegistry()
+		connector := testPubSubConnectorFor(t, &pingServer{}, withRegistry(registry))
+		ctx, cancel := context.WithCancel(context.Background())
+
+		err := connector.InitOrAbort(ctx, func() error {
+			return connector.InitOrDisable("my-component", map[string]string{}, func() error {
+				if err := connector.Dial("my-component"); err != nil {
+					return err
+				}
+				cancel() // the component is removed while its init is in progress.
+				<-connector.Context.Done()
+				return connector.Context.Err()
+			})
+		})
+
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, connectivity.Shutdown, connector.conn.GetState())
+		assert.Empty(t, registry.Snapshot())
+	})
+
+	t.Run("init should keep the connection open when it completes before the context is done", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{})
+		ctx, cancel := context.WithCancel(context.Background())
+
+		err := connector.InitOrAbort(ctx, func() error {
+			return connector.Dial("my-component")
+		})
+		cancel()
+
+		require.NoError(t, err)
+		assert.NoError(t, connector.Context.Err())
+		assert.NotEqual(t, connectivity.Shutdown, connector.conn.GetState())
+	})
+
+	t.Run("init errors should be returned as is", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{})
+		initErr := errors.New("init failed")
+
+		err := connector.InitOrAbort(context.Background(), func() error {
+			return initErr
+		})
+
+		assert.Same(t, initErr, err)
+	})
+}
+
+func TestReinitWith(t *testing.T) {
+	t.Run("reinit should return ErrReinitUnsupported when the component was not initialized", func(t *testing.T) {
+		connector := NewGRPCConnectorWithConn(&fakeClient{}, &grpc.ClientConn{})
+
+		reinitCalled := 0
+		err := connector.ReinitWith("my-component", nil, func() error {
+			reinitCalled++
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrReinitUnsupported)
+		assert.Equal(t, 0, reinitCalled)
+	})
+
+	t.Run("reinit should call the reinit function when the component is initialized", func(t *testing.T) {
+		connector := NewGRPCConnectorWithConn(&fakeClient{}, &grpc.ClientConn{})
+		require.NoError(t, connector.InitOnce("my-component", func() error { return nil }))
+
+		reinitCalled := 0
+		require.NoError(t, connector.ReinitWith("my-component", nil, func() error {
+			reinitCalled++
+			return nil
+		}))
+		assert.Equal(t, 1, reinitCalled)
+		assert.True(t, connector.Initialized())
+	})
+
+	t.Run("reinit should return ErrReinitUnsupported when the component replies unimplemented", func(t *testing.T) {
+		connector := NewGRPCConnectorWithConn(&fakeClient{}, &grpc.ClientConn{})
+		require.NoError(t, connector.InitOnce("my-component", func() error { return nil }))
+
+		err := connector.ReinitWith("my-component", nil, func() error {
+			return status.Error(codes.Unimplemented, "not supported")
+		})
+		assert.ErrorIs(t, err, ErrReinitUnsupported)
+	})
+
+	t.Run("reinit should return other errors as is", func(t *testing.T) {
+		connector := NewGRPCConnectorWithConn(&fakeClient{}, &grpc.ClientConn{})
+		require.NoError(t, connector.InitOnce("my-component", func() error { return nil }))
+
+		fakeErr := errors.New("reinit failed")
+		err := connector.ReinitWith("my-component", nil, func() error {
+			return fakeErr
+		})
+		assert.ErrorIs(t, err, fakeErr)
+		assert.NotErrorIs(t, err, ErrReinitUnsupported)
+	})
+
+	t.Run("reinit should return ErrReinitUnsupported when the new properties disable the component", func(t *testing.T) {
+		connector := NewGRPCConnectorWithConn(&fakeClient{}, &grpc.ClientConn{})
+		require.NoError(t, connector.InitOnce("my-component", func() error { return nil }))
+
+		err := connector.ReinitWith("my-component", map[string]string{DisabledMetadataKey: "true"}, func() error {
+			return nil
+		})
+		assert.ErrorIs(t, err, ErrReinitUnsupported)
+	})
+}
+
+func TestCaptureInfo(t *testing.T) {
+	t.Run("capture info should read the version info from the component ping", func(t *testing.T) {
+		svc := &pingServer{pingResp: &proto.PingResponse{
+			Version:         "v1.2.3",
+			BuildSha:        "abc123",
+			ProtocolVersion: 1,
+		}}
+		connector := testPubSubConnectorFor(t, svc)
+		require.NoError(t, connector.Dial("my-component"))
+		assert.Equal(t, ComponentInfo{}, connector.Info())
+
+		connector.CaptureInfo()
+		assert.Equal(t, ComponentInfo{Version: "v1.2.3", BuildSHA: "abc123", ProtocolVersion: 1}, connector.Info())
+	})
+
+	t.Run("capture info should keep an empty info for components that don't report it", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{})
+		require.NoError(t, connector.Dial("my-component"))
+
+		connector.CaptureInfo()
+		assert.Equal(t, ComponentInfo{}, connector.Info())
+	})
+
+	t.Run("capture info should ignore ping errors", func(t *testing.T) {
+		svc := &pingServer{pingErr: errors.New("fake-err")}
+		connector := testPubSubConnectorFor(t, svc)
+		require.NoError(t, connector.Dial("my-component"))
+
+		connector.CaptureInfo()
+		assert.Equal(t, ComponentInfo{}, connector.Info())
+		assert.Equal(t, int64(1), svc.pingCalled.Load())
+	})
+}
+
+func TestReportInitWarnings(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	log.EnableJSONOutput(true)
+	defer func() {
+		log.SetOutput(os.Stdout)
+		log.EnableJSONOutput(false)
+	}()
+
+	connector := testPubSubConnectorFor(t, &pingServer{}, WithPluggable(components.Pluggable{Type: components.CategoryPubSub, Name: "my-component"}), withRegistry(NewRegistry()))
+	require.NoError(t, connector.Dial("my-instance"))
+
+	t.Run("init warnings should be logged at warn level with the component fields", func(t *testing.T) {
+		buf.Reset()
+		connector.ReportInitWarnings([]string{"TLS not configured, using plaintext"})
+
+		var entry map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+		assert.Equal(t, "warning", entry["level"])
+		assert.Contains(t, entry["msg"], "my-instance")
+		assert.Contains(t, entry["msg"], "TLS not configured, using plaintext")
+		assert.Equal(t, "my-component", entry["component_name"])
+	})
+
+	t.Run("init warnings should be listed in the component status", func(t *testing.T) {
+		connector.ReportInitWarnings([]string{"first", "second"})
+		assert.Equal(t, []string{"first", "second"}, connector.Status().InitWarnings)
+	})
+
+	t.Run("init warnings should be replaced on the next init", func(t *testing.T) {
+		connector.ReportInitWarnings([]string{"first"})
+		connector.ReportInitWarnings(nil)
+		assert.Empty(t, connector.Status().InitWarnings)
+	})
+}
+
+func TestCheckProtocolVersion(t *testing.T) {
+	defaultMinProtocolVersion := minProtocolVersion
+	defer func() {
+		minProtocolVersion = defaultMinProtocolVersion
+	}()
+	minProtocolVersion = 1
+
+	connector := NewGRPCConnectorWithConn(&fakeClient{}, &grpc.ClientConn{})
+
+	t.Run("compatible protocol versions should be accepted", func(t *testing.T) {
+		require.NoError(t, connector.CheckProtocolVersion(1))
+		require.NoError(t, connector.CheckProtocolVersion(ProtocolVersion+1))
+	})
+
+	t.Run("protocol versions below the minimum should be rejected", func(t *testing.T) {
+		err := connector.CheckProtocolVersion(0)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrIncompatibleProtocolVersion)
+	})
+}
+
+func TestWaitForSocket(t *testing.T) {
+	// gRPC Pluggable component requires Unix Domain Socket to work, I'm skipping this test when running on windows.
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	t.Run("wait for socket should return when the socket is created after a short delay", func(t *testing.T) {
+		const fakeSocketPath = "/tmp/delayed-socket.sock"
+		os.RemoveAll(fakeSocketPath) // guarantee that is not being used.
+		defer os.RemoveAll(fakeSocketPath)
+
+		listenerChan := make(chan net.Listener, 1)
+		go func() {
+			time.Sleep(100 * time.Millisecond)
+			listener, err := net.Listen("unix", fakeSocketPath)
+			assert.NoError(t, err)
+			listenerChan <- listener
+		}()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		require.NoError(t, WaitForSocket(ctx, fakeSocketPath))
+
+		listener := <-listenerChan
+		if listener != nil {
+			listener.Close()
+		}
+	})
+
+	t.Run("wait for socket should return not ready error when the socket is never created", func(t *testing.T) {
+		const fakeSocketPath = "/tmp/never-created-socket.sock"
+		os.RemoveAll(fakeSocketPath) // guarantee that does not exist.
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		err := WaitForSocket(ctx, fakeSocketPath)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrSocketNotReady)
+		assert.Contains(t, err.Error(), fakeSocketPath)
+	})
+}
+
+func TestComponentLogger(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	log.EnableJSONOutput(true)
+	defer func() {
+		log.SetOutput(os.Stdout)
+		log.EnableJSONOutput(false)
+	}()
+
+	componentLogger(components.Pluggable{
+		Type:    components.CategoryStateStore,
+		Name:    "my-component",
+		Version: "v1",
+	}).Info("fake-message")
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, "fake-message", entry["msg"])
+	assert.Equal(t, "state", entry["component_type"])
+	assert.Equal(t, "my-component", entry["component_name"])
+	assert.Equal(t, "v1", entry["component_version"])
 }
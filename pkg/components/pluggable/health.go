@@ -0,0 +1,231 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+const (
+	// DefaultHealthCheckInterval is the default interval between the discovered components health checks.
+	DefaultHealthCheckInterval = 5 * time.Second
+	// DefaultEvictionGracePeriod is the default amount of time a component can be unhealthy before being evicted.
+	DefaultEvictionGracePeriod = 30 * time.Second
+)
+
+var (
+	discoveredServicesLock sync.Mutex
+	discoveredServices     []service
+)
+
+// setDiscoveredServices stores the discovered services so they can be health monitored.
+func setDiscoveredServices(services []service) {
+	discoveredServicesLock.Lock()
+	defer discoveredServicesLock.Unlock()
+	discoveredServices = services
+}
+
+// getDiscoveredServices returns the last discovered services.
+func getDiscoveredServices() []service {
+	discoveredServicesLock.Lock()
+	defer discoveredServicesLock.Unlock()
+	return discoveredServices
+}
+
+// unavailableDialer returns a dialer that always fails with ErrComponentUnavailable, it is registered in place of evicted components.
+func unavailableDialer(componentName string) GRPCConnectionDialer {
+	return func(context.Context, string, ...grpc.DialOption) (*grpc.ClientConn, error) {
+		return nil, fmt.Errorf("%w: component '%s' was evicted after being unhealthy, waiting for it to recover", ErrComponentUnavailable, componentName)
+	}
+}
+
+// setAvailable marks the component instance available or not, data plane calls made to unavailable instances fail fast with ErrComponentUnavailable.
+func (g *GRPCConnector[TClient]) setAvailable(available bool) {
+	g.unavailable.Store(!available)
+}
+
+// checkAvailable returns an ErrComponentUnavailable error when the component instance is evicted and the given method is a data plane call.
+func (g *GRPCConnector[TClient]) checkAvailable(method string) error {
+	if !g.unavailable.Load() || isLifecycleMethod(method) {
+		return nil
+	}
+	return fmt.Errorf("%w: component '%s' was evicted after being unhealthy, waiting for it to recover", ErrComponentUnavailable, g.options.pluggable.Name)
+}
+
+// availabilityUnaryInterceptor returns a grpc client unary interceptor that fails the calls made while the component is evicted.
+func (g *GRPCConnector[TClient]) availabilityUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := g.checkAvailable(method); err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// availabilityStreamInterceptor returns a grpc client stream interceptor that fails the streams opened while the component is evicted.
+func (g *GRPCConnector[TClient]) availabilityStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if err := g.checkAvailable(method); err != nil {
+			return nil, err
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
+
+// pingConn pings the given service through the given connection with a fail-fast call.
+func pingConn(ctx context.Context, conn *grpc.ClientConn, protoRef string) error {
+	return conn.Invoke(ctx, "/"+protoRef+"/Ping", &proto.PingRequest{}, &proto.PingResponse{}, grpc.WaitForReady(false))
+}
+
+// healthMonitor evicts the components that are unhealthy beyond the grace period and registers them again when they recover.
+type healthMonitor struct {
+	gracePeriod time.Duration
+	ping        func(context.Context, service) error
+	now         func() time.Time
+	// unhealthySince holds the time of the first failed health check of each unhealthy component.
+	unhealthySince map[string]time.Time
+	// evicted holds the currently evicted components.
+	evicted map[string]bool
+	// conns holds the health check connections by socket, they are dialed once and reused across checks.
+	conns map[string]*grpc.ClientConn
+	// registry holds the loaded component instances marked unavailable while their component is evicted.
+	registry *Registry
+}
+
+func newHealthMonitor(gracePeriod time.Duration) *healthMonitor {
+	h := &healthMonitor{
+		gracePeriod:    gracePeriod,
+		now:            time.Now,
+		unhealthySince: make(map[string]time.Time),
+		evicted:        make(map[string]bool),
+		conns:          make(map[string]*grpc.ClientConn),
+		registry:       DefaultRegistry,
+	}
+	h.ping = h.pingService
+	return h
+}
+
+// pingService pings the given service reusing the connection to its socket from the previous checks.
+func (h *healthMonitor) pingService(ctx context.Context, svc service) error {
+	conn, ok := h.conns[svc.socket]
+	if !ok {
+		var err error
+		if conn, err = SocketDial(ctx, svc.socket); err != nil {
+			return err
+		}
+		h.conns[svc.socket] = conn
+	}
+	return pingConn(ctx, conn, svc.protoRef)
+}
+
+// close closes the health check connections.
+func (h *healthMonitor) close() {
+	for socket, conn := range h.conns {
+		conn.Close()
+		delete(h.conns, socket)
+	}
+}
+
+// pingAll pings every given service, returning the first failure.
+func (h *healthMonitor) pingAll(ctx context.Context, services []service) error {
+	for _, svc := range services {
+		if err := h.ping(ctx, svc); err != nil {
+			return fmt.Errorf("service '%s': %w", svc.protoRef, err)
+		}
+	}
+	return nil
+}
+
+// check health checks the given services grouped by component.
+func (h *healthMonitor) check(ctx context.Context, services []service) {
+	byComponent := make(map[string][]service)
+	for _, svc := range services {
+		if _, ok := onServiceDiscovered[svc.protoRef]; !ok { // ignoring unknown service
+			continue
+		}
+		byComponent[svc.componentName] = append(byComponent[svc.componentName], svc)
+	}
+
+	for componentName, componentServices := range byComponent {
+		if err := h.pingAll(ctx, componentServices); err != nil {
+			h.unhealthy(componentName, componentServices, err)
+			continue
+		}
+		h.healthy(componentName, componentServices)
+	}
+}
+
+// unhealthy evicts the component when it is unhealthy beyond the grace period.
+func (h *healthMonitor) unhealthy(componentName string, services []service, err error) {
+	since, ok := h.unhealthySince[componentName]
+	if !ok {
+		h.unhealthySince[componentName] = h.now()
+		log.Warnf("pluggable component '%s' is unhealthy: %v", componentName, err)
+		return
+	}
+	if h.evicted[componentName] || h.now().Sub(since) < h.gracePeriod {
+		return
+	}
+
+	dialer := unavailableDialer(componentName)
+	for _, svc := range services {
+		onServiceDiscovered[svc.protoRef](componentName, dialer)
+	}
+	h.registry.setAvailable(componentName, false)
+	h.evicted[componentName] = true
+	log.Errorf("pluggable component '%s' was evicted after being unhealthy for more than %s: %v", componentName, h.gracePeriod, err)
+}
+
+// healthy registers the component again when it was evicted.
+func (h *healthMonitor) healthy(componentName string, services []service) {
+	delete(h.unhealthySince, componentName)
+	if !h.evicted[componentName] {
+		return
+	}
+
+	for _, svc := range services {
+		onServiceDiscovered[svc.protoRef](componentName, svc.dialer)
+	}
+	h.registry.setAvailable(componentName, true)
+	delete(h.evicted, componentName)
+	log.Infof("pluggable component '%s' recovered and was registered again", componentName)
+}
+
+// MonitorHealth health checks every service of the discovered components every interval until the context is done.
+// it is disabled unless enabled through the runtime registry options. components that are unhealthy beyond the grace period are evicted, so new instances fail with ErrComponentUnavailable instead of timing out,
+// they are registered again as soon as they become healthy.
+func MonitorHealth(ctx context.Context, interval, gracePeriod time.Duration) {
+	monitor := newHealthMonitor(gracePeriod)
+	defer monitor.close()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			checkCtx, cancel := context.WithTimeout(ctx, interval)
+			monitor.check(checkCtx, getDiscoveredServices())
+			cancel()
+		}
+	}
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dapr/dapr/pkg/components"
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+func TestHealthMonitor(t *testing.T) {
+	const (
+		fakeComponentName = "fake-health-comp"
+		fakeServiceName   = "fake-health-svc"
+		gracePeriod       = time.Minute
+	)
+	errDialerCalled := errors.New("original dialer called")
+	fakeDialer := func(context.Context, string, ...grpc.DialOption) (*grpc.ClientConn, error) {
+		return nil, errDialerCalled
+	}
+
+	var registered []GRPCConnectionDialer
+	AddServiceDiscoveryCallback(fakeServiceName, func(name string, dialer GRPCConnectionDialer) {
+		assert.Equal(t, fakeComponentName, name)
+		registered = append(registered, dialer)
+	})
+	services := []service{{protoRef: fakeServiceName, componentName: fakeComponentName, dialer: fakeDialer}}
+
+	newMonitor := func(pingErr *error, now *time.Time) *healthMonitor {
+		registered = nil
+		monitor := newHealthMonitor(gracePeriod)
+		monitor.registry = NewRegistry()
+		monitor.ping = func(context.Context, service) error {
+			return *pingErr
+		}
+		monitor.now = func() time.Time {
+			return *now
+		}
+		return monitor
+	}
+
+	t.Run("unhealthy components should not be evicted within the grace period", func(t *testing.T) {
+		pingErr, now := errors.New("unavailable"), time.Now()
+		monitor := newMonitor(&pingErr, &now)
+
+		monitor.check(context.Background(), services)
+		now = now.Add(gracePeriod / 2)
+		monitor.check(context.Background(), services)
+
+		assert.Empty(t, registered)
+	})
+
+	t.Run("components unhealthy beyond the grace period should be evicted", func(t *testing.T) {
+		pingErr, now := errors.New("unavailable"), time.Now()
+		monitor := newMonitor(&pingErr, &now)
+
+		monitor.check(context.Background(), services)
+		now = now.Add(gracePeriod)
+		monitor.check(context.Background(), services)
+		now = now.Add(gracePeriod)
+		monitor.check(context.Background(), services)
+
+		require.Len(t, registered, 1, "components should be evicted only once")
+		_, err := registered[0](context.Background(), fakeComponentName)
+		assert.ErrorIs(t, err, ErrComponentUnavailable)
+	})
+
+	t.Run("evicted components should be registered again when they recover", func(t *testing.T) {
+		pingErr, now := errors.New("unavailable"), time.Now()
+		monitor := newMonitor(&pingErr, &now)
+
+		monitor.check(context.Background(), services)
+		now = now.Add(gracePeriod)
+		monitor.check(context.Background(), services)
+		pingErr = nil
+		monitor.check(context.Background(), services)
+		monitor.check(context.Background(), services)
+
+		require.Len(t, registered, 2)
+		_, err := registered[1](context.Background(), fakeComponentName)
+		assert.ErrorIs(t, err, errDialerCalled)
+	})
+
+	t.Run("already loaded instances should fail fast while the component is evicted", func(t *testing.T) {
+		pingErr, now := errors.New("unavailable"), time.Now()
+		monitor := newMonitor(&pingErr, &now)
+		pc := components.Pluggable{Type: components.CategoryPubSub, Name: fakeComponentName, Version: "v1"}
+		connector := testPubSubConnectorFor(t, &pingServer{}, WithPluggable(pc), withRegistry(monitor.registry))
+		require.NoError(t, connector.Dial("my-pubsub"))
+		other := testPubSubConnectorFor(t, &pingServer{}, WithPluggable(components.Pluggable{Type: components.CategoryPubSub, Name: "other-comp", Version: "v1"}), withRegistry(monitor.registry))
+		require.NoError(t, other.Dial("other-pubsub"))
+
+		monitor.check(context.Background(), services)
+		now = now.Add(gracePeriod)
+		monitor.check(context.Background(), services)
+
+		_, err := connector.Client.Publish(context.Background(), &proto.PublishRequest{})
+		assert.ErrorIs(t, err, ErrComponentUnavailable)
+		_, err = other.Client.Publish(context.Background(), &proto.PublishRequest{})
+		assert.Equal(t, codes.Unimplemented, status.Code(err), "instances of other components should not be affected")
+		require.NoError(t, connector.Ping(), "lifecycle calls should not be failed")
+
+		pingErr = nil
+		monitor.check(context.Background(), services)
+		_, err = connector.Client.Publish(context.Background(), &proto.PublishRequest{})
+		assert.Equal(t, codes.Unimplemented, status.Code(err), "recovered instances should be called again")
+	})
+
+	t.Run("recovered components should restart the grace period", func(t *testing.T) {
+		pingErr, now := errors.New("unavailable"), time.Now()
+		monitor := newMonitor(&pingErr, &now)
+
+		monitor.check(context.Background(), services)
+		now = now.Add(gracePeriod / 2)
+		pingErr = nil
+		monitor.check(context.Background(), services)
+		pingErr = errors.New("unavailable")
+		now = now.Add(gracePeriod / 2)
+		monitor.check(context.Background(), services)
+		now = now.Add(gracePeriod / 2)
+		monitor.check(context.Background(), services)
+
+		assert.Empty(t, registered)
+	})
+
+	t.Run("every service of the component should be health checked", func(t *testing.T) {
+		const otherServiceName = "fake-health-other-svc"
+		AddServiceDiscoveryCallback(otherServiceName, func(string, GRPCConnectionDialer) {})
+		pingErr, now := error(nil), time.Now()
+		monitor := newMonitor(&pingErr, &now)
+		var pinged []string
+		monitor.ping = func(_ context.Context, svc service) error {
+			pinged = append(pinged, svc.protoRef)
+			if svc.protoRef == otherServiceName {
+				return errors.New("unavailable")
+			}
+			return nil
+		}
+
+		monitor.check(context.Background(), append(services, service{protoRef: otherServiceName, componentName: fakeComponentName}))
+		assert.Equal(t, []string{fakeServiceName, otherServiceName}, pinged)
+		assert.Contains(t, monitor.unhealthySince, fakeComponentName)
+	})
+
+	t.Run("unknown services should not be health checked", func(t *testing.T) {
+		pingErr, now := errors.New("unavailable"), time.Now()
+		monitor := newMonitor(&pingErr, &now)
+		pingCalled := 0
+		monitor.ping = func(context.Context, service) error {
+			pingCalled++
+			return pingErr
+		}
+
+		monitor.check(context.Background(), []service{{protoRef: "unknown-svc", componentName: fakeComponentName}})
+		assert.Equal(t, 0, pingCalled)
+	})
+}
+
+func TestPingService(t *testing.T) {
+	// gRPC Pluggable component requires Unix Domain Socket to work, I'm skipping this test when running on windows.
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	//nolint:nosnakecase
+	svc := service{protoRef: proto.PubSub_ServiceDesc.ServiceName, socket: filepath.Join(t.TempDir(), "ping.sock")}
+
+	t.Run("ping should fail when the component is not running", func(t *testing.T) {
+		monitor := newHealthMonitor(time.Minute)
+		defer monitor.close()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		assert.Error(t, monitor.pingService(ctx, svc))
+	})
+
+	t.Run("ping should call the component ping", func(t *testing.T) {
+		listener, err := net.Listen("unix", svc.socket)
+		require.NoError(t, err)
+		defer listener.Close()
+		s := grpc.NewServer()
+		srv := &pingServer{}
+		proto.RegisterPubSubServer(s, srv)
+		go s.Serve(listener)
+		defer s.Stop()
+
+		monitor := newHealthMonitor(time.Minute)
+		defer monitor.close()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, monitor.pingService(ctx, svc))
+		assert.Equal(t, int64(1), srv.pingCalled.Load())
+	})
+
+	t.Run("the connection should be reused across pings", func(t *testing.T) {
+		listener, err := net.Listen("unix", svc.socket)
+		require.NoError(t, err)
+		defer listener.Close()
+		s := grpc.NewServer()
+		srv := &pingServer{}
+		proto.RegisterPubSubServer(s, srv)
+		go s.Serve(listener)
+		defer s.Stop()
+
+		monitor := newHealthMonitor(time.Minute)
+		defer monitor.close()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, monitor.pingService(ctx, svc))
+		conn := monitor.conns[svc.socket]
+		require.NoError(t, monitor.pingService(ctx, svc))
+		assert.Same(t, conn, monitor.conns[svc.socket])
+		assert.Equal(t, int64(2), srv.pingCalled.Load())
+	})
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+)
+
+// replicaSeparator separates the component name from the replica identifier in the socket file name,
+// e.g. 'my-component@1.sock' and 'my-component@2.sock' are two replicas of the 'my-component' component.
+const replicaSeparator = "@"
+
+// roundRobinServiceConfig is the gRPC service config that spreads calls across all ready sockets.
+// sockets whose connection fails are removed from the rotation until they are reachable again.
+const roundRobinServiceConfig = `{"loadBalancingConfig":[{"round_robin":{}}]}`
+
+// componentNameOf returns the component name of the given socket file name, removing its namespace, extension and replica identifier.
+func componentNameOf(fileName string) string {
+	_, fileName = socketNamespaceOf(fileName)
+	name := removeExt(fileName)
+	if idx := strings.LastIndex(name, replicaSeparator); idx > 0 {
+		return name[:idx]
+	}
+	return name
+}
+
+// multiSocketDialer creates a dialer that balances the calls across all given sockets using the round robin load balancer.
+func multiSocketDialer(sockets []string, additionalOpts ...grpc.DialOption) GRPCConnectionDialer {
+	return func(ctx context.Context, name string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+		addresses := make([]resolver.Address, len(sockets))
+		for i, socket := range sockets {
+			addresses[i] = resolver.Address{Addr: socket}
+		}
+		r := manual.NewBuilderWithScheme("pluggable-" + uuid.NewString())
+		r.InitialState(resolver.State{Addresses: addresses})
+
+		dialOpts := append([]grpc.DialOption{
+			grpc.WithResolvers(r),
+			grpc.WithDefaultServiceConfig(roundRobinServiceConfig),
+			grpc.WithTransportCredentials(insecure.NewCredentials()),
+			grpc.WithContextDialer(func(ctx context.Context, socket string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+			}),
+			grpc.WithStreamInterceptor(instanceIDStreamInterceptor(name)),
+			grpc.WithUnaryInterceptor(instanceIDUnaryInterceptor(name)),
+		}, additionalOpts...)
+
+		log.Debugf("balancing component '%s' across sockets %v", name, sockets)
+		grpcConn, err := grpc.DialContext(ctx, r.Scheme()+":///"+name, append(dialOpts, opts...)...)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open GRPC connection using sockets %v: %w", sockets, err)
+		}
+		return grpcConn, nil
+	}
+}
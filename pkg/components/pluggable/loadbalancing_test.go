@@ -0,0 +1,113 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+type countingStateStore struct {
+	proto.UnimplementedStateStoreServer
+	pingCalled atomic.Int64
+}
+
+func (c *countingStateStore) Ping(context.Context, *proto.PingRequest) (*proto.PingResponse, error) {
+	c.pingCalled.Add(1)
+	return &proto.PingResponse{}, nil
+}
+
+func serveCountingStateStore(t *testing.T, socket string) (*countingStateStore, *grpc.Server) {
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	srv := &countingStateStore{}
+	server := grpc.NewServer()
+	proto.RegisterStateStoreServer(server, srv)
+	go server.Serve(listener)
+	return srv, server
+}
+
+func TestComponentNameOf(t *testing.T) {
+	assert.Equal(t, "my-component", componentNameOf("my-component.sock"))
+	assert.Equal(t, "my-component", componentNameOf("my-component@1.sock"))
+	assert.Equal(t, "my-component", componentNameOf("my-component@replica-2.sock"))
+	assert.Equal(t, "@my-component", componentNameOf("@my-component.sock"))
+}
+
+func TestMultiSocketDialer(t *testing.T) {
+	// gRPC Pluggable component requires Unix Domain Socket to work, I'm skipping this test when running on windows.
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	socketFolder, err := os.MkdirTemp("/tmp", "replicas")
+	require.NoError(t, err)
+	defer os.RemoveAll(socketFolder)
+
+	socketA, socketB := filepath.Join(socketFolder, "comp@a.sock"), filepath.Join(socketFolder, "comp@b.sock")
+	backendA, serverA := serveCountingStateStore(t, socketA)
+	defer serverA.Stop()
+	backendB, serverB := serveCountingStateStore(t, socketB)
+	defer serverB.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := multiSocketDialer([]string{socketA, socketB}, grpc.WithBlock())(ctx, "comp")
+	require.NoError(t, err)
+	defer conn.Close()
+	client := proto.NewStateStoreClient(conn)
+
+	t.Run("calls should be distributed across all sockets", func(t *testing.T) {
+		// the round robin balancer picks only ready connections, so all sockets are awaited to be used.
+		assert.Eventually(t, func() bool {
+			_, err := client.Ping(ctx, &proto.PingRequest{}, grpc.WaitForReady(true))
+			require.NoError(t, err)
+			return backendA.pingCalled.Load() > 0 && backendB.pingCalled.Load() > 0
+		}, 5*time.Second, time.Millisecond)
+	})
+
+	t.Run("removing a socket should shift all traffic to the remaining ones", func(t *testing.T) {
+		serverA.Stop()
+
+		// wait for the balancer to remove the dead socket from the rotation.
+		assert.Eventually(t, func() bool {
+			before := backendB.pingCalled.Load()
+			for i := 0; i < 10; i++ {
+				if _, err := client.Ping(ctx, &proto.PingRequest{}, grpc.WaitForReady(true)); err != nil {
+					return false
+				}
+			}
+			return backendB.pingCalled.Load()-before == 10
+		}, 5*time.Second, 10*time.Millisecond)
+
+		pingsA := backendA.pingCalled.Load()
+		for i := 0; i < 10; i++ {
+			_, err := client.Ping(ctx, &proto.PingRequest{}, grpc.WaitForReady(true))
+			require.NoError(t, err)
+		}
+		assert.Equal(t, pingsA, backendA.pingCalled.Load())
+	})
+}
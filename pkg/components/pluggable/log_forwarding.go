@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+	"github.com/dapr/dapr/utils"
+)
+
+// ForwardLogsMetadataKey is the component metadata key used to forward the component logs to the sidecar logger,
+// the component must implement the optional ComponentLogs service.
+const ForwardLogsMetadataKey = "dapr.io/forward-logs"
+
+// ForwardsLogs returns true when the given component metadata properties enable the component log forwarding.
+func ForwardsLogs(properties map[string]string) bool {
+	return utils.IsTruthy(properties[ForwardLogsMetadataKey])
+}
+
+// forwardLogs streams the component log entries and emits them through the connector logger until the stream ends.
+// components that don't implement the ComponentLogs service are ignored.
+func (g *GRPCConnector[TClient]) forwardLogs(name string) {
+	if g.conn == nil {
+		return
+	}
+	stream, err := proto.NewComponentLogsClient(g.conn).Log(g.Context, &proto.LogRequest{})
+	if err != nil {
+		g.logger.Warnf("could not forward the logs of pluggable component instance '%s': %v", name, err)
+		return
+	}
+
+	for {
+		entry, err := stream.Recv()
+		if err != nil {
+			switch {
+			case errors.Is(err, io.EOF), status.Code(err) == codes.Canceled:
+			case status.Code(err) == codes.Unimplemented:
+				g.logger.Warnf("pluggable component instance '%s' does not support log forwarding", name)
+			default:
+				g.logger.Warnf("stopped forwarding the logs of pluggable component instance '%s': %v", name, err)
+			}
+			return
+		}
+		g.emitLog(entry)
+	}
+}
+
+// emitLog emits the given component log entry through the connector logger with the entry fields attached.
+// fatal entries are emitted as errors so they don't stop the sidecar.
+func (g *GRPCConnector[TClient]) emitLog(entry *proto.LogEntry) {
+	l := g.logger
+	if len(entry.GetFields()) > 0 {
+		fields := make(map[string]any, len(entry.GetFields()))
+		for k, v := range entry.GetFields() {
+			fields[k] = v
+		}
+		l = l.WithFields(fields)
+	}
+
+	switch strings.ToLower(entry.GetLevel()) {
+	case "debug":
+		l.Debug(entry.GetMessage())
+	case "warn", "warning":
+		l.Warn(entry.GetMessage())
+	case "error", "fatal":
+		l.Error(entry.GetMessage())
+	default:
+		l.Info(entry.GetMessage())
+	}
+}
@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/dapr/dapr/pkg/components"
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+	"github.com/dapr/kit/logger"
+)
+
+// logsServer is a pubsub server that also implements the ComponentLogs service.
+type logsServer struct {
+	pingServer
+	logCalled atomic.Int64
+	entries   []*proto.LogEntry
+}
+
+func (s *logsServer) Log(_ *proto.LogRequest, stream proto.ComponentLogs_LogServer) error {
+	s.logCalled.Add(1)
+	for _, entry := range s.entries {
+		if err := stream.Send(entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestForwardLogs(t *testing.T) {
+	const componentName = "my-pubsub"
+
+	captureLogs := func(t *testing.T) *bytes.Buffer {
+		buf := &bytes.Buffer{}
+		log.SetOutput(buf)
+		log.SetOutputLevel(logger.DebugLevel)
+		log.EnableJSONOutput(true)
+		t.Cleanup(func() {
+			log.SetOutput(os.Stdout)
+			log.SetOutputLevel(logger.InfoLevel)
+			log.EnableJSONOutput(false)
+		})
+		return buf
+	}
+
+	logEntries := func(t *testing.T, buf *bytes.Buffer) []map[string]any {
+		entries := make([]map[string]any, 0)
+		scanner := bufio.NewScanner(bytes.NewReader(buf.Bytes()))
+		for scanner.Scan() {
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(scanner.Bytes(), &entry))
+			entries = append(entries, entry)
+		}
+		return entries
+	}
+
+	connectorFor := func(t *testing.T, svc *logsServer) *GRPCConnector[proto.PubSubClient] {
+		connector := testConnectorFor(t, func(s *grpc.Server, svc *logsServer) {
+			proto.RegisterPubSubServer(s, svc)
+			proto.RegisterComponentLogsServer(s, svc)
+		}, svc, proto.NewPubSubClient, WithPluggable(components.Pluggable{
+			Type: components.CategoryPubSub,
+			Name: componentName,
+		}))
+		require.NoError(t, connector.Dial(""))
+		return connector
+	}
+
+	t.Run("forwarded entries should be emitted with the component fields", func(t *testing.T) {
+		connector := connectorFor(t, &logsServer{entries: []*proto.LogEntry{
+			{Level: "warn", Message: "connection lost", Fields: map[string]string{"broker": "kafka-0"}},
+		}})
+		logs := captureLogs(t)
+		connector.forwardLogs("instance")
+
+		entries := logEntries(t, logs)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "connection lost", entries[0]["msg"])
+		assert.Equal(t, "warning", entries[0]["level"])
+		assert.Equal(t, componentName, entries[0]["component_name"])
+		assert.Equal(t, "kafka-0", entries[0]["broker"])
+	})
+
+	t.Run("fatal and unknown levels should be emitted as error and info", func(t *testing.T) {
+		connector := connectorFor(t, &logsServer{entries: []*proto.LogEntry{
+			{Level: "fatal", Message: "fatal-message"},
+			{Level: "trace", Message: "unknown-level-message"},
+		}})
+		logs := captureLogs(t)
+		connector.forwardLogs("instance")
+
+		entries := logEntries(t, logs)
+		require.Len(t, entries, 2)
+		assert.Equal(t, "error", entries[0]["level"])
+		assert.Equal(t, "info", entries[1]["level"])
+	})
+
+	t.Run("components that don't implement the logs service should be ignored", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{})
+		require.NoError(t, connector.Dial(""))
+		logs := captureLogs(t)
+		connector.forwardLogs("instance")
+
+		assert.Contains(t, logs.String(), "does not support log forwarding")
+	})
+
+	t.Run("logs should be forwarded on init only when enabled by the component metadata", func(t *testing.T) {
+		svc := &logsServer{}
+		connector := connectorFor(t, svc)
+		require.NoError(t, connector.InitOrDisable("instance", map[string]string{}, func() error { return nil }))
+		time.Sleep(100 * time.Millisecond)
+		assert.Equal(t, int64(0), svc.logCalled.Load())
+
+		svc = &logsServer{}
+		connector = connectorFor(t, svc)
+		require.NoError(t, connector.InitOrDisable("instance", map[string]string{ForwardLogsMetadataKey: "true"}, func() error { return nil }))
+		assert.Eventually(t, func() bool {
+			return svc.logCalled.Load() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
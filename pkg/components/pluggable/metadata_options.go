@@ -0,0 +1,331 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/dapr/dapr/utils"
+)
+
+const (
+	// RateLimitMetadataKey is the component metadata key used to cap the rate of the calls made to the component, in calls per second, see WithRateLimit.
+	RateLimitMetadataKey = "dapr.io/rate-limit"
+	// RateLimitBurstMetadataKey is the component metadata key used to set the burst of calls allowed above the rate limit, 1 by default.
+	RateLimitBurstMetadataKey = "dapr.io/rate-limit-burst"
+	// RateLimitModeMetadataKey is the component metadata key used to set the behavior of the calls above the rate limit: 'reject', the default, or 'wait'.
+	RateLimitModeMetadataKey = "dapr.io/rate-limit-mode"
+	// CompressionMetadataKey is the component metadata key used to set the compressor of the outgoing messages, e.g. 'gzip', see WithCompression.
+	CompressionMetadataKey = "dapr.io/compression"
+	// MaxRecvMessageSizeMetadataKey is the component metadata key used to set the max message size in bytes that can be received from the component.
+	MaxRecvMessageSizeMetadataKey = "dapr.io/max-recv-message-size"
+	// MaxSendMessageSizeMetadataKey is the component metadata key used to set the max message size in bytes that can be sent to the component.
+	MaxSendMessageSizeMetadataKey = "dapr.io/max-send-message-size"
+	// RequestLoggingMetadataKey is the component metadata key used to enable logging every call made to the component, see WithRequestLogging.
+	RequestLoggingMetadataKey = "dapr.io/request-logging"
+	// InitialPingRetriesMetadataKey is the component metadata key used to make Dial ping the component, retrying up to the given number of times,
+	// see WithInitialPingRetries.
+	InitialPingRetriesMetadataKey = "dapr.io/initial-ping-retries"
+	// InitialPingBackoffMetadataKey is the component metadata key used to set the initial interval between the initial ping attempts, e.g. '200ms'.
+	InitialPingBackoffMetadataKey = "dapr.io/initial-ping-backoff"
+	// RetryBudgetRatioMetadataKey is the component metadata key used to bound the retries made to the component to the given ratio of its calls,
+	// e.g. '0.1', see WithRetryBudget.
+	RetryBudgetRatioMetadataKey = "dapr.io/retry-budget-ratio"
+	// RetryBudgetMinPerSecMetadataKey is the component metadata key used to set the retries per second allowed on top of the retry budget ratio.
+	RetryBudgetMinPerSecMetadataKey = "dapr.io/retry-budget-min-per-sec"
+	// ChannelzMetadataKey is the component metadata key used to expose the component connection through the channelz service, see WithChannelz.
+	ChannelzMetadataKey = "dapr.io/channelz"
+	// ShutdownTimeoutMetadataKey is the component metadata key used to set the max amount of time to wait for the component shutdown, e.g. '10s',
+	// see WithShutdownTimeout.
+	ShutdownTimeoutMetadataKey = "dapr.io/shutdown-timeout"
+	// FeaturesFallbackMetadataKey is the component metadata key used to set the feature set assumed when the features call keeps failing:
+	// 'conservative', the default, or 'optimistic', see WithFeaturesFallback.
+	FeaturesFallbackMetadataKey = "dapr.io/features-fallback"
+	// FeaturesRetriesMetadataKey is the component metadata key used to set how many times a failed features call is retried, '0' disables the retries.
+	FeaturesRetriesMetadataKey = "dapr.io/features-retries"
+	// FeaturesBackoffMetadataKey is the component metadata key used to set the initial interval between the features call attempts, e.g. '200ms'.
+	FeaturesBackoffMetadataKey = "dapr.io/features-backoff"
+	// WarmupTimeoutMetadataKey is the component metadata key used to set the max amount of time to wait for the component warmup, e.g. '1m',
+	// see WithWarmupTimeout.
+	WarmupTimeoutMetadataKey = "dapr.io/warmup-timeout"
+)
+
+// defaultInitialPingBackoff is the initial interval between the initial ping attempts enabled through the component metadata without a backoff.
+const defaultInitialPingBackoff = 100 * time.Millisecond
+
+// metadataOption returns the connector options set through the component metadata keys it handles, none when they are not set.
+type metadataOption func(properties map[string]string) ([]Option, error)
+
+// metadataOptions are the parsers of the connector options that can be set through the component metadata.
+var metadataOptions = []metadataOption{
+	rateLimitFromMetadata,
+	compressionFromMetadata,
+	maxMessageSizeFromMetadata,
+	requestLoggingFromMetadata,
+	initialPingRetriesFromMetadata,
+	retryBudgetFromMetadata,
+	channelzFromMetadata,
+	shutdownTimeoutFromMetadata,
+	featuresFromMetadata,
+	warmupTimeoutFromMetadata,
+}
+
+// optionsFromMetadata returns the connector options set through the given component metadata properties.
+// This is how the options are set for the pluggable components registered through the service discovery, whose connectors are created
+// with no other option than the component descriptor. It returns an ErrInvalidMetadataOption error when a value cannot be parsed.
+func optionsFromMetadata(properties map[string]string) ([]Option, error) {
+	opts := []Option{}
+	for _, parse := range metadataOptions {
+		parsed, err := parse(properties)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, parsed...)
+	}
+	return opts, nil
+}
+
+// applyMetadataOptions applies the connector options set through the given component metadata properties,
+// they take precedence over the options the connector was created with.
+func (g *GRPCConnector[TClient]) applyMetadataOptions(properties map[string]string) error {
+	opts, err := optionsFromMetadata(properties)
+	if err != nil {
+		return err
+	}
+	for _, opt := range opts {
+		opt(&g.options)
+	}
+	return nil
+}
+
+// rateLimitFromMetadata returns the rate limit options set through the component metadata, see RateLimitMetadataKey.
+func rateLimitFromMetadata(properties map[string]string) ([]Option, error) {
+	rps, ok, err := intFromMetadata(properties, RateLimitMetadataKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	burst, _, err := intFromMetadata(properties, RateLimitBurstMetadataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	mode := RateLimitReject
+	switch value := properties[RateLimitModeMetadataKey]; value {
+	case "", "reject":
+	case "wait":
+		mode = RateLimitWait
+	default:
+		return nil, fmt.Errorf("%w: '%s' must be 'reject' or 'wait', got '%s'", ErrInvalidMetadataOption, RateLimitModeMetadataKey, value)
+	}
+	return []Option{WithRateLimit(rps, burst), WithRateLimitMode(mode)}, nil
+}
+
+// compressionFromMetadata returns the compression option set through the component metadata, see CompressionMetadataKey.
+// the compressor is checked when the component is dialed.
+func compressionFromMetadata(properties map[string]string) ([]Option, error) {
+	codec := properties[CompressionMetadataKey]
+	if codec == "" {
+		return nil, nil
+	}
+	return []Option{WithCompression(codec)}, nil
+}
+
+// maxMessageSizeFromMetadata returns the max message size options set through the component metadata, see MaxRecvMessageSizeMetadataKey
+// and MaxSendMessageSizeMetadataKey. Each size is only overridden when its key is set, see WithMaxMessageSize.
+func maxMessageSizeFromMetadata(properties map[string]string) ([]Option, error) {
+	opts := []Option{}
+	recv, ok, err := intFromMetadata(properties, MaxRecvMessageSizeMetadataKey)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, func(o *connectorOptions) {
+			o.maxRecvMsgSize = recv
+		})
+	}
+	send, ok, err := intFromMetadata(properties, MaxSendMessageSizeMetadataKey)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, func(o *connectorOptions) {
+			o.maxSendMsgSize = send
+		})
+	}
+	return opts, nil
+}
+
+// requestLoggingFromMetadata returns the request logging option set through the component metadata, see RequestLoggingMetadataKey.
+// The redactor set in code, if any, is kept when the logging is enabled.
+func requestLoggingFromMetadata(properties map[string]string) ([]Option, error) {
+	value, ok := properties[RequestLoggingMetadataKey]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	enabled := utils.IsTruthy(value)
+	return []Option{func(o *connectorOptions) {
+		if enabled && o.requestLogger != nil {
+			return
+		}
+		WithRequestLogging(enabled, nil)(o)
+	}}, nil
+}
+
+// initialPingRetriesFromMetadata returns the initial ping retries option set through the component metadata, see InitialPingRetriesMetadataKey.
+// The backoff set in code is kept when InitialPingBackoffMetadataKey is not set, defaultInitialPingBackoff is used when there is none.
+func initialPingRetriesFromMetadata(properties map[string]string) ([]Option, error) {
+	retries, ok, err := intFromMetadata(properties, InitialPingRetriesMetadataKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	backoff, hasBackoff, err := durationFromMetadata(properties, InitialPingBackoffMetadataKey)
+	if err != nil {
+		return nil, err
+	}
+	return []Option{func(o *connectorOptions) {
+		switch {
+		case hasBackoff:
+		case o.initialPingBackoff > 0:
+			backoff = o.initialPingBackoff
+		default:
+			backoff = defaultInitialPingBackoff
+		}
+		WithInitialPingRetries(retries, backoff)(o)
+	}}, nil
+}
+
+// retryBudgetFromMetadata returns the retry budget option set through the component metadata, see RetryBudgetRatioMetadataKey.
+// The budget is set when either key is set, the other one being zero when not set.
+func retryBudgetFromMetadata(properties map[string]string) ([]Option, error) {
+	ratio, hasRatio, err := ratioFromMetadata(properties, RetryBudgetRatioMetadataKey)
+	if err != nil {
+		return nil, err
+	}
+	minPerSec, hasMinPerSec, err := intFromMetadata(properties, RetryBudgetMinPerSecMetadataKey)
+	if err != nil {
+		return nil, err
+	}
+	if !hasRatio && !hasMinPerSec {
+		return nil, nil
+	}
+	return []Option{WithRetryBudget(ratio, minPerSec)}, nil
+}
+
+// channelzFromMetadata returns the channelz option set through the component metadata, see ChannelzMetadataKey.
+func channelzFromMetadata(properties map[string]string) ([]Option, error) {
+	value, ok := properties[ChannelzMetadataKey]
+	if !ok || value == "" {
+		return nil, nil
+	}
+	return []Option{WithChannelz(utils.IsTruthy(value))}, nil
+}
+
+// shutdownTimeoutFromMetadata returns the shutdown timeout option set through the component metadata, see ShutdownTimeoutMetadataKey.
+func shutdownTimeoutFromMetadata(properties map[string]string) ([]Option, error) {
+	timeout, ok, err := durationFromMetadata(properties, ShutdownTimeoutMetadataKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return []Option{WithShutdownTimeout(timeout)}, nil
+}
+
+// featuresFromMetadata returns the features call options set through the component metadata, see FeaturesFallbackMetadataKey,
+// FeaturesRetriesMetadataKey and FeaturesBackoffMetadataKey. Each setting is only overridden when its key is set.
+func featuresFromMetadata(properties map[string]string) ([]Option, error) {
+	opts := []Option{}
+	switch value := properties[FeaturesFallbackMetadataKey]; value {
+	case "":
+	case FeaturesFallbackConservative.String():
+		opts = append(opts, WithFeaturesFallback(FeaturesFallbackConservative))
+	case FeaturesFallbackOptimistic.String():
+		opts = append(opts, WithFeaturesFallback(FeaturesFallbackOptimistic))
+	default:
+		return nil, fmt.Errorf("%w: '%s' must be '%s' or '%s', got '%s'", ErrInvalidMetadataOption, FeaturesFallbackMetadataKey,
+			FeaturesFallbackConservative, FeaturesFallbackOptimistic, value)
+	}
+
+	retries, ok, err := intFromMetadata(properties, FeaturesRetriesMetadataKey)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		if retries == 0 {
+			retries = -1 // zero means the default retries in the connector options.
+		}
+		opts = append(opts, func(o *connectorOptions) {
+			o.featuresRetries = retries
+		})
+	}
+
+	backoff, ok, err := durationFromMetadata(properties, FeaturesBackoffMetadataKey)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		opts = append(opts, func(o *connectorOptions) {
+			o.featuresBackoff = backoff
+		})
+	}
+	return opts, nil
+}
+
+// warmupTimeoutFromMetadata returns the warmup timeout option set through the component metadata, see WarmupTimeoutMetadataKey.
+func warmupTimeoutFromMetadata(properties map[string]string) ([]Option, error) {
+	timeout, ok, err := durationFromMetadata(properties, WarmupTimeoutMetadataKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	return []Option{WithWarmupTimeout(timeout)}, nil
+}
+
+// intFromMetadata parses the non-negative integer set through the given component metadata key, returning false when it is not set.
+func intFromMetadata(properties map[string]string, key string) (int, bool, error) {
+	value, ok := properties[key]
+	if !ok || value == "" {
+		return 0, false, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, false, fmt.Errorf("%w: '%s' must be a non-negative integer, got '%s'", ErrInvalidMetadataOption, key, value)
+	}
+	return n, true, nil
+}
+
+// ratioFromMetadata parses the ratio between 0 and 1 set through the given component metadata key, returning false when it is not set.
+func ratioFromMetadata(properties map[string]string, key string) (float64, bool, error) {
+	value, ok := properties[key]
+	if !ok || value == "" {
+		return 0, false, nil
+	}
+	ratio, err := strconv.ParseFloat(value, 64)
+	if err != nil || ratio < 0 || ratio > 1 {
+		return 0, false, fmt.Errorf("%w: '%s' must be a number between 0 and 1, got '%s'", ErrInvalidMetadataOption, key, value)
+	}
+	return ratio, true, nil
+}
+
+// durationFromMetadata parses the positive duration set through the given component metadata key, returning false when it is not set.
+func durationFromMetadata(properties map[string]string, key string) (time.Duration, bool, error) {
+	value, ok := properties[key]
+	if !ok || value == "" {
+		return 0, false, nil
+	}
+	d, err := time.ParseDuration(value)
+	if err != nil || d <= 0 {
+		return 0, false, fmt.Errorf("%w: '%s' must be a positive duration, got '%s'", ErrInvalidMetadataOption, key, value)
+	}
+	return d, true, nil
+}
@@ -0,0 +1,212 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+)
+
+// metadataOptionsOf returns the connector options set through the given component metadata properties.
+func metadataOptionsOf(t *testing.T, properties map[string]string) connectorOptions {
+	t.Helper()
+	opts, err := optionsFromMetadata(properties)
+	require.NoError(t, err)
+	options := connectorOptions{}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return options
+}
+
+func TestOptionsFromMetadata(t *testing.T) {
+	t.Run("no option should be set when no metadata key is set", func(t *testing.T) {
+		opts, err := optionsFromMetadata(map[string]string{"connectionString": "value"})
+		require.NoError(t, err)
+		assert.Empty(t, opts)
+	})
+
+	t.Run("rate limit should be set from the metadata", func(t *testing.T) {
+		options := metadataOptionsOf(t, map[string]string{
+			RateLimitMetadataKey:      "10",
+			RateLimitBurstMetadataKey: "5",
+			RateLimitModeMetadataKey:  "wait",
+		})
+		require.NotNil(t, options.rateLimiter)
+		assert.Equal(t, rate.Limit(10), options.rateLimiter.Limit())
+		assert.Equal(t, 5, options.rateLimiter.Burst())
+		assert.Equal(t, RateLimitWait, options.rateLimitMode)
+	})
+
+	t.Run("rate limit should reject the calls above the limit by default", func(t *testing.T) {
+		options := metadataOptionsOf(t, map[string]string{RateLimitMetadataKey: "10"})
+		require.NotNil(t, options.rateLimiter)
+		assert.Equal(t, 1, options.rateLimiter.Burst())
+		assert.Equal(t, RateLimitReject, options.rateLimitMode)
+	})
+
+	t.Run("compression should be set from the metadata", func(t *testing.T) {
+		options := metadataOptionsOf(t, map[string]string{CompressionMetadataKey: "gzip"})
+		assert.Equal(t, "gzip", options.compressor)
+	})
+
+	t.Run("max message sizes should be set from the metadata", func(t *testing.T) {
+		options := metadataOptionsOf(t, map[string]string{
+			MaxRecvMessageSizeMetadataKey: "8388608",
+			MaxSendMessageSizeMetadataKey: "1048576",
+		})
+		assert.Equal(t, 8388608, options.maxRecvMsgSize)
+		assert.Equal(t, 1048576, options.maxSendMsgSize)
+	})
+
+	t.Run("max message sizes not set in the metadata should be kept", func(t *testing.T) {
+		options := connectorOptions{}
+		WithMaxMessageSize(1024, 2048)(&options)
+		opts, err := optionsFromMetadata(map[string]string{MaxRecvMessageSizeMetadataKey: "4096"})
+		require.NoError(t, err)
+		for _, opt := range opts {
+			opt(&options)
+		}
+		assert.Equal(t, 4096, options.maxRecvMsgSize)
+		assert.Equal(t, 2048, options.maxSendMsgSize)
+	})
+
+	t.Run("request logging should be set from the metadata", func(t *testing.T) {
+		options := metadataOptionsOf(t, map[string]string{RequestLoggingMetadataKey: "true"})
+		assert.NotNil(t, options.requestLogger)
+
+		options = connectorOptions{}
+		WithRequestLogging(true, nil)(&options)
+		opts, err := optionsFromMetadata(map[string]string{RequestLoggingMetadataKey: "false"})
+		require.NoError(t, err)
+		for _, opt := range opts {
+			opt(&options)
+		}
+		assert.Nil(t, options.requestLogger)
+	})
+
+	t.Run("initial ping retries should be set from the metadata", func(t *testing.T) {
+		options := metadataOptionsOf(t, map[string]string{
+			InitialPingRetriesMetadataKey: "3",
+			InitialPingBackoffMetadataKey: "250ms",
+		})
+		assert.Equal(t, 3, options.initialPingRetries)
+		assert.Equal(t, 250*time.Millisecond, options.initialPingBackoff)
+
+		options = metadataOptionsOf(t, map[string]string{InitialPingRetriesMetadataKey: "3"})
+		assert.Equal(t, defaultInitialPingBackoff, options.initialPingBackoff)
+	})
+
+	t.Run("retry budget should be set from the metadata", func(t *testing.T) {
+		options := metadataOptionsOf(t, map[string]string{
+			RetryBudgetRatioMetadataKey:     "0.2",
+			RetryBudgetMinPerSecMetadataKey: "5",
+		})
+		require.NotNil(t, options.retryBudget)
+		assert.Equal(t, 0.2, options.retryBudget.ratio)
+		assert.Equal(t, float64(5), options.retryBudget.minPerSec)
+
+		options = metadataOptionsOf(t, map[string]string{RetryBudgetMinPerSecMetadataKey: "5"})
+		require.NotNil(t, options.retryBudget)
+		assert.Zero(t, options.retryBudget.ratio)
+	})
+
+	t.Run("channelz should be set from the metadata", func(t *testing.T) {
+		assert.True(t, metadataOptionsOf(t, map[string]string{ChannelzMetadataKey: "true"}).channelz)
+		assert.False(t, metadataOptionsOf(t, map[string]string{ChannelzMetadataKey: "false"}).channelz)
+	})
+
+	t.Run("shutdown timeout should be set from the metadata", func(t *testing.T) {
+		options := metadataOptionsOf(t, map[string]string{ShutdownTimeoutMetadataKey: "10s"})
+		assert.Equal(t, 10*time.Second, options.shutdownTimeoutOrDefault())
+	})
+
+	t.Run("features call options should be set from the metadata", func(t *testing.T) {
+		options := metadataOptionsOf(t, map[string]string{
+			FeaturesFallbackMetadataKey: "optimistic",
+			FeaturesRetriesMetadataKey:  "5",
+			FeaturesBackoffMetadataKey:  "50ms",
+		})
+		assert.Equal(t, FeaturesFallbackOptimistic, options.featuresFallback)
+		assert.Equal(t, 5, options.featuresRetriesOrDefault())
+		assert.Equal(t, 50*time.Millisecond, options.featuresBackoff)
+	})
+
+	t.Run("zero features retries should disable the retries", func(t *testing.T) {
+		options := metadataOptionsOf(t, map[string]string{FeaturesRetriesMetadataKey: "0"})
+		assert.Zero(t, options.featuresRetriesOrDefault())
+	})
+
+	t.Run("warmup timeout should be set from the metadata", func(t *testing.T) {
+		options := metadataOptionsOf(t, map[string]string{WarmupTimeoutMetadataKey: "1m"})
+		assert.Equal(t, time.Minute, options.warmupTimeout)
+	})
+
+	t.Run("invalid values should return an error", func(t *testing.T) {
+		for _, properties := range []map[string]string{
+			{RateLimitMetadataKey: "fast"},
+			{RateLimitMetadataKey: "-1"},
+			{RateLimitMetadataKey: "10", RateLimitBurstMetadataKey: "many"},
+			{RateLimitMetadataKey: "10", RateLimitModeMetadataKey: "drop"},
+			{MaxRecvMessageSizeMetadataKey: "4MB"},
+			{MaxSendMessageSizeMetadataKey: "-1"},
+			{InitialPingRetriesMetadataKey: "three"},
+			{InitialPingRetriesMetadataKey: "3", InitialPingBackoffMetadataKey: "100"},
+			{RetryBudgetRatioMetadataKey: "1.5"},
+			{RetryBudgetRatioMetadataKey: "ten percent"},
+			{RetryBudgetMinPerSecMetadataKey: "-5"},
+			{ShutdownTimeoutMetadataKey: "soon"},
+			{ShutdownTimeoutMetadataKey: "-1s"},
+			{FeaturesFallbackMetadataKey: "pessimistic"},
+			{FeaturesRetriesMetadataKey: "a few"},
+			{FeaturesBackoffMetadataKey: "0s"},
+			{WarmupTimeoutMetadataKey: "forever"},
+		} {
+			_, err := optionsFromMetadata(properties)
+			assert.ErrorIs(t, err, ErrInvalidMetadataOption, properties)
+		}
+	})
+
+	t.Run("metadata options should be applied before the component is dialed", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{})
+		require.NoError(t, connector.InitOrDisable("my-component", map[string]string{RateLimitMetadataKey: "1"}, func() error {
+			return connector.Dial("my-component")
+		}))
+
+		assert.NotEqual(t, codes.ResourceExhausted, publishCode(context.Background(), connector))
+		assert.Equal(t, codes.ResourceExhausted, publishCode(context.Background(), connector))
+	})
+
+	t.Run("an unknown compressor should fail the init", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{})
+		err := connector.InitOrDisable("my-component", map[string]string{CompressionMetadataKey: "not-registered"}, func() error {
+			return connector.Dial("my-component")
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid metadata options should fail the init", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{})
+		err := connector.InitOrDisable("my-component", map[string]string{RateLimitMetadataKey: "fast"}, func() error {
+			return connector.Dial("my-component")
+		})
+		assert.ErrorIs(t, err, ErrInvalidMetadataOption)
+	})
+}
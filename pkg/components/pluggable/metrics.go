@@ -0,0 +1,54 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+
+	"github.com/dapr/dapr/pkg/components"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+// metricsUnaryInterceptor returns a grpc client unary interceptor that records the rpc latency and errors of the given pluggable component.
+func metricsUnaryInterceptor(pc components.Pluggable) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		diag.DefaultPluggableComponentMonitoring.RPCCompleted(ctx, string(pc.Type), pc.Name, method, status.Code(err), time.Since(start))
+		return err
+	}
+}
+
+// metricsStreamInterceptor returns a grpc client stream interceptor that records the errors when opening streams of the given pluggable component.
+// the latency recorded is the time spent opening the stream, as streams are long-lived.
+func metricsStreamInterceptor(pc components.Pluggable) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		start := time.Now()
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		diag.DefaultPluggableComponentMonitoring.RPCCompleted(ctx, string(pc.Type), pc.Name, method, status.Code(err), time.Since(start))
+		return stream, err
+	}
+}
+
+// ObserveInit calls the given component Init rpc recording the time spent on it, whether it succeeded or not.
+func (g *GRPCConnector[TClient]) ObserveInit(init func() error) error {
+	start := time.Now()
+	err := init()
+	diag.DefaultPluggableComponentMonitoring.InitCompleted(g.Context, string(g.options.pluggable.Type), g.options.pluggable.Name, time.Since(start))
+	return err
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/dapr/dapr/pkg/components"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
+)
+
+const (
+	rpcErrorsViewName    = "pluggable_component/rpc_errors_total"
+	rpcLatencyViewName   = "pluggable_component/rpc_latency_seconds"
+	initDurationViewName = "pluggable_component/init_duration_seconds"
+)
+
+// rowTags returns the view row tags as a map of tag names to values.
+func rowTags(row *view.Row) map[string]string {
+	tags := make(map[string]string, len(row.Tags))
+	for _, t := range row.Tags {
+		tags[t.Key.Name()] = t.Value
+	}
+	return tags
+}
+
+// findRow returns the row of the given view that matches the given component name.
+func findRow(t *testing.T, viewName, componentName string) *view.Row {
+	t.Helper()
+	rows, err := view.RetrieveData(viewName)
+	require.NoError(t, err)
+	for _, row := range rows {
+		if rowTags(row)["component_name"] == componentName {
+			return row
+		}
+	}
+	return nil
+}
+
+func TestMetricsInterceptors(t *testing.T) {
+	require.NoError(t, diag.DefaultPluggableComponentMonitoring.Init("test-app"))
+	t.Cleanup(func() {
+		view.Unregister(view.Find(rpcErrorsViewName), view.Find(rpcLatencyViewName))
+	})
+
+	t.Run("failed rpcs should increment the errors counter with the component labels", func(t *testing.T) {
+		const componentName = "failing-pubsub"
+		svc := &pingServer{pingErr: status.Error(codes.Unavailable, "not ready")}
+		connector := testPubSubConnectorFor(t, svc, WithPluggable(components.Pluggable{Type: components.CategoryPubSub, Name: componentName}))
+		require.NoError(t, connector.Dial(componentName))
+
+		require.Error(t, connector.PingContext(context.Background(), true))
+		require.Error(t, connector.PingContext(context.Background(), true))
+
+		row := findRow(t, rpcErrorsViewName, componentName)
+		require.NotNil(t, row)
+		assert.Equal(t, int64(2), row.Data.(*view.CountData).Value)
+		assert.Equal(t, map[string]string{
+			"app_id":         "test-app",
+			"component_type": string(components.CategoryPubSub),
+			"component_name": componentName,
+			"method":         "/dapr.proto.components.v1.PubSub/Ping",
+			"code":           codes.Unavailable.String(),
+		}, rowTags(row))
+	})
+
+	t.Run("succeeded rpcs should record the latency but not the errors counter", func(t *testing.T) {
+		const componentName = "healthy-pubsub"
+		svc := &pingServer{}
+		connector := testPubSubConnectorFor(t, svc, WithPluggable(components.Pluggable{Type: components.CategoryPubSub, Name: componentName}))
+		require.NoError(t, connector.Dial(componentName))
+
+		require.NoError(t, connector.PingContext(context.Background(), true))
+
+		assert.Nil(t, findRow(t, rpcErrorsViewName, componentName))
+		row := findRow(t, rpcLatencyViewName, componentName)
+		require.NotNil(t, row)
+		assert.Equal(t, int64(1), row.Data.(*view.DistributionData).Count)
+		assert.Equal(t, codes.OK.String(), rowTags(row)["code"])
+	})
+}
+
+func TestObserveInit(t *testing.T) {
+	require.NoError(t, diag.DefaultPluggableComponentMonitoring.Init("test-app"))
+	t.Cleanup(func() {
+		view.Unregister(view.Find(rpcErrorsViewName), view.Find(rpcLatencyViewName), view.Find(initDurationViewName))
+	})
+
+	t.Run("a delayed init should record its duration with the component labels", func(t *testing.T) {
+		const componentName = "slow-pubsub"
+		connector := testPubSubConnectorFor(t, &pingServer{}, WithPluggable(components.Pluggable{Type: components.CategoryPubSub, Name: componentName}))
+
+		require.NoError(t, connector.ObserveInit(func() error {
+			time.Sleep(20 * time.Millisecond)
+			return nil
+		}))
+
+		row := findRow(t, initDurationViewName, componentName)
+		require.NotNil(t, row)
+		data := row.Data.(*view.DistributionData)
+		assert.Equal(t, int64(1), data.Count)
+		assert.GreaterOrEqual(t, data.Min, (20 * time.Millisecond).Seconds())
+		assert.Equal(t, map[string]string{
+			"app_id":         "test-app",
+			"component_type": string(components.CategoryPubSub),
+			"component_name": componentName,
+		}, rowTags(row))
+	})
+
+	t.Run("a failed init should be recorded and its error returned", func(t *testing.T) {
+		const componentName = "failing-init-pubsub"
+		connector := testPubSubConnectorFor(t, &pingServer{}, WithPluggable(components.Pluggable{Type: components.CategoryPubSub, Name: componentName}))
+
+		initErr := errors.New("init failed")
+		assert.ErrorIs(t, connector.ObserveInit(func() error {
+			return initErr
+		}), initErr)
+
+		row := findRow(t, initDurationViewName, componentName)
+		require.NotNil(t, row)
+		assert.Equal(t, int64(1), row.Data.(*view.DistributionData).Count)
+	})
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// namespacedSocketPrefix opts a socket in to the namespace scoping, the namespace it is scoped to follows the prefix and is separated
+// from the component name by namespaceSeparator, e.g. the 'ns~my-namespace~my-component.sock' socket can only be dialed by the sidecars
+// of the 'my-namespace' namespace. sockets without the prefix can be dialed by any sidecar, whatever their name.
+const namespacedSocketPrefix = "ns" + namespaceSeparator
+
+// namespaceSeparator separates the namespace a socket is scoped to from the component name in the socket file name.
+const namespaceSeparator = "~"
+
+// socketNamespaceOf returns the namespace the given socket file name is scoped to, empty when not scoped, and the file name without it.
+func socketNamespaceOf(fileName string) (namespace string, unscoped string) {
+	scoped, ok := strings.CutPrefix(fileName, namespacedSocketPrefix)
+	if !ok {
+		return "", fileName
+	}
+	if namespace, unscoped, ok := strings.Cut(scoped, namespaceSeparator); ok && namespace != "" {
+		return namespace, unscoped
+	}
+	return "", fileName
+}
+
+// checkSocketNamespace returns an ErrSocketNamespaceMismatch error when the given socket is scoped to a namespace other than the sidecar one,
+// the sidecar namespace is the one set through SetAppIdentity.
+func checkSocketNamespace(socket string) error {
+	namespace, _ := socketNamespaceOf(filepath.Base(socket))
+	if namespace == "" {
+		return nil
+	}
+	if _, current := getAppIdentity(); namespace != current {
+		return fmt.Errorf("%w: socket '%s' is scoped to the namespace '%s' but the sidecar runs in the namespace '%s'", ErrSocketNamespaceMismatch, socket, namespace, current)
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+func TestSocketNamespace(t *testing.T) {
+	SetAppIdentity("", "my-namespace")
+	t.Cleanup(func() { SetAppIdentity("", "") })
+
+	t.Run("the namespace should be parsed from the socket file name", func(t *testing.T) {
+		namespace, unscoped := socketNamespaceOf("ns~my-namespace~my-component.sock")
+		assert.Equal(t, "my-namespace", namespace)
+		assert.Equal(t, "my-component.sock", unscoped)
+
+		namespace, unscoped = socketNamespaceOf("my-component.sock")
+		assert.Empty(t, namespace)
+		assert.Equal(t, "my-component.sock", unscoped)
+
+		assert.Equal(t, "my-component", componentNameOf("ns~my-namespace~my-component@1.sock"))
+	})
+
+	t.Run("sockets without the namespace prefix should not be scoped", func(t *testing.T) {
+		namespace, unscoped := socketNamespaceOf("my~component.sock")
+		assert.Empty(t, namespace)
+		assert.Equal(t, "my~component.sock", unscoped)
+
+		require.NoError(t, checkSocketNamespace("/tmp/other-namespace~my-component.sock"))
+	})
+
+	t.Run("sockets not scoped or scoped to the sidecar namespace should be allowed", func(t *testing.T) {
+		require.NoError(t, checkSocketNamespace("/tmp/my-component.sock"))
+		require.NoError(t, checkSocketNamespace("/tmp/ns~my-namespace~my-component.sock"))
+	})
+
+	t.Run("sockets scoped to another namespace should not be allowed", func(t *testing.T) {
+		require.ErrorIs(t, checkSocketNamespace("/tmp/ns~other-namespace~my-component.sock"), ErrSocketNamespaceMismatch)
+
+		SetAppIdentity("", "")
+		require.ErrorIs(t, checkSocketNamespace("/tmp/ns~my-namespace~my-component.sock"), ErrSocketNamespaceMismatch)
+		SetAppIdentity("", "my-namespace")
+	})
+
+	t.Run("dial should refuse to connect to a socket scoped to another namespace", func(t *testing.T) {
+		connector := NewGRPCConnector("/tmp/ns~other-namespace~my-component.sock", proto.NewPubSubClient, withRegistry(NewRegistry()))
+		t.Cleanup(func() { connector.Close() })
+
+		require.ErrorIs(t, connector.Dial("my-component"), ErrSocketNamespaceMismatch)
+		assert.Nil(t, connector.conn)
+	})
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"sync"
+
+	"google.golang.org/grpc"
+)
+
+// operationIDKey is the context key of the operation id.
+type operationIDKey struct{}
+
+// WithOperationID returns a context carrying the given operation id, the calls made to the component with it can be cancelled through CancelOperation.
+func WithOperationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, operationIDKey{}, id)
+}
+
+// operationIDFrom returns the operation id carried by the given context, if any.
+func operationIDFrom(ctx context.Context) string {
+	id, _ := ctx.Value(operationIDKey{}).(string)
+	return id
+}
+
+// inFlightOperations tracks the cancel functions of the in-flight calls by their operation id.
+type inFlightOperations struct {
+	lock sync.Mutex
+	next uint64
+	// cancels holds the cancel functions of each in-flight call by operation id, calls are keyed by a sequence number.
+	cancels map[string]map[uint64]context.CancelFunc
+}
+
+// track returns a cancelable context registered under the given operation id and a function to unregister it once the call is done.
+func (o *inFlightOperations) track(ctx context.Context, id string) (context.Context, func()) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	if o.cancels == nil {
+		o.cancels = make(map[string]map[uint64]context.CancelFunc)
+	}
+	if o.cancels[id] == nil {
+		o.cancels[id] = make(map[uint64]context.CancelFunc)
+	}
+	seq := o.next
+	o.next++
+	o.cancels[id][seq] = cancel
+
+	return ctx, func() {
+		o.lock.Lock()
+		defer o.lock.Unlock()
+		delete(o.cancels[id], seq)
+		if len(o.cancels[id]) == 0 {
+			delete(o.cancels, id)
+		}
+		cancel()
+	}
+}
+
+// cancel cancels the in-flight calls of the given operation id, returning false when there is none.
+func (o *inFlightOperations) cancel(id string) bool {
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	calls, ok := o.cancels[id]
+	if !ok {
+		return false
+	}
+	for _, cancel := range calls {
+		cancel()
+	}
+	return true
+}
+
+// CancelOperation cancels the in-flight calls made to the component with the given operation id, see WithOperationID,
+// without cancelling the other calls. It returns false when there is no in-flight call for the operation.
+func (g *GRPCConnector[TClient]) CancelOperation(id string) bool {
+	cancelled := g.operations.cancel(id)
+	if cancelled {
+		g.logger.Debugf("cancelled in-flight operation '%s'", id)
+	}
+	return cancelled
+}
+
+// operationsUnaryInterceptor returns a grpc client unary interceptor that tracks the calls made with an operation id so they can be cancelled.
+func (g *GRPCConnector[TClient]) operationsUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		id := operationIDFrom(ctx)
+		if id == "" {
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+		ctx, done := g.operations.track(ctx, id)
+		defer done()
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
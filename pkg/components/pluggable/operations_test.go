@@ -0,0 +1,88 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+func TestCancelOperation(t *testing.T) {
+	// blockingConnector returns a connector whose pings block until cancelled.
+	blockingConnector := func(t *testing.T) (*GRPCConnector[proto.PubSubClient], *pingServer) {
+		svc := &pingServer{onPing: func(ctx context.Context) {
+			<-ctx.Done()
+		}}
+		connector := testPubSubConnectorFor(t, svc)
+		require.NoError(t, connector.Dial(""))
+		return connector, svc
+	}
+
+	ping := func(connector *GRPCConnector[proto.PubSubClient], id string) <-chan error {
+		errCh := make(chan error, 1)
+		go func() {
+			_, err := connector.Client.Ping(WithOperationID(context.Background(), id), &proto.PingRequest{})
+			errCh <- err
+		}()
+		return errCh
+	}
+
+	t.Run("cancelling an operation should leave the other operations running", func(t *testing.T) {
+		connector, svc := blockingConnector(t)
+
+		op1, op2 := ping(connector, "op1"), ping(connector, "op2")
+		require.Eventually(t, func() bool {
+			return svc.pingCalled.Load() == 2
+		}, 5*time.Second, 10*time.Millisecond)
+
+		assert.True(t, connector.CancelOperation("op1"))
+		select {
+		case err := <-op1:
+			assert.Equal(t, codes.Canceled, status.Code(err))
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "expected the cancelled operation to return")
+		}
+
+		select {
+		case err := <-op2:
+			require.Fail(t, "expected the other operation to keep running", "returned %v", err)
+		case <-time.After(100 * time.Millisecond):
+		}
+
+		assert.True(t, connector.CancelOperation("op2"))
+		assert.Equal(t, codes.Canceled, status.Code(<-op2))
+	})
+
+	t.Run("cancelling an unknown operation should return false", func(t *testing.T) {
+		connector, _ := blockingConnector(t)
+		assert.False(t, connector.CancelOperation("unknown"))
+	})
+
+	t.Run("finished operations should not be tracked", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{})
+		require.NoError(t, connector.Dial(""))
+
+		_, err := connector.Client.Ping(WithOperationID(context.Background(), "op"), &proto.PingRequest{})
+		require.NoError(t, err)
+		assert.False(t, connector.CancelOperation("op"))
+	})
+}
@@ -0,0 +1,382 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/status"
+
+	"github.com/dapr/dapr/pkg/buildinfo"
+	"github.com/dapr/dapr/pkg/components"
+
+	// registers the gzip compressor.
+	_ "google.golang.org/grpc/encoding/gzip"
+)
+
+// Option is a function that applies a connector option.
+type Option func(o *connectorOptions)
+
+// connectorOptions holds the options used by the connector when connecting to the component.
+type connectorOptions struct {
+	// pluggable is the pluggable component being connected to.
+	pluggable components.Pluggable
+	// compressor is the name of the compressor used for outgoing messages, empty means no compression.
+	compressor string
+	// maxRecvMsgSize is the max message size in bytes the connector can receive, zero means the grpc default.
+	maxRecvMsgSize int
+	// maxSendMsgSize is the max message size in bytes the connector can send, zero means the grpc default.
+	maxSendMsgSize int
+	// unaryInterceptors are custom interceptors chained to every unary call.
+	unaryInterceptors []grpc.UnaryClientInterceptor
+	// streamInterceptors are custom interceptors chained to every stream call.
+	streamInterceptors []grpc.StreamClientInterceptor
+	// requestLogger logs every request and response exchanged with the component when set.
+	requestLogger *requestLogger
+	// initialPingRetries is the number of times the initial ping is retried when the component returns an error, zero means no initial ping.
+	initialPingRetries int
+	// initialPingBackoff is the initial interval between initial ping attempts, doubled on every retry.
+	initialPingBackoff time.Duration
+	// subscribeDrainTimeout is the max amount of time to wait for in-flight messages when a subscription stops, zero means the default.
+	subscribeDrainTimeout time.Duration
+	// subscribeBuffer is the number of messages received ahead of the subscription handlers, zero means synchronous handoff.
+	subscribeBuffer int
+	// retryBudget throttles the retries made to the component when set.
+	retryBudget *RetryBudget
+	// rateLimiter caps the rate of the calls made to the component when set, it is shared by all the component operations.
+	rateLimiter *rate.Limiter
+	// rateLimitMode is the behavior of the calls above the rate limit.
+	rateLimitMode RateLimitMode
+	// callTimeout is the max amount of time a unary data plane call made to the component can take, zero means DefaultCallTimeout and negative means no timeout.
+	callTimeout time.Duration
+	// featuresRetries is the number of times a failed features call is retried, zero means the default, negative means no retries.
+	featuresRetries int
+	// featuresBackoff is the initial interval between features call attempts, zero means the default.
+	featuresBackoff time.Duration
+	// featuresFallback is the feature set assumed when the features call keeps failing.
+	featuresFallback FeaturesFallback
+	// warmupTimeout is the max amount of time to wait for the component warmup after init, zero means the default.
+	warmupTimeout time.Duration
+	// shutdownTimeout is the max amount of time to wait for the component shutdown on close, zero means the default.
+	shutdownTimeout time.Duration
+	// contextDialer opens the connections to the component instead of the connector dialer when set.
+	contextDialer func(ctx context.Context, addr string) (net.Conn, error)
+	// pingFunc is the liveness call made to the component instead of the generic Ping when set.
+	pingFunc PingFunc
+	// channelz exposes the connection through the channelz service when set.
+	channelz bool
+	// userAgent is the user agent sent to the component, empty means the default user agent.
+	userAgent string
+	// registry is the registry the component instance is listed in once loaded, DefaultRegistry when not set.
+	registry *Registry
+	// eventBus is the event bus the component connection events are emitted to, DefaultEventBus when not set.
+	eventBus *EventBus
+}
+
+// userAgentOrDefault returns the configured user agent, or the default one identifying the sidecar and its version.
+func (o *connectorOptions) userAgentOrDefault() string {
+	if o.userAgent != "" {
+		return o.userAgent
+	}
+	return "dapr-sidecar/" + buildinfo.Version()
+}
+
+// dialOptions returns the grpc dial options for the configured connector options.
+func (o *connectorOptions) dialOptions() ([]grpc.DialOption, error) {
+	opts := []grpc.DialOption{}
+	if o.compressor != "" {
+		if encoding.GetCompressor(o.compressor) == nil {
+			return nil, fmt.Errorf("compressor '%s' is not registered", o.compressor)
+		}
+		opts = append(opts, grpc.WithDefaultCallOptions(grpc.UseCompressor(o.compressor)))
+	}
+	if o.maxRecvMsgSize > 0 || o.maxSendMsgSize > 0 {
+		opts = append(opts, o.messageSizeDialOptions()...)
+	}
+	if o.requestLogger != nil {
+		opts = append(opts,
+			grpc.WithChainUnaryInterceptor(o.requestLogger.unaryInterceptor()),
+			grpc.WithChainStreamInterceptor(o.requestLogger.streamInterceptor()),
+		)
+	}
+	if len(o.unaryInterceptors) > 0 {
+		opts = append(opts, grpc.WithChainUnaryInterceptor(o.unaryInterceptors...))
+	}
+	if len(o.streamInterceptors) > 0 {
+		opts = append(opts, grpc.WithChainStreamInterceptor(o.streamInterceptors...))
+	}
+	return opts, nil
+}
+
+// messageSizeDialOptions returns the dial options that set the configured message size limits.
+// it also decorates the size exceeded errors with the configured limits.
+func (o *connectorOptions) messageSizeDialOptions() []grpc.DialOption {
+	callOpts := make([]grpc.CallOption, 0, 2)
+	if o.maxRecvMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallRecvMsgSize(o.maxRecvMsgSize))
+	}
+	if o.maxSendMsgSize > 0 {
+		callOpts = append(callOpts, grpc.MaxCallSendMsgSize(o.maxSendMsgSize))
+	}
+
+	messageSizeErr := func(err error) error {
+		s, ok := status.FromError(err)
+		if !ok || s.Code() != codes.ResourceExhausted {
+			return err
+		}
+		return status.Errorf(codes.ResourceExhausted, "%s: the pluggable component connection is limited to messages of %s received and %s sent", s.Message(), sizeLimitOf(o.maxRecvMsgSize), sizeLimitOf(o.maxSendMsgSize))
+	}
+
+	return []grpc.DialOption{
+		grpc.WithDefaultCallOptions(callOpts...),
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return messageSizeErr(invoker(ctx, method, req, reply, cc, opts...))
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			stream, err := streamer(ctx, desc, cc, method, opts...)
+			if err != nil {
+				return nil, messageSizeErr(err)
+			}
+			return &errorMappingClientStream{ClientStream: stream, mapErr: messageSizeErr}, nil
+		}),
+	}
+}
+
+// sizeLimitOf returns the human readable size limit, zero means the grpc default.
+func sizeLimitOf(size int) string {
+	if size <= 0 {
+		return "the default size"
+	}
+	return fmt.Sprintf("%d bytes", size)
+}
+
+// errorMappingClientStream is a client stream that maps the errors returned when sending and receiving messages.
+type errorMappingClientStream struct {
+	grpc.ClientStream
+	mapErr func(error) error
+}
+
+func (s *errorMappingClientStream) SendMsg(m interface{}) error {
+	return s.mapErr(s.ClientStream.SendMsg(m))
+}
+
+func (s *errorMappingClientStream) RecvMsg(m interface{}) error {
+	return s.mapErr(s.ClientStream.RecvMsg(m))
+}
+
+// WithPluggable sets the pluggable component descriptor, used to identify the component in logs.
+func WithPluggable(pc components.Pluggable) Option {
+	return func(o *connectorOptions) {
+		o.pluggable = pc
+	}
+}
+
+// WithCompression sets the compressor used for outgoing messages, the component should support the same codec.
+// gzip is supported out of the box. By default messages are not compressed. It can be set through the component metadata, see CompressionMetadataKey.
+func WithCompression(codec string) Option {
+	return func(o *connectorOptions) {
+		o.compressor = codec
+	}
+}
+
+// WithMaxMessageSize sets the max message size in bytes that can be received and sent through the connection.
+// Zero keeps the grpc default, which is 4MB for received messages. The sizes can be set through the component metadata,
+// see MaxRecvMessageSizeMetadataKey and MaxSendMessageSizeMetadataKey.
+func WithMaxMessageSize(recv, send int) Option {
+	return func(o *connectorOptions) {
+		o.maxRecvMsgSize = recv
+		o.maxSendMsgSize = send
+	}
+}
+
+// WithUnaryInterceptors appends the given interceptors to the chain of interceptors called on every unary call.
+// They run after the built-in interceptors, in the given order.
+func WithUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) Option {
+	return func(o *connectorOptions) {
+		o.unaryInterceptors = append(o.unaryInterceptors, interceptors...)
+	}
+}
+
+// WithStreamInterceptors appends the given interceptors to the chain of interceptors called on every stream call.
+// They run after the built-in interceptors, in the given order.
+func WithStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) Option {
+	return func(o *connectorOptions) {
+		o.streamInterceptors = append(o.streamInterceptors, interceptors...)
+	}
+}
+
+// WithRequestLogging enables logging the method, the request and the response of every call made to the component at debug level.
+// The redactor, when set, is applied to every message before it is logged so that secrets can be stripped.
+// It is meant to diagnose a single misbehaving component and it is disabled by default. It can be enabled through the component metadata,
+// see RequestLoggingMetadataKey.
+func WithRequestLogging(enabled bool, redactor Redactor) Option {
+	return func(o *connectorOptions) {
+		if !enabled {
+			o.requestLogger = nil
+			return
+		}
+		o.requestLogger = &requestLogger{logger: log, redactor: redactor}
+	}
+}
+
+// WithInitialPingRetries makes Dial ping the component once connected, retrying up to n times with exponential backoff
+// starting at the given interval when the component returns an error, e.g. while its backend is warming up.
+// Waiting for the socket to be ready is handled separately and is not counted as a retry. By default Dial doesn't ping.
+// It can be set through the component metadata, see InitialPingRetriesMetadataKey.
+func WithInitialPingRetries(n int, backoff time.Duration) Option {
+	return func(o *connectorOptions) {
+		o.initialPingRetries = n
+		o.initialPingBackoff = backoff
+	}
+}
+
+// DefaultSubscribeDrainTimeout is the default amount of time to wait for in-flight messages when a subscription stops.
+const DefaultSubscribeDrainTimeout = 5 * time.Second
+
+// WithSubscribeDrainTimeout sets the max amount of time to wait for in-flight messages to be delivered and ack'ed when a subscription stops.
+func WithSubscribeDrainTimeout(d time.Duration) Option {
+	return func(o *connectorOptions) {
+		o.subscribeDrainTimeout = d
+	}
+}
+
+// WithSubscribeBuffer sets how many messages are received from the component stream ahead of the subscription handlers before applying backpressure.
+// It only applies to subscriptions limiting their in-flight messages, up to maxInFlightMessages messages are handled at the same time
+// while up to n more are buffered waiting for a handler, the component is granted credits for both. Zero, the default, means synchronous handoff:
+// a message is only received when a handler is available. Subscriptions without an in-flight limit hand every message off to a handler right away.
+// Buffered messages are in-flight: when a subscription stops they are still delivered and ack'ed within the drain timeout, see WithSubscribeDrainTimeout,
+// the ones still buffered when it expires are neither delivered nor ack'ed so the component can redeliver them.
+func WithSubscribeBuffer(n int) Option {
+	return func(o *connectorOptions) {
+		o.subscribeBuffer = n
+	}
+}
+
+// WithRetryBudget bounds all the retries made to the component, e.g. the initial ping and the pubsub reconnect retries,
+// by a shared budget allowing to retry up to the given ratio of the calls made to the component plus minPerSec retries per second.
+// When the budget is exhausted retries are skipped and the original error is returned. By default retries are not budgeted.
+// It can be set through the component metadata, see RetryBudgetRatioMetadataKey.
+func WithRetryBudget(ratio float64, minPerSec int) Option {
+	return func(o *connectorOptions) {
+		o.retryBudget = NewRetryBudget(ratio, minPerSec)
+	}
+}
+
+// WithRateLimit caps the rate of the calls made to the component to rps calls per second, allowing bursts of up to burst calls,
+// so that fragile backends are protected from traffic spikes. The limit is shared by all the component operations, streams count once when opened
+// and the lifecycle calls (init, ping, features, schema, warmup and shutdown) are not limited. By default calls above the limit fail with a ResourceExhausted error, see WithRateLimitMode. Calls are not limited by default.
+// It can be set through the component metadata, see RateLimitMetadataKey.
+func WithRateLimit(rps int, burst int) Option {
+	return func(o *connectorOptions) {
+		if burst < 1 {
+			burst = 1
+		}
+		o.rateLimiter = rate.NewLimiter(rate.Limit(rps), burst)
+	}
+}
+
+// WithRateLimitMode sets whether the calls above the component rate limit are rejected, the default, or wait until they are allowed.
+func WithRateLimitMode(mode RateLimitMode) Option {
+	return func(o *connectorOptions) {
+		o.rateLimitMode = mode
+	}
+}
+
+// WithCallTimeout sets the max amount of time every unary data plane call made to the component can take, calls whose context has a shorter
+// deadline keep it. Streams and the lifecycle calls (init, ping, features, schema, warmup and shutdown) are not bounded. Calls are bounded by
+// DefaultCallTimeout by default, a negative duration disables the timeout.
+func WithCallTimeout(d time.Duration) Option {
+	return func(o *connectorOptions) {
+		o.callTimeout = d
+	}
+}
+
+// WithFeaturesRetries sets how many times a failed features call is retried, with exponential backoff starting at the given interval,
+// before assuming the fallback feature set, see WithFeaturesFallback. By default the call is retried 3 times starting at 100ms,
+// a negative n disables the retries. It can be set through the component metadata, see FeaturesRetriesMetadataKey.
+func WithFeaturesRetries(n int, backoff time.Duration) Option {
+	return func(o *connectorOptions) {
+		o.featuresRetries = n
+		o.featuresBackoff = backoff
+	}
+}
+
+// WithFeaturesFallback sets the feature set assumed when the features call keeps failing: no optional feature, the default,
+// or every optional feature of the component type. It can be set through the component metadata, see FeaturesFallbackMetadataKey.
+func WithFeaturesFallback(fallback FeaturesFallback) Option {
+	return func(o *connectorOptions) {
+		o.featuresFallback = fallback
+	}
+}
+
+// WithWarmupTimeout sets the max amount of time to wait for the component to warm up once initialized, see Warmup.
+// By default the component is waited for up to 30 seconds. It can be set through the component metadata, see WarmupTimeoutMetadataKey.
+func WithWarmupTimeout(d time.Duration) Option {
+	return func(o *connectorOptions) {
+		o.warmupTimeout = d
+	}
+}
+
+// WithShutdownTimeout sets the max amount of time to wait for the component to handle the shutdown signal sent when the connector is closed.
+// By default the component is waited for up to 5 seconds. It can be set through the component metadata, see ShutdownTimeoutMetadataKey.
+func WithShutdownTimeout(d time.Duration) Option {
+	return func(o *connectorOptions) {
+		o.shutdownTimeout = d
+	}
+}
+
+// WithContextDialer sets the function used to open the connections to the component, e.g. to route them through a proxy or an in-memory listener.
+// It overrides the connector dialer, the unix socket one by default, and it is called with the component socket path as the address,
+// or the component instance name when the socket is not known. The socket is not required to exist locally.
+func WithContextDialer(dialer func(ctx context.Context, addr string) (net.Conn, error)) Option {
+	return func(o *connectorOptions) {
+		o.contextDialer = dialer
+	}
+}
+
+// PingFunc is a service specific liveness call made to the component through the given connection, see WithPingFunc.
+type PingFunc func(ctx context.Context, conn grpc.ClientConnInterface, opts ...grpc.CallOption) error
+
+// WithPingFunc sets the liveness call made to the component when it is pinged, for services whose liveness method differs from the
+// generic Ping of the GRPCClient interface, e.g. named differently or taking arguments. By default the generic Ping is called.
+// The component version info is not captured when set, as it is only reported by the generic Ping response.
+func WithPingFunc(ping PingFunc) Option {
+	return func(o *connectorOptions) {
+		o.pingFunc = ping
+	}
+}
+
+// WithChannelz exposes the component connection through the channelz service, see RegisterChannelzService,
+// so that its channel, subchannel and socket stats can be inspected when troubleshooting. It is disabled by default.
+// It can be enabled through the component metadata, see ChannelzMetadataKey.
+func WithChannelz(enabled bool) Option {
+	return func(o *connectorOptions) {
+		o.channelz = enabled
+	}
+}
+
+// WithUserAgent sets the user agent sent to the component on every call, e.g. to identify the sidecar traffic in proxies and access logs.
+// By default the user agent is 'dapr-sidecar/<version>'.
+func WithUserAgent(ua string) Option {
+	return func(o *connectorOptions) {
+		o.userAgent = ua
+	}
+}
@@ -0,0 +1,417 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	"github.com/dapr/dapr/pkg/buildinfo"
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+	testingGrpc "github.com/dapr/dapr/pkg/testing/grpc"
+	"github.com/dapr/kit/logger"
+)
+
+var testLogger = logger.NewLogger("pluggable-components-test")
+
+// countingCompressor is a gzip compressor that counts how many messages were compressed.
+type countingCompressor struct {
+	encoding.Compressor
+	compressed atomic.Int64
+}
+
+func (c *countingCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	c.compressed.Add(1)
+	return c.Compressor.Compress(w)
+}
+
+func (c *countingCompressor) Name() string {
+	return "counting-gzip"
+}
+
+var testCompressor = &countingCompressor{Compressor: encoding.GetCompressor("gzip")}
+
+func init() {
+	encoding.RegisterCompressor(testCompressor)
+}
+
+type pingServer struct {
+	proto.UnimplementedPubSubServer
+	pingCalled atomic.Int64
+	pingErr    error
+	onPing     func(context.Context)
+	pingResp   *proto.PingResponse
+}
+
+func (s *pingServer) Ping(ctx context.Context, _ *proto.PingRequest) (*proto.PingResponse, error) {
+	s.pingCalled.Add(1)
+	if s.onPing != nil {
+		s.onPing(ctx)
+	}
+	if s.pingResp != nil {
+		return s.pingResp, s.pingErr
+	}
+	return &proto.PingResponse{}, s.pingErr
+}
+
+type largeGetServer struct {
+	proto.UnimplementedStateStoreServer
+	data []byte
+}
+
+func (s *largeGetServer) Get(context.Context, *proto.GetRequest) (*proto.GetResponse, error) {
+	return &proto.GetResponse{Data: s.data}, nil
+}
+
+// testConnectorFor returns a connector backed by an in-memory grpc server using the given options.
+func testConnectorFor[TServer any, TClient GRPCClient](t *testing.T, registersvc func(*grpc.Server, TServer), svc TServer, factory func(grpc.ClientConnInterface) TClient, opts ...Option) *GRPCConnector[TClient] {
+	t.Helper()
+	dialer, cleanup, err := testingGrpc.TestServerWithDialer(testLogger, registersvc)(svc)
+	require.NoError(t, err)
+	t.Cleanup(cleanup)
+
+	connector := NewGRPCConnectorWithDialer(func(ctx context.Context, _ string, dialOpts ...grpc.DialOption) (*grpc.ClientConn, error) {
+		return dialer(ctx, dialOpts...)
+	}, factory, opts...)
+	t.Cleanup(func() { connector.Close() })
+	return connector
+}
+
+// testPubSubConnectorFor returns a pubsub connector backed by the given in-memory server.
+func testPubSubConnectorFor(t *testing.T, svc *pingServer, opts ...Option) *GRPCConnector[proto.PubSubClient] {
+	t.Helper()
+	return testConnectorFor(t, func(s *grpc.Server, svc *pingServer) {
+		proto.RegisterPubSubServer(s, svc)
+	}, svc, proto.NewPubSubClient, opts...)
+}
+
+func TestConnectorOptions(t *testing.T) {
+	t.Run("no dial options should be used by default", func(t *testing.T) {
+		opts, err := (&connectorOptions{}).dialOptions()
+		require.NoError(t, err)
+		assert.Empty(t, opts)
+	})
+
+	t.Run("compression should add the compressor as a dial option", func(t *testing.T) {
+		options := connectorOptions{}
+		WithCompression("gzip")(&options)
+		opts, err := options.dialOptions()
+		require.NoError(t, err)
+		assert.Len(t, opts, 1)
+	})
+
+	t.Run("compression should return an error when the compressor is not registered", func(t *testing.T) {
+		options := connectorOptions{}
+		WithCompression("not-registered")(&options)
+		_, err := options.dialOptions()
+		assert.Error(t, err)
+	})
+
+	t.Run("compression should compress outgoing messages", func(t *testing.T) {
+		svc := &pingServer{}
+		connector := testPubSubConnectorFor(t, svc, WithCompression(testCompressor.Name()))
+		require.NoError(t, connector.Dial(""))
+
+		compressedBefore := testCompressor.compressed.Load()
+		require.NoError(t, connector.Ping())
+		assert.Equal(t, int64(1), svc.pingCalled.Load())
+		assert.Greater(t, testCompressor.compressed.Load(), compressedBefore)
+	})
+
+	t.Run("max message size should allow receiving messages larger than the default size", func(t *testing.T) {
+		const defaultMaxRecvMsgSize = 4 * 1024 * 1024
+		svc := &largeGetServer{data: make([]byte, defaultMaxRecvMsgSize+1)}
+		registerStateStore := func(s *grpc.Server, svc *largeGetServer) {
+			proto.RegisterStateStoreServer(s, svc)
+		}
+
+		connector := testConnectorFor(t, registerStateStore, svc, proto.NewStateStoreClient)
+		require.NoError(t, connector.Dial(""))
+		_, err := connector.Client.Get(context.Background(), &proto.GetRequest{})
+		require.Error(t, err)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+		const raisedLimit = 2 * defaultMaxRecvMsgSize
+		connector = testConnectorFor(t, registerStateStore, svc, proto.NewStateStoreClient, WithMaxMessageSize(raisedLimit, 0))
+		require.NoError(t, connector.Dial(""))
+		resp, err := connector.Client.Get(context.Background(), &proto.GetRequest{})
+		require.NoError(t, err)
+		assert.Len(t, resp.Data, defaultMaxRecvMsgSize+1)
+	})
+
+	t.Run("max message size exceeded error should mention the configured limit", func(t *testing.T) {
+		const limit = 1024
+		svc := &largeGetServer{data: make([]byte, limit+1)}
+		connector := testConnectorFor(t, func(s *grpc.Server, svc *largeGetServer) {
+			proto.RegisterStateStoreServer(s, svc)
+		}, svc, proto.NewStateStoreClient, WithMaxMessageSize(limit, 0))
+		require.NoError(t, connector.Dial(""))
+
+		_, err := connector.Client.Get(context.Background(), &proto.GetRequest{})
+		require.Error(t, err)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+		assert.Contains(t, err.Error(), "1024 bytes received")
+	})
+
+	t.Run("custom unary interceptors should be called with the method name", func(t *testing.T) {
+		var methods []string
+		interceptor := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			methods = append(methods, method)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+
+		svc := &pingServer{}
+		connector := testPubSubConnectorFor(t, svc, WithUnaryInterceptors(interceptor))
+		require.NoError(t, connector.Dial(""))
+		require.NoError(t, connector.Ping())
+
+		assert.Equal(t, []string{"/dapr.proto.components.v1.PubSub/Ping"}, methods)
+	})
+
+	t.Run("custom stream interceptors should be called with the method name", func(t *testing.T) {
+		var methods []string
+		interceptor := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			methods = append(methods, method)
+			return streamer(ctx, desc, cc, method, opts...)
+		}
+
+		connector := testPubSubConnectorFor(t, &pingServer{}, WithStreamInterceptors(interceptor))
+		require.NoError(t, connector.Dial(""))
+		stream, err := connector.Client.PullMessages(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, stream.CloseSend())
+
+		assert.Equal(t, []string{"/dapr.proto.components.v1.PubSub/PullMessages"}, methods)
+	})
+
+	t.Run("custom unary interceptors should compose with the built-in interceptors", func(t *testing.T) {
+		// gRPC Pluggable component requires Unix Domain Socket to work, I'm skipping this test when running on windows.
+		if runtime.GOOS == "windows" {
+			return
+		}
+		const fakeSocketPath, componentName = "/tmp/socket.sock", "my-component"
+		const customKey, customValue = "x-custom", "custom-value"
+		os.RemoveAll(fakeSocketPath) // guarantee that is not being used.
+		defer os.RemoveAll(fakeSocketPath)
+		listener, err := net.Listen("unix", fakeSocketPath)
+		require.NoError(t, err)
+		defer listener.Close()
+
+		svc := &pingServer{
+			onPing: func(ctx context.Context) {
+				md, ok := metadata.FromIncomingContext(ctx)
+				assert.True(t, ok)
+				assert.Equal(t, []string{componentName}, md.Get(metadataInstanceID))
+				assert.Equal(t, []string{customValue}, md.Get(customKey))
+			},
+		}
+		s := grpc.NewServer()
+		proto.RegisterPubSubServer(s, svc)
+		go s.Serve(listener)
+		defer s.Stop()
+
+		interceptor := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			return invoker(metadata.AppendToOutgoingContext(ctx, customKey, customValue), method, req, reply, cc, opts...)
+		}
+		connector := NewGRPCConnector(fakeSocketPath, proto.NewPubSubClient, WithUnaryInterceptors(interceptor))
+		defer connector.Close()
+		require.NoError(t, connector.Dial(componentName))
+		require.NoError(t, connector.Ping())
+		assert.Equal(t, int64(1), svc.pingCalled.Load())
+	})
+}
+
+func TestUserAgent(t *testing.T) {
+	userAgentOf := func(t *testing.T, opts ...Option) string {
+		t.Helper()
+		var userAgent []string
+		svc := &pingServer{
+			onPing: func(ctx context.Context) {
+				md, _ := metadata.FromIncomingContext(ctx)
+				userAgent = md.Get("user-agent")
+			},
+		}
+		connector := testPubSubConnectorFor(t, svc, opts...)
+		require.NoError(t, connector.Dial("my-component"))
+		require.NoError(t, connector.Ping())
+		require.Len(t, userAgent, 1)
+		return userAgent[0]
+	}
+
+	t.Run("the sidecar user agent should be sent by default", func(t *testing.T) {
+		assert.True(t, strings.HasPrefix(userAgentOf(t), "dapr-sidecar/"+buildinfo.Version()+" "))
+	})
+
+	t.Run("the user agent should be overridable", func(t *testing.T) {
+		userAgent := userAgentOf(t, WithUserAgent("my-gateway-client/1.0"))
+		assert.True(t, strings.HasPrefix(userAgent, "my-gateway-client/1.0 "))
+		assert.NotContains(t, userAgent, "dapr-sidecar")
+	})
+}
+
+func TestContextDialer(t *testing.T) {
+	// serveBufconn serves a pubsub ping server on an in-memory listener.
+	serveBufconn := func(t *testing.T, svc *pingServer) *bufconn.Listener {
+		t.Helper()
+		listener := bufconn.Listen(1024 * 1024)
+		s := grpc.NewServer()
+		proto.RegisterPubSubServer(s, svc)
+		go s.Serve(listener)
+		t.Cleanup(s.Stop)
+		return listener
+	}
+
+	t.Run("the context dialer should be used instead of the unix socket dialer", func(t *testing.T) {
+		svc := &pingServer{}
+		listener := serveBufconn(t, svc)
+		var dialedAddr atomic.Value
+		connector := NewGRPCConnector("/tmp/not-a-socket.sock", proto.NewPubSubClient, WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+			dialedAddr.Store(addr)
+			return listener.DialContext(ctx)
+		}))
+		t.Cleanup(func() { connector.Close() })
+
+		require.NoError(t, connector.Dial("my-component"))
+		require.NoError(t, connector.Ping())
+		assert.Equal(t, int64(1), svc.pingCalled.Load())
+		assert.Equal(t, "/tmp/not-a-socket.sock", dialedAddr.Load(), "the context dialer should be called with the component socket")
+	})
+
+	t.Run("the context dialer should override the connector dialer", func(t *testing.T) {
+		svc := &pingServer{}
+		listener := serveBufconn(t, svc)
+		connector := NewGRPCConnectorWithDialer(func(context.Context, string, ...grpc.DialOption) (*grpc.ClientConn, error) {
+			return nil, errors.New("the connector dialer should not be used")
+		}, proto.NewPubSubClient, WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}))
+		t.Cleanup(func() { connector.Close() })
+
+		require.NoError(t, connector.Dial("my-component"))
+		require.NoError(t, connector.Ping())
+		assert.Equal(t, int64(1), svc.pingCalled.Load())
+	})
+
+	t.Run("the component instance id should be sent through the context dialer connection", func(t *testing.T) {
+		var instanceID []string
+		svc := &pingServer{
+			onPing: func(ctx context.Context) {
+				md, _ := metadata.FromIncomingContext(ctx)
+				instanceID = md.Get(metadataInstanceID)
+			},
+		}
+		listener := serveBufconn(t, svc)
+		connector := NewGRPCConnector("/tmp/not-a-socket.sock", proto.NewPubSubClient, WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}))
+		t.Cleanup(func() { connector.Close() })
+
+		require.NoError(t, connector.Dial("my-component"))
+		require.NoError(t, connector.Ping())
+		assert.Equal(t, []string{"my-component"}, instanceID)
+	})
+}
+
+// livenessServer is a service whose liveness method is named differently and takes a non-empty request.
+type livenessServer struct {
+	checked chan string
+	err     error
+}
+
+// livenessMethod is the full method name of the livenessServer liveness call.
+const livenessMethod = "/dapr.proto.components.v1.test.Liveness/Check"
+
+// registerLivenessServer registers the given liveness server, the service is described by hand as it has no proto definition.
+func registerLivenessServer(s *grpc.Server, svc *livenessServer) {
+	s.RegisterService(&grpc.ServiceDesc{
+		ServiceName: "dapr.proto.components.v1.test.Liveness",
+		HandlerType: (*any)(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Check",
+			Handler: func(srv any, ctx context.Context, dec func(any) error, _ grpc.UnaryServerInterceptor) (any, error) {
+				in := &wrapperspb.StringValue{}
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				srv.(*livenessServer).checked <- in.GetValue()
+				return &emptypb.Empty{}, srv.(*livenessServer).err
+			},
+		}},
+	}, svc)
+}
+
+func TestPingFunc(t *testing.T) {
+	connectorFor := func(t *testing.T, liveness *livenessServer, generic *pingServer, opts ...Option) *GRPCConnector[proto.PubSubClient] {
+		t.Helper()
+		return testConnectorFor(t, func(s *grpc.Server, _ *pingServer) {
+			proto.RegisterPubSubServer(s, generic)
+			registerLivenessServer(s, liveness)
+		}, generic, proto.NewPubSubClient, opts...)
+	}
+	checkWith := func(probe string) PingFunc {
+		return func(ctx context.Context, conn grpc.ClientConnInterface, opts ...grpc.CallOption) error {
+			return conn.Invoke(ctx, livenessMethod, &wrapperspb.StringValue{Value: probe}, &emptypb.Empty{}, opts...)
+		}
+	}
+
+	t.Run("the generic ping should be called by default", func(t *testing.T) {
+		liveness, generic := &livenessServer{checked: make(chan string, 1)}, &pingServer{}
+		connector := connectorFor(t, liveness, generic)
+		require.NoError(t, connector.Dial("my-component"))
+
+		require.NoError(t, connector.Ping())
+		assert.Equal(t, int64(1), generic.pingCalled.Load())
+		assert.Empty(t, liveness.checked)
+	})
+
+	t.Run("the ping function should be called instead of the generic ping when set", func(t *testing.T) {
+		liveness, generic := &livenessServer{checked: make(chan string, 1)}, &pingServer{}
+		connector := connectorFor(t, liveness, generic, WithPingFunc(checkWith("sidecar")))
+		require.NoError(t, connector.Dial("my-component"))
+
+		require.NoError(t, connector.Ping())
+		assert.Equal(t, "sidecar", <-liveness.checked, "the liveness request should be sent as is")
+		assert.Zero(t, generic.pingCalled.Load())
+		assert.Equal(t, ComponentInfo{}, connector.Info())
+		assert.NotNil(t, connector.Status().LastPing)
+	})
+
+	t.Run("the ping function errors should be returned", func(t *testing.T) {
+		liveness := &livenessServer{checked: make(chan string, 1), err: status.Error(codes.Unavailable, "not live")}
+		connector := connectorFor(t, liveness, &pingServer{}, WithPingFunc(checkWith("sidecar")))
+		require.NoError(t, connector.Dial("my-component"))
+
+		err := connector.Ping()
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+		assert.Nil(t, connector.Status().LastPing)
+	})
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RateLimitMode is the behavior of the calls made to the component when its rate limit is hit, see WithRateLimit.
+type RateLimitMode int
+
+const (
+	// RateLimitReject fails the calls above the rate limit with a ResourceExhausted error.
+	RateLimitReject RateLimitMode = iota
+	// RateLimitWait blocks the calls above the rate limit until they are allowed or their context is done.
+	RateLimitWait
+)
+
+// lifecycleMethods are the component rpcs that are not part of its data plane.
+var lifecycleMethods = []string{"Init", "Ping", "Features", "Schema", "Warmup", "Shutdown"}
+
+// isLifecycleMethod returns true when the given full method name is a component lifecycle rpc.
+func isLifecycleMethod(method string) bool {
+	for _, name := range lifecycleMethods {
+		if strings.HasSuffix(method, "/"+name) {
+			return true
+		}
+	}
+	return false
+}
+
+// rateLimitExempt returns true for the calls that are not rate limited, the lifecycle calls are exempted so the component
+// health is not reported as failing while its traffic is throttled and its init or graceful shutdown is never rejected.
+func rateLimitExempt(method string) bool {
+	return isLifecycleMethod(method)
+}
+
+// rateLimit waits or reserves a token of the component rate limiter for the given method according to the configured mode.
+func (o *connectorOptions) rateLimit(ctx context.Context, method string) error {
+	if o.rateLimiter == nil || rateLimitExempt(method) {
+		return nil
+	}
+	if o.rateLimitMode == RateLimitWait {
+		if err := o.rateLimiter.Wait(ctx); err != nil {
+			return status.Errorf(codes.ResourceExhausted, "rate limit of component %s: %v", o.pluggable.Name, err)
+		}
+		return nil
+	}
+	if !o.rateLimiter.Allow() {
+		return status.Errorf(codes.ResourceExhausted, "rate limit of component %s exceeded", o.pluggable.Name)
+	}
+	return nil
+}
+
+// rateLimitUnaryInterceptor returns a grpc client unary interceptor that applies the component rate limit to every call.
+func (g *GRPCConnector[TClient]) rateLimitUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if err := g.options.rateLimit(ctx, method); err != nil {
+			return err
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// rateLimitStreamInterceptor returns a grpc client stream interceptor that applies the component rate limit to every stream opened.
+func (g *GRPCConnector[TClient]) rateLimitStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if err := g.options.rateLimit(ctx, method); err != nil {
+			return nil, err
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
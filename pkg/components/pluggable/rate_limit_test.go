@@ -0,0 +1,114 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+// publishCode calls the component publish, unimplemented by the ping server, and returns the resulting status code.
+func publishCode(ctx context.Context, connector *GRPCConnector[proto.PubSubClient]) codes.Code {
+	_, err := connector.Client.Publish(ctx, &proto.PublishRequest{})
+	return status.Code(err)
+}
+
+func TestRateLimit(t *testing.T) {
+	t.Run("calls should not be limited by default", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{})
+		require.NoError(t, connector.Dial("my-component"))
+
+		for i := 0; i < 10; i++ {
+			assert.Equal(t, codes.Unimplemented, publishCode(context.Background(), connector))
+		}
+	})
+
+	t.Run("calls above the rate should be rejected by default", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{}, WithRateLimit(1, 2))
+		require.NoError(t, connector.Dial("my-component"))
+
+		assert.Equal(t, codes.Unimplemented, publishCode(context.Background(), connector))
+		assert.Equal(t, codes.Unimplemented, publishCode(context.Background(), connector))
+		assert.Equal(t, codes.ResourceExhausted, publishCode(context.Background(), connector))
+	})
+
+	t.Run("the limit should be shared by all the component operations", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{}, WithRateLimit(1, 1))
+		require.NoError(t, connector.Dial("my-component"))
+
+		assert.Equal(t, codes.Unimplemented, publishCode(context.Background(), connector))
+		_, err := connector.Client.PullMessages(context.Background())
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+
+	t.Run("pings should not be limited", func(t *testing.T) {
+		svc := &pingServer{}
+		connector := testPubSubConnectorFor(t, svc, WithRateLimit(1, 1))
+		require.NoError(t, connector.Dial("my-component"))
+
+		for i := 0; i < 3; i++ {
+			require.NoError(t, connector.Ping())
+		}
+		assert.Equal(t, int64(3), svc.pingCalled.Load())
+	})
+
+	t.Run("lifecycle calls should not be limited", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{}, WithRateLimit(1, 1))
+		require.NoError(t, connector.Dial("my-component"))
+
+		assert.Equal(t, codes.Unimplemented, publishCode(context.Background(), connector))
+		for i := 0; i < 3; i++ {
+			_, err := connector.Client.Init(context.Background(), &proto.PubSubInitRequest{})
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+			_, err = connector.Client.Features(context.Background(), &proto.FeaturesRequest{})
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+			_, err = connector.Client.Schema(context.Background(), &proto.SchemaRequest{})
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+			_, err = connector.Client.Warmup(context.Background(), &proto.WarmupRequest{})
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+			_, err = connector.Client.Shutdown(context.Background(), &proto.ShutdownRequest{})
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+		}
+	})
+
+	t.Run("calls above the rate should wait when configured to", func(t *testing.T) {
+		const rps = 20
+		connector := testPubSubConnectorFor(t, &pingServer{}, WithRateLimit(rps, 1), WithRateLimitMode(RateLimitWait))
+		require.NoError(t, connector.Dial("my-component"))
+
+		start := time.Now()
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, codes.Unimplemented, publishCode(context.Background(), connector))
+		}
+		assert.GreaterOrEqual(t, time.Since(start), 2*time.Second/rps-10*time.Millisecond)
+	})
+
+	t.Run("waiting calls should fail when their deadline would be exceeded", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{}, WithRateLimit(1, 1), WithRateLimitMode(RateLimitWait))
+		require.NoError(t, connector.Dial("my-component"))
+
+		assert.Equal(t, codes.Unimplemented, publishCode(context.Background(), connector))
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		assert.Equal(t, codes.ResourceExhausted, publishCode(ctx, connector))
+	})
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/jhump/protoreflect/grpcreflect"
+	reflectpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// DefaultRequiredComponentsTimeout is the default amount of time to wait for the required pluggable components to be ready.
+const DefaultRequiredComponentsTimeout = 30 * time.Second
+
+// requiredSocketExt is the socket file extension expected for required components.
+const requiredSocketExt = ".sock"
+
+// pingSocket pings all known services served by the given socket.
+func pingSocket(ctx context.Context, socket string) error {
+	conn, err := SocketDial(ctx, socket)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	client := grpcreflect.NewClientV1Alpha(ctx, reflectpb.NewServerReflectionClient(conn))
+	defer client.Reset()
+	serviceList, err := client.ListServices()
+	if err != nil {
+		return fmt.Errorf("unable to list services: %w", err)
+	}
+
+	pinged := 0
+	for _, svc := range serviceList {
+		if _, ok := onServiceDiscovered[svc]; !ok { // ignoring unknown service
+			continue
+		}
+		if err := pingConn(ctx, conn, svc); err != nil {
+			return err
+		}
+		pinged++
+	}
+	if pinged == 0 {
+		return fmt.Errorf("no pluggable component service found on socket '%s'", socket)
+	}
+	return nil
+}
+
+// WaitForRequired blocks until each of the given required components has created its socket and its services answer to Ping.
+// required components are expected to create their socket as '<name>.sock' under the sockets folder, unless its name is overridden.
+// It returns an ErrRequiredComponentNotReady error when any of them is not ready within the given timeout.
+func WaitForRequired(ctx context.Context, names []string, timeout time.Duration) error {
+	return waitForRequired(ctx, names, timeout, pingSocket)
+}
+
+func waitForRequired(ctx context.Context, names []string, timeout time.Duration, ping func(context.Context, string) error) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	folder := GetSocketFolderPath()
+	for _, name := range names {
+		socket := filepath.Join(folder, socketFileFor(name))
+		log.Infof("waiting for required pluggable component '%s' on socket '%s'", name, socket)
+
+		bo := backoff.NewExponentialBackOff()
+		bo.InitialInterval = 10 * time.Millisecond
+		bo.MaxInterval = 500 * time.Millisecond
+		bo.MaxElapsedTime = 0
+
+		var lastErr error
+		err := backoff.Retry(func() error {
+			if lastErr = WaitForSocket(ctx, socket); lastErr != nil {
+				return lastErr
+			}
+			lastErr = ping(ctx, socket)
+			return lastErr
+		}, backoff.WithContext(bo, ctx))
+		if err != nil {
+			if lastErr == nil {
+				lastErr = err
+			}
+			return fmt.Errorf("%w: component '%s' did not respond on socket '%s' within %s: %v", ErrRequiredComponentNotReady, name, socket, timeout, lastErr)
+		}
+		log.Infof("required pluggable component '%s' is ready", name)
+	}
+	return nil
+}
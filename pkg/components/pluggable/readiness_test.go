@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+type pingOnlyStateStore struct {
+	proto.UnimplementedStateStoreServer
+}
+
+func (pingOnlyStateStore) Ping(context.Context, *proto.PingRequest) (*proto.PingResponse, error) {
+	return &proto.PingResponse{}, nil
+}
+
+func TestWaitForRequired(t *testing.T) {
+	// gRPC Pluggable component requires Unix Domain Socket to work, I'm skipping this test when running on windows.
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	socketFolder, err := os.MkdirTemp("/tmp", "required")
+	require.NoError(t, err)
+	defer os.RemoveAll(socketFolder)
+	t.Setenv(SocketFolderEnvVar, socketFolder)
+
+	t.Run("wait should block until the required component socket responds", func(t *testing.T) {
+		serviceName := proto.StateStore_ServiceDesc.ServiceName
+		AddServiceDiscoveryCallback(serviceName, func(string, GRPCConnectionDialer) {})
+		defer delete(onServiceDiscovered, serviceName)
+
+		socket := filepath.Join(socketFolder, "required-comp.sock")
+		serverCh := make(chan *grpc.Server, 1)
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			listener, err := net.Listen("unix", socket)
+			if !assert.NoError(t, err) {
+				serverCh <- nil
+				return
+			}
+			server := grpc.NewServer()
+			proto.RegisterStateStoreServer(server, pingOnlyStateStore{})
+			reflection.Register(server)
+			serverCh <- server
+			server.Serve(listener)
+		}()
+
+		start := time.Now()
+		require.NoError(t, WaitForRequired(context.Background(), []string{"required-comp"}, 5*time.Second))
+		assert.GreaterOrEqual(t, time.Since(start), 200*time.Millisecond)
+
+		if server := <-serverCh; server != nil {
+			server.Stop()
+		}
+	})
+
+	t.Run("wait should retry until the ping succeeds", func(t *testing.T) {
+		socket := filepath.Join(socketFolder, "flaky-comp.sock")
+		listener, err := net.Listen("unix", socket)
+		require.NoError(t, err)
+		defer listener.Close()
+
+		pingCalled := 0
+		err = waitForRequired(context.Background(), []string{"flaky-comp"}, 5*time.Second, func(_ context.Context, s string) error {
+			assert.Equal(t, socket, s)
+			pingCalled++
+			if pingCalled < 3 {
+				return errors.New("not ready")
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 3, pingCalled)
+	})
+
+	t.Run("missing required component should fail with a clear error", func(t *testing.T) {
+		err := WaitForRequired(context.Background(), []string{"missing-comp"}, 100*time.Millisecond)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrRequiredComponentNotReady)
+		assert.Contains(t, err.Error(), "missing-comp")
+		assert.Contains(t, err.Error(), filepath.Join(socketFolder, "missing-comp.sock"))
+	})
+
+	t.Run("unresponsive required component should fail with the last ping error", func(t *testing.T) {
+		socket := filepath.Join(socketFolder, "unresponsive-comp.sock")
+		listener, err := net.Listen("unix", socket)
+		require.NoError(t, err)
+		defer listener.Close()
+
+		err = waitForRequired(context.Background(), []string{"unresponsive-comp"}, 100*time.Millisecond, func(context.Context, string) error {
+			return errors.New("ping failed")
+		})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrRequiredComponentNotReady)
+		assert.Contains(t, err.Error(), "ping failed")
+	})
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// PluggableStatus is the status of a loaded pluggable component instance.
+type PluggableStatus struct {
+	// Type is the pluggable component category, e.g. state.
+	Type string `json:"type"`
+	// Name is the component instance name.
+	Name string `json:"name"`
+	// Component is the pluggable component name, derived from its socket file name.
+	Component string `json:"component,omitempty"`
+	// Version is the component implementation version reported on ping.
+	Version string `json:"version,omitempty"`
+	// SocketPath is the socket the component listens on, when known.
+	SocketPath string `json:"socketPath,omitempty"`
+	// State is the connectivity state of the component connection, DISABLED for disabled components.
+	State string `json:"state"`
+	// LastPing is the time of the last successful ping, nil when the component was never pinged.
+	LastPing *time.Time `json:"lastPing,omitempty"`
+	// InitWarnings are the non-fatal issues reported by the component on its last init.
+	InitWarnings []string `json:"initWarnings,omitempty"`
+}
+
+// stateDisabled is the state of the components disabled through their metadata, see DisabledMetadataKey.
+const stateDisabled = "DISABLED"
+
+// statusReporter reports the status of a loaded pluggable component instance.
+type statusReporter interface {
+	Status() PluggableStatus
+}
+
+// Registry holds the loaded pluggable component instances.
+type Registry struct {
+	lock       sync.RWMutex
+	components map[statusReporter]struct{}
+}
+
+// NewRegistry creates a new empty pluggable component instances registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		components: make(map[statusReporter]struct{}),
+	}
+}
+
+// DefaultRegistry is the registry of the pluggable component instances loaded by the connectors.
+var DefaultRegistry = NewRegistry()
+
+// withRegistry lists the component instance in the given registry instead of the DefaultRegistry.
+func withRegistry(r *Registry) Option {
+	return func(o *connectorOptions) {
+		o.registry = r
+	}
+}
+
+// register adds the given component instance to the registry, registering it again is a no-op.
+func (r *Registry) register(c statusReporter) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.components[c] = struct{}{}
+}
+
+// unregister removes the given component instance from the registry.
+func (r *Registry) unregister(c statusReporter) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.components, c)
+}
+
+// availabilitySetter is a loaded component instance that can be marked unavailable, see ErrComponentUnavailable.
+type availabilitySetter interface {
+	setAvailable(available bool)
+}
+
+// setAvailable marks every loaded instance of the given pluggable component available or not.
+func (r *Registry) setAvailable(componentName string, available bool) {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	for c := range r.components {
+		if setter, ok := c.(availabilitySetter); ok && c.Status().Component == componentName {
+			setter.setAvailable(available)
+		}
+	}
+}
+
+// Snapshot returns the current status of every loaded pluggable component instance, sorted by type and name.
+func (r *Registry) Snapshot() []PluggableStatus {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	snapshot := make([]PluggableStatus, 0, len(r.components))
+	for c := range r.components {
+		snapshot = append(snapshot, c.Status())
+	}
+	sort.Slice(snapshot, func(i, j int) bool {
+		if snapshot[i].Type != snapshot[j].Type {
+			return snapshot[i].Type < snapshot[j].Type
+		}
+		return snapshot[i].Name < snapshot[j].Name
+	})
+	return snapshot
+}
+
+// discoveredSocketOf returns the socket of the given discovered pluggable component, empty when not discovered.
+func discoveredSocketOf(componentName string) string {
+	for _, svc := range getDiscoveredServices() {
+		if svc.componentName == componentName {
+			return svc.socket
+		}
+	}
+	return ""
+}
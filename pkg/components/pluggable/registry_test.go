@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/components"
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+// statusOf returns the status of the component instance with the given name from the given registry snapshot.
+func statusOf(registry *Registry, name string) (PluggableStatus, bool) {
+	for _, status := range registry.Snapshot() {
+		if status.Name == name {
+			return status, true
+		}
+	}
+	return PluggableStatus{}, false
+}
+
+func TestRegistrySnapshot(t *testing.T) {
+	pc := components.Pluggable{Type: components.CategoryPubSub, Name: "my-component"}
+	registry := NewRegistry()
+
+	t.Run("dialed components should be listed with their connectivity state and last ping", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{pingResp: &proto.PingResponse{Version: "1.2.3"}}, WithPluggable(pc), withRegistry(registry))
+		_, ok := statusOf(registry, "snapshot-dialed")
+		assert.False(t, ok, "components should not be listed before being dialed")
+
+		require.NoError(t, connector.Dial("snapshot-dialed"))
+		status, ok := statusOf(registry, "snapshot-dialed")
+		require.True(t, ok)
+		assert.Equal(t, "pubsub", status.Type)
+		assert.Equal(t, "my-component", status.Component)
+		assert.Nil(t, status.LastPing)
+
+		require.NoError(t, connector.Ping())
+		status, ok = statusOf(registry, "snapshot-dialed")
+		require.True(t, ok)
+		assert.Equal(t, "READY", status.State)
+		assert.Equal(t, "1.2.3", status.Version)
+		assert.NotNil(t, status.LastPing)
+
+		require.NoError(t, connector.Close())
+		_, ok = statusOf(registry, "snapshot-dialed")
+		assert.False(t, ok, "closed components should not be listed")
+	})
+
+	t.Run("disabled components should be listed as disabled", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{}, WithPluggable(pc), withRegistry(registry))
+		require.NoError(t, connector.InitOrDisable("snapshot-disabled", map[string]string{DisabledMetadataKey: "true"}, func() error {
+			return connector.Dial("snapshot-disabled")
+		}))
+
+		status, ok := statusOf(registry, "snapshot-disabled")
+		require.True(t, ok)
+		assert.Equal(t, stateDisabled, status.State)
+	})
+
+	t.Run("the socket should be reported when known", func(t *testing.T) {
+		connector := NewGRPCConnector("/tmp/my-component.sock", proto.NewPubSubClient, WithPluggable(pc), withRegistry(registry))
+		connector.loaded("snapshot-socket")
+		t.Cleanup(func() { connector.Close() })
+
+		status, ok := statusOf(registry, "snapshot-socket")
+		require.True(t, ok)
+		assert.Equal(t, "/tmp/my-component.sock", status.SocketPath)
+	})
+
+	t.Run("the snapshot should be sorted by type and name", func(t *testing.T) {
+		registry := NewRegistry()
+		for _, name := range []string{"b", "a"} {
+			connector := testPubSubConnectorFor(t, &pingServer{}, WithPluggable(pc), withRegistry(registry))
+			require.NoError(t, connector.Dial(name))
+		}
+		stateConnector := NewGRPCConnector("", proto.NewPubSubClient, WithPluggable(components.Pluggable{Type: components.CategoryStateStore, Name: "my-component"}), withRegistry(registry))
+		stateConnector.loaded("0")
+		t.Cleanup(func() { stateConnector.Close() })
+
+		snapshot := registry.Snapshot()
+		require.Len(t, snapshot, 3)
+		assert.Equal(t, []string{"pubsub/a", "pubsub/b", "state/0"}, []string{
+			snapshot[0].Type + "/" + snapshot[0].Name,
+			snapshot[1].Type + "/" + snapshot[1].Name,
+			snapshot[2].Type + "/" + snapshot[2].Name,
+		})
+	})
+}
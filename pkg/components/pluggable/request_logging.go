@@ -0,0 +1,99 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/dapr/kit/logger"
+)
+
+// Redactor returns a copy of the given message without the sensitive data that should not be logged.
+type Redactor func(proto.Message) proto.Message
+
+// requestLogger logs the requests and responses exchanged with a pluggable component.
+type requestLogger struct {
+	logger   logger.Logger
+	redactor Redactor
+}
+
+// format returns the serialized message, after redaction.
+func (l *requestLogger) format(msg any) string {
+	m, ok := msg.(proto.Message)
+	if !ok {
+		return fmt.Sprintf("%v", msg)
+	}
+	if l.redactor != nil {
+		m = l.redactor(proto.Clone(m))
+	}
+	b, err := protojson.Marshal(m)
+	if err != nil {
+		return fmt.Sprintf("<unable to serialize message: %v>", err)
+	}
+	return string(b)
+}
+
+// unaryInterceptor logs the method, the request and the response of every unary call at debug level.
+func (l *requestLogger) unaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		err := invoker(ctx, method, req, reply, cc, opts...)
+		if !l.logger.IsOutputLevelEnabled(logger.DebugLevel) {
+			return err
+		}
+		if err != nil {
+			l.logger.Debugf("pluggable component call %s, request: %s, error: %v", method, l.format(req), err)
+			return err
+		}
+		l.logger.Debugf("pluggable component call %s, request: %s, response: %s", method, l.format(req), l.format(reply))
+		return nil
+	}
+}
+
+// streamInterceptor logs the method and every message sent and received through stream calls at debug level.
+func (l *requestLogger) streamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		stream, err := streamer(ctx, desc, cc, method, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &loggingClientStream{ClientStream: stream, method: method, requestLogger: l}, nil
+	}
+}
+
+// loggingClientStream is a client stream that logs the messages sent and received.
+type loggingClientStream struct {
+	grpc.ClientStream
+	*requestLogger
+	method string
+}
+
+func (s *loggingClientStream) SendMsg(m any) error {
+	if s.logger.IsOutputLevelEnabled(logger.DebugLevel) {
+		s.logger.Debugf("pluggable component stream %s, sent: %s", s.method, s.format(m))
+	}
+	return s.ClientStream.SendMsg(m)
+}
+
+func (s *loggingClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	if err == nil && s.logger.IsOutputLevelEnabled(logger.DebugLevel) {
+		s.logger.Debugf("pluggable component stream %s, received: %s", s.method, s.format(m))
+	}
+	return err
+}
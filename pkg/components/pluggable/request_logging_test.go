@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"bytes"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	protobuf "google.golang.org/protobuf/proto"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+	"github.com/dapr/kit/logger"
+)
+
+func TestRequestLogging(t *testing.T) {
+	captureLogs := func(t *testing.T) *bytes.Buffer {
+		buf := &bytes.Buffer{}
+		log.SetOutput(buf)
+		log.SetOutputLevel(logger.DebugLevel)
+		t.Cleanup(func() {
+			log.SetOutput(os.Stdout)
+			log.SetOutputLevel(logger.InfoLevel)
+		})
+		return buf
+	}
+
+	t.Run("request logging should be disabled by default", func(t *testing.T) {
+		options := connectorOptions{}
+		WithRequestLogging(false, nil)(&options)
+		opts, err := options.dialOptions()
+		require.NoError(t, err)
+		assert.Empty(t, opts)
+	})
+
+	t.Run("request logging should log the method name and the response", func(t *testing.T) {
+		logs := captureLogs(t)
+
+		svc := &pingServer{pingResp: &proto.PingResponse{Version: "v1.2.3"}}
+		connector := testPubSubConnectorFor(t, svc, WithRequestLogging(true, nil))
+		require.NoError(t, connector.Dial(""))
+		require.NoError(t, connector.Ping())
+
+		assert.Contains(t, logs.String(), "/dapr.proto.components.v1.PubSub/Ping")
+		assert.Contains(t, logs.String(), "v1.2.3")
+	})
+
+	t.Run("request logging should apply the redactor before logging", func(t *testing.T) {
+		logs := captureLogs(t)
+
+		redactor := func(m protobuf.Message) protobuf.Message {
+			if resp, ok := m.(*proto.PingResponse); ok {
+				resp.BuildSha = "***"
+			}
+			return m
+		}
+		svc := &pingServer{pingResp: &proto.PingResponse{BuildSha: "secret-sha"}}
+		connector := testPubSubConnectorFor(t, svc, WithRequestLogging(true, redactor))
+		require.NoError(t, connector.Dial(""))
+		require.NoError(t, connector.Ping())
+
+		assert.Contains(t, logs.String(), "***")
+		assert.NotContains(t, logs.String(), "secret-sha")
+		// the redactor must not change the response returned to the caller.
+		assert.Equal(t, "secret-sha", connector.Info().BuildSHA)
+	})
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"google.golang.org/grpc"
+)
+
+// retryBudgetWindowCalls is the number of calls whose deposits can be accumulated by a retry budget.
+const retryBudgetWindowCalls = 100
+
+// RetryBudget is a token bucket shared by all the retry paths of a component, so retries are throttled when the failure rate is high
+// instead of combining into a retry storm that overloads a recovering component.
+// Every call made to the component deposits ratio tokens and minPerSec tokens are added every second, every retry withdraws one token.
+type RetryBudget struct {
+	ratio     float64
+	minPerSec float64
+	maxTokens float64
+
+	lock       sync.Mutex
+	tokens     float64
+	lastRefill time.Time
+	now        func() time.Time
+}
+
+// NewRetryBudget creates a retry budget that allows retrying up to the given ratio of the calls made to the component,
+// plus minPerSec retries per second so that components with low traffic can still retry.
+func NewRetryBudget(ratio float64, minPerSec int) *RetryBudget {
+	if ratio < 0 {
+		ratio = 0
+	}
+	if minPerSec < 0 {
+		minPerSec = 0
+	}
+	b := &RetryBudget{
+		ratio:     ratio,
+		minPerSec: float64(minPerSec),
+		maxTokens: float64(minPerSec) + ratio*retryBudgetWindowCalls,
+		tokens:    float64(minPerSec),
+		now:       time.Now,
+	}
+	b.lastRefill = b.now()
+	return b
+}
+
+// refill adds the min per second tokens elapsed since the last refill, it must be called with the lock held.
+func (b *RetryBudget) refill() {
+	now := b.now()
+	b.tokens += now.Sub(b.lastRefill).Seconds() * b.minPerSec
+	if b.tokens > b.maxTokens {
+		b.tokens = b.maxTokens
+	}
+	b.lastRefill = now
+}
+
+// Deposit records a call made to the component.
+func (b *RetryBudget) Deposit() {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.refill()
+	b.tokens += b.ratio
+	if b.tokens > b.maxTokens {
+		b.tokens = b.maxTokens
+	}
+}
+
+// TryWithdraw returns true and withdraws a token when the budget allows a retry.
+func (b *RetryBudget) TryWithdraw() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.refill()
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// budgetedBackOff is a backoff that stops retrying when the retry budget is exhausted.
+type budgetedBackOff struct {
+	backoff.BackOff
+	budget *RetryBudget
+}
+
+func (b *budgetedBackOff) NextBackOff() time.Duration {
+	next := b.BackOff.NextBackOff()
+	if next == backoff.Stop {
+		return next
+	}
+	if !b.budget.TryWithdraw() {
+		log.Debug("retry budget exhausted, skipping retry")
+		return backoff.Stop
+	}
+	return next
+}
+
+// BudgetedBackOff returns the given backoff bounded by the component retry budget, see WithRetryBudget.
+// When the budget is exhausted the backoff stops so the last error is returned instead of retrying.
+// The backoff is returned as is when no retry budget is set.
+func (g *GRPCConnector[TClient]) BudgetedBackOff(bo backoff.BackOff) backoff.BackOff {
+	if g.options.retryBudget == nil {
+		return bo
+	}
+	return &budgetedBackOff{BackOff: bo, budget: g.options.retryBudget}
+}
+
+// retryBudgetUnaryInterceptor returns a grpc client unary interceptor that deposits every call in the component retry budget.
+func (g *GRPCConnector[TClient]) retryBudgetUnaryInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if g.options.retryBudget != nil {
+			g.options.retryBudget.Deposit()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+// retryBudgetStreamInterceptor returns a grpc client stream interceptor that deposits every stream in the component retry budget.
+func (g *GRPCConnector[TClient]) retryBudgetStreamInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		if g.options.retryBudget != nil {
+			g.options.retryBudget.Deposit()
+		}
+		return streamer(ctx, desc, cc, method, opts...)
+	}
+}
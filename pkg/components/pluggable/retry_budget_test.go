@@ -0,0 +1,109 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+func TestRetryBudget(t *testing.T) {
+	budgetAt := func(ratio float64, minPerSec int, now *time.Time) *RetryBudget {
+		b := NewRetryBudget(ratio, minPerSec)
+		b.now = func() time.Time { return *now }
+		b.lastRefill = *now
+		return b
+	}
+
+	t.Run("min per second tokens should be refilled over time", func(t *testing.T) {
+		now := time.Now()
+		b := budgetAt(0, 1, &now)
+
+		assert.True(t, b.TryWithdraw())
+		assert.False(t, b.TryWithdraw())
+
+		now = now.Add(time.Second)
+		assert.True(t, b.TryWithdraw())
+		assert.False(t, b.TryWithdraw())
+	})
+
+	t.Run("calls should deposit the ratio of a retry", func(t *testing.T) {
+		now := time.Now()
+		b := budgetAt(0.5, 0, &now)
+
+		assert.False(t, b.TryWithdraw())
+		b.Deposit()
+		assert.False(t, b.TryWithdraw())
+		b.Deposit()
+		assert.True(t, b.TryWithdraw())
+		assert.False(t, b.TryWithdraw())
+	})
+
+	t.Run("tokens should not accumulate beyond the budget window", func(t *testing.T) {
+		now := time.Now()
+		b := budgetAt(0, 2, &now)
+
+		now = now.Add(time.Hour)
+		for i := 0; i < 2; i++ {
+			assert.True(t, b.TryWithdraw())
+		}
+		assert.False(t, b.TryWithdraw())
+	})
+
+	t.Run("exhausted budget should stop the backoff and return the original error", func(t *testing.T) {
+		connector := NewGRPCConnectorWithDialer(nil, func(grpc.ClientConnInterface) *fakeClient {
+			return &fakeClient{}
+		}, WithRetryBudget(0, 0))
+
+		errFailed := errors.New("failed")
+		attempts := 0
+		err := backoff.Retry(func() error {
+			attempts++
+			return errFailed
+		}, connector.BudgetedBackOff(backoff.WithMaxRetries(backoff.NewConstantBackOff(time.Millisecond), 5)))
+		assert.Equal(t, errFailed, err)
+		assert.Equal(t, 1, attempts)
+	})
+
+	t.Run("backoff should be returned as is without a retry budget", func(t *testing.T) {
+		connector := NewGRPCConnectorWithDialer(nil, func(grpc.ClientConnInterface) *fakeClient {
+			return &fakeClient{}
+		})
+		bo := backoff.NewConstantBackOff(time.Millisecond)
+		assert.Same(t, bo, connector.BudgetedBackOff(bo))
+	})
+
+	t.Run("initial ping retries should be skipped when the budget is exhausted", func(t *testing.T) {
+		svc := &flakyPingServer{failures: 5, err: status.Error(codes.Unavailable, "warming up")}
+		connector := testConnectorFor(t, func(s *grpc.Server, svc *flakyPingServer) {
+			proto.RegisterPubSubServer(s, svc)
+		}, svc, proto.NewPubSubClient, WithInitialPingRetries(5, time.Millisecond), WithRetryBudget(0, 1))
+
+		err := connector.Dial("my-component")
+		require.Error(t, err)
+		assert.Equal(t, codes.Unavailable, status.Code(errors.Unwrap(err)))
+		// the first attempt plus the single retry allowed by the budget.
+		assert.Equal(t, int64(2), svc.pingCalled.Load())
+	})
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+// schemaValidation is set when the component metadata is validated against the schema advertised by the component, see SetSchemaValidation.
+var schemaValidation atomic.Bool
+
+// SetSchemaValidation enables the validation of the component metadata against the schema advertised by the component before its init.
+func SetSchemaValidation(enabled bool) {
+	schemaValidation.Store(enabled)
+}
+
+// schemaProvider is a client of a component service supporting the optional schema rpc.
+type schemaProvider interface {
+	Schema(ctx context.Context, in *proto.SchemaRequest, opts ...grpc.CallOption) (*proto.SchemaResponse, error)
+}
+
+// ValidateMetadata validates the given component metadata properties against the schema advertised by the component when schema
+// validation is enabled, see SetSchemaValidation. It returns a MetadataSchemaError listing every invalid key.
+// The keys handled by the runtime, the given ones, those prefixed by 'dapr.io/' and SocketPathMetadataKey, are not validated.
+// Components that don't implement the schema rpc are not validated.
+func (g *GRPCConnector[TClient]) ValidateMetadata(properties map[string]string, runtimeKeys ...string) error {
+	if !schemaValidation.Load() {
+		return nil
+	}
+	client, ok := any(g.Client).(schemaProvider)
+	if !ok {
+		return nil
+	}
+	schema, err := client.Schema(g.Context, &proto.SchemaRequest{})
+	if status.Code(err) == codes.Unimplemented {
+		g.logger.Debug("pluggable component does not advertise its metadata schema, skipping validation")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("could not get the pluggable component metadata schema: %w", err)
+	}
+
+	skipped := map[string]struct{}{strings.ToLower(SocketPathMetadataKey): {}}
+	for _, key := range runtimeKeys {
+		skipped[strings.ToLower(key)] = struct{}{}
+	}
+	validated := make(map[string]string, len(properties))
+	for key, value := range properties {
+		if _, ok := skipped[strings.ToLower(key)]; ok || strings.HasPrefix(key, "dapr.io/") {
+			continue
+		}
+		validated[key] = value
+	}
+	return validateMetadata(schema, validated)
+}
+
+// validateMetadata validates the given metadata properties against the given schema, keys are matched case-insensitively.
+func validateMetadata(schema *proto.SchemaResponse, properties map[string]string) error {
+	fields := make(map[string]*proto.MetadataFieldSchema, len(schema.GetFields()))
+	for key, field := range schema.GetFields() {
+		fields[strings.ToLower(key)] = field
+	}
+
+	var errs []MetadataFieldError
+	seen := make(map[string]struct{}, len(properties))
+	for key, value := range properties {
+		field, ok := fields[strings.ToLower(key)]
+		if !ok {
+			if !schema.GetAllowUnknownFields() {
+				errs = append(errs, MetadataFieldError{Key: key, Reason: "unknown key"})
+			}
+			continue
+		}
+		seen[strings.ToLower(key)] = struct{}{}
+		if err := validateMetadataValue(field.GetType(), value); err != nil {
+			errs = append(errs, MetadataFieldError{Key: key, Reason: err.Error()})
+		}
+	}
+	for key, field := range schema.GetFields() {
+		if _, ok := seen[strings.ToLower(key)]; field.GetRequired() && !ok {
+			errs = append(errs, MetadataFieldError{Key: key, Reason: "required key is missing"})
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.Slice(errs, func(i, j int) bool {
+		return errs[i].Key < errs[j].Key
+	})
+	return &MetadataSchemaError{Fields: errs}
+}
+
+// validateMetadataValue returns an error when the given metadata value is not of the given type.
+func validateMetadataValue(typ proto.MetadataFieldSchema_Type, value string) error {
+	var err error
+	switch typ {
+	case proto.MetadataFieldSchema_TYPE_NUMBER:
+		_, err = strconv.ParseFloat(value, 64)
+	case proto.MetadataFieldSchema_TYPE_BOOLEAN:
+		_, err = strconv.ParseBool(value)
+	case proto.MetadataFieldSchema_TYPE_DURATION:
+		_, err = time.ParseDuration(value)
+	}
+	if err != nil {
+		return fmt.Errorf("value '%s' is not a valid %s", value, strings.ToLower(strings.TrimPrefix(typ.String(), "TYPE_")))
+	}
+	return nil
+}
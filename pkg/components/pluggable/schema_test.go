@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+// schemaServer is a pubsub server advertising the given metadata schema.
+type schemaServer struct {
+	pingServer
+	schema       *proto.SchemaResponse
+	schemaCalled atomic.Int64
+}
+
+func (s *schemaServer) Schema(context.Context, *proto.SchemaRequest) (*proto.SchemaResponse, error) {
+	s.schemaCalled.Add(1)
+	return s.schema, nil
+}
+
+func TestValidateMetadata(t *testing.T) {
+	schema := &proto.SchemaResponse{
+		Fields: map[string]*proto.MetadataFieldSchema{
+			"connectionString": {Type: proto.MetadataFieldSchema_TYPE_STRING, Required: true},
+			"maxRetries":       {Type: proto.MetadataFieldSchema_TYPE_NUMBER},
+			"enableTLS":        {Type: proto.MetadataFieldSchema_TYPE_BOOLEAN},
+			"timeout":          {Type: proto.MetadataFieldSchema_TYPE_DURATION},
+		},
+	}
+	connectorFor := func(t *testing.T, svc *schemaServer) *GRPCConnector[proto.PubSubClient] {
+		t.Helper()
+		connector := testConnectorFor(t, func(s *grpc.Server, svc *schemaServer) {
+			proto.RegisterPubSubServer(s, svc)
+		}, svc, proto.NewPubSubClient)
+		require.NoError(t, connector.Dial("my-component"))
+		return connector
+	}
+
+	SetSchemaValidation(true)
+	t.Cleanup(func() { SetSchemaValidation(false) })
+
+	t.Run("metadata matching the schema should be valid", func(t *testing.T) {
+		connector := connectorFor(t, &schemaServer{schema: schema})
+		require.NoError(t, connector.ValidateMetadata(map[string]string{
+			"connectionString": "host=localhost",
+			"MAXRETRIES":       "3",
+			"enableTLS":        "true",
+			"timeout":          "5s",
+		}))
+	})
+
+	t.Run("unknown and mistyped keys should be reported field by field", func(t *testing.T) {
+		connector := connectorFor(t, &schemaServer{schema: schema})
+		err := connector.ValidateMetadata(map[string]string{
+			"connectionString": "host=localhost",
+			"maxRetires":       "3",
+			"enableTLS":        "yes please",
+			"timeout":          "5",
+		})
+		require.ErrorIs(t, err, ErrMetadataSchemaViolation)
+
+		var schemaErr *MetadataSchemaError
+		require.ErrorAs(t, err, &schemaErr)
+		assert.Equal(t, []MetadataFieldError{
+			{Key: "enableTLS", Reason: "value 'yes please' is not a valid boolean"},
+			{Key: "maxRetires", Reason: "unknown key"},
+			{Key: "timeout", Reason: "value '5' is not a valid duration"},
+		}, schemaErr.Fields)
+	})
+
+	t.Run("missing required keys should be reported", func(t *testing.T) {
+		connector := connectorFor(t, &schemaServer{schema: schema})
+		var schemaErr *MetadataSchemaError
+		require.ErrorAs(t, connector.ValidateMetadata(map[string]string{}), &schemaErr)
+		assert.Equal(t, []MetadataFieldError{{Key: "connectionString", Reason: "required key is missing"}}, schemaErr.Fields)
+	})
+
+	t.Run("the keys handled by the runtime should not be validated", func(t *testing.T) {
+		connector := connectorFor(t, &schemaServer{schema: schema})
+		require.NoError(t, connector.ValidateMetadata(map[string]string{
+			"connectionString":    "host=localhost",
+			DisabledMetadataKey:   "false",
+			SocketPathMetadataKey: "/tmp/my-component.sock",
+			"maxBulkSize":         "10",
+		}, "maxBulkSize"))
+	})
+
+	t.Run("unknown keys should be valid when allowed by the schema", func(t *testing.T) {
+		connector := connectorFor(t, &schemaServer{schema: &proto.SchemaResponse{AllowUnknownFields: true}})
+		require.NoError(t, connector.ValidateMetadata(map[string]string{"anything": "goes"}))
+	})
+
+	t.Run("components without a schema should not be validated", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{})
+		require.NoError(t, connector.Dial("my-component"))
+		require.NoError(t, connector.ValidateMetadata(map[string]string{"anything": "goes"}))
+	})
+
+	t.Run("metadata should not be validated when the schema validation is disabled", func(t *testing.T) {
+		SetSchemaValidation(false)
+		t.Cleanup(func() { SetSchemaValidation(true) })
+
+		svc := &schemaServer{schema: schema}
+		connector := connectorFor(t, svc)
+		require.NoError(t, connector.ValidateMetadata(map[string]string{"anything": "goes"}))
+		assert.Zero(t, svc.schemaCalled.Load())
+	})
+}
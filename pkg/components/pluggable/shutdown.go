@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+// defaultShutdownTimeout is the default max amount of time to wait for the component to handle the shutdown signal.
+const defaultShutdownTimeout = 5 * time.Second
+
+// shutdowner is a client of a component service supporting the optional shutdown rpc.
+type shutdowner interface {
+	Shutdown(ctx context.Context, in *proto.ShutdownRequest, opts ...grpc.CallOption) (*proto.ShutdownResponse, error)
+}
+
+// shutdownTimeoutOrDefault returns the configured shutdown timeout, or the default one.
+func (o *connectorOptions) shutdownTimeoutOrDefault() time.Duration {
+	if o.shutdownTimeout > 0 {
+		return o.shutdownTimeout
+	}
+	return defaultShutdownTimeout
+}
+
+// shutdown signals the component that its connection is being closed, waiting for it up to the shutdown timeout bounded by the given context.
+// components that don't implement the shutdown rpc are not waited for, other failures are logged and ignored as the connection is closed anyway.
+func (g *GRPCConnector[TClient]) shutdown(ctx context.Context) {
+	client, ok := any(g.Client).(shutdowner)
+	if !ok || g.conn == nil {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, g.options.shutdownTimeoutOrDefault())
+	defer cancel()
+
+	_, err := client.Shutdown(ctx, &proto.ShutdownRequest{}, grpc.WaitForReady(false))
+	switch status.Code(err) {
+	case codes.OK:
+		g.logger.Debug("pluggable component acknowledged the shutdown")
+	case codes.Unimplemented:
+	default:
+		g.logger.Warnf("pluggable component shutdown failed, closing its connection anyway: %v", err)
+	}
+}
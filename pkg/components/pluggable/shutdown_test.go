@@ -0,0 +1,118 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+type shutdownServer struct {
+	pingServer
+	shutdownCalled atomic.Int64
+	onShutdown     func(context.Context)
+}
+
+func (s *shutdownServer) Shutdown(ctx context.Context, _ *proto.ShutdownRequest) (*proto.ShutdownResponse, error) {
+	s.shutdownCalled.Add(1)
+	if s.onShutdown != nil {
+		s.onShutdown(ctx)
+	}
+	return &proto.ShutdownResponse{}, nil
+}
+
+// testShutdownConnectorFor returns a pubsub connector backed by the given in-memory server implementing the shutdown rpc.
+func testShutdownConnectorFor(t *testing.T, svc *shutdownServer, opts ...Option) *GRPCConnector[proto.PubSubClient] {
+	t.Helper()
+	return testConnectorFor(t, func(s *grpc.Server, svc *shutdownServer) {
+		proto.RegisterPubSubServer(s, svc)
+	}, svc, proto.NewPubSubClient, opts...)
+}
+
+func TestCloseGracefully(t *testing.T) {
+	t.Run("the component shutdown should be called before closing the connection", func(t *testing.T) {
+		svc := &shutdownServer{}
+		connector := testShutdownConnectorFor(t, svc)
+		require.NoError(t, connector.Dial("my-component"))
+
+		require.NoError(t, connector.CloseGracefully(context.Background()))
+		assert.Equal(t, int64(1), svc.shutdownCalled.Load())
+
+		require.NoError(t, connector.Close())
+		assert.Equal(t, int64(1), svc.shutdownCalled.Load(), "the component should be shut down only once")
+	})
+
+	t.Run("close should shut the component down", func(t *testing.T) {
+		svc := &shutdownServer{}
+		connector := testShutdownConnectorFor(t, svc)
+		require.NoError(t, connector.Dial("my-component"))
+
+		require.NoError(t, connector.Close())
+		assert.Equal(t, int64(1), svc.shutdownCalled.Load())
+	})
+
+	t.Run("components not implementing the shutdown should not block the close", func(t *testing.T) {
+		connector := testPubSubConnectorFor(t, &pingServer{}, WithShutdownTimeout(time.Minute))
+		require.NoError(t, connector.Dial("my-component"))
+
+		closed := make(chan error, 1)
+		go func() { closed <- connector.CloseGracefully(context.Background()) }()
+
+		select {
+		case err := <-closed:
+			require.NoError(t, err)
+		case <-time.After(time.Second):
+			require.Fail(t, "close should not wait for components not implementing the shutdown")
+		}
+	})
+
+	t.Run("the component shutdown should be bounded by the shutdown timeout", func(t *testing.T) {
+		svc := &shutdownServer{onShutdown: func(ctx context.Context) { <-ctx.Done() }}
+		connector := testShutdownConnectorFor(t, svc, WithShutdownTimeout(50*time.Millisecond))
+		require.NoError(t, connector.Dial("my-component"))
+
+		start := time.Now()
+		require.NoError(t, connector.CloseGracefully(context.Background()))
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, int64(1), svc.shutdownCalled.Load())
+	})
+
+	t.Run("the component shutdown should be bounded by the given context", func(t *testing.T) {
+		svc := &shutdownServer{onShutdown: func(ctx context.Context) { <-ctx.Done() }}
+		connector := testShutdownConnectorFor(t, svc, WithShutdownTimeout(time.Minute))
+		require.NoError(t, connector.Dial("my-component"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		require.NoError(t, connector.CloseGracefully(ctx))
+		assert.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("components not dialed should not be shut down", func(t *testing.T) {
+		svc := &shutdownServer{}
+		connector := testShutdownConnectorFor(t, svc)
+
+		require.NoError(t, connector.Close())
+		assert.Equal(t, int64(0), svc.shutdownCalled.Load())
+	})
+}
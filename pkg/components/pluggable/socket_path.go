@@ -0,0 +1,44 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// SocketPathMetadataKey is the component metadata key used to set the absolute socket path the component listens on,
+// instead of the socket discovered in the sockets folder. The path must be within the sockets folder, see GetSocketFolderPath.
+const SocketPathMetadataKey = "socketPath"
+
+// socketPathOf returns the socket path set through the given component metadata properties, empty when not set.
+// It returns an ErrSocketPathNotAllowed error when the path is not absolute or not within the sockets folder.
+func socketPathOf(properties map[string]string) (string, error) {
+	socket := properties[SocketPathMetadataKey]
+	if socket == "" {
+		return "", nil
+	}
+	if !filepath.IsAbs(socket) {
+		return "", fmt.Errorf("%w: socket path '%s' is not absolute", ErrSocketPathNotAllowed, socket)
+	}
+
+	folder := filepath.Clean(GetSocketFolderPath())
+	socket = filepath.Clean(socket)
+	rel, err := filepath.Rel(folder, socket)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%w: socket path '%s' is not within the sockets folder '%s'", ErrSocketPathNotAllowed, socket, folder)
+	}
+	return socket, nil
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+// servePingOn serves a pubsub ping server on the given socket path.
+func servePingOn(t *testing.T, socket string) *pingServer {
+	t.Helper()
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	svc := &pingServer{}
+	s := grpc.NewServer()
+	proto.RegisterPubSubServer(s, svc)
+	go s.Serve(listener)
+	t.Cleanup(s.Stop)
+	return svc
+}
+
+func TestSocketPath(t *testing.T) {
+	// gRPC Pluggable component requires Unix Domain Socket to work, I'm skipping this test when running on windows.
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	const componentName = "my-component"
+	folder, err := os.MkdirTemp("", "sockets")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(folder) })
+	t.Setenv(SocketFolderEnvVar, folder)
+
+	defaultSocket := filepath.Join(folder, "default.sock")
+	explicitSocket := filepath.Join(folder, "explicit", "explicit.sock")
+	require.NoError(t, os.Mkdir(filepath.Dir(explicitSocket), 0o700))
+	defaultSvc := servePingOn(t, defaultSocket)
+	explicitSvc := servePingOn(t, explicitSocket)
+
+	initWith := func(t *testing.T, properties map[string]string) (*GRPCConnector[proto.PubSubClient], error) {
+		t.Helper()
+		connector := NewGRPCConnector(defaultSocket, proto.NewPubSubClient)
+		t.Cleanup(func() { connector.Close() })
+		return connector, connector.InitOrDisable(componentName, properties, func() error {
+			return connector.Dial(componentName)
+		})
+	}
+
+	t.Run("the socket path set through the component metadata should be dialed when present", func(t *testing.T) {
+		defaultCalls, explicitCalls := defaultSvc.pingCalled.Load(), explicitSvc.pingCalled.Load()
+
+		connector, err := initWith(t, map[string]string{SocketPathMetadataKey: explicitSocket})
+		require.NoError(t, err)
+		require.NoError(t, connector.Ping())
+
+		assert.Equal(t, explicitCalls+1, explicitSvc.pingCalled.Load())
+		assert.Equal(t, defaultCalls, defaultSvc.pingCalled.Load())
+	})
+
+	t.Run("the connector socket should be dialed when the socket path is not set", func(t *testing.T) {
+		defaultCalls, explicitCalls := defaultSvc.pingCalled.Load(), explicitSvc.pingCalled.Load()
+
+		connector, err := initWith(t, map[string]string{})
+		require.NoError(t, err)
+		require.NoError(t, connector.Ping())
+
+		assert.Equal(t, defaultCalls+1, defaultSvc.pingCalled.Load())
+		assert.Equal(t, explicitCalls, explicitSvc.pingCalled.Load())
+	})
+
+	t.Run("socket paths outside of the sockets folder should not be allowed", func(t *testing.T) {
+		for _, socket := range []string{
+			"relative.sock",
+			folder,
+			filepath.Join(os.TempDir(), "outside.sock"),
+			filepath.Join(folder, "..", "escaped.sock"),
+		} {
+			connector, err := initWith(t, map[string]string{SocketPathMetadataKey: socket})
+			require.ErrorIs(t, err, ErrSocketPathNotAllowed, socket)
+			assert.Nil(t, connector.conn, "the component should not be dialed")
+		}
+	})
+}
@@ -0,0 +1,55 @@
+//go:build !windows
+// +build !windows
+
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"syscall"
+)
+
+// writeOK is the access mode required to connect to a unix domain socket.
+const writeOK = 0x2
+
+// socketAccess checks whether the sidecar is allowed to connect to the given socket.
+var socketAccess = func(socket string) error {
+	return syscall.Access(socket, writeOK)
+}
+
+// checkSocketPermissions returns a SocketPermissionError when the sidecar is not allowed to connect to the given socket.
+// other access errors are ignored and left to the dial.
+func checkSocketPermissions(socket string) error {
+	err := socketAccess(socket)
+	if !errors.Is(err, fs.ErrPermission) {
+		return nil
+	}
+
+	permissionErr := &SocketPermissionError{
+		Socket:     socket,
+		SidecarUID: os.Getuid(),
+		Err:        err,
+	}
+	if info, statErr := os.Stat(socket); statErr == nil {
+		permissionErr.Mode = info.Mode()
+		if stat, ok := info.Sys().(*syscall.Stat_t); ok {
+			permissionErr.OwnerUID = int(stat.Uid)
+			permissionErr.OwnerGID = int(stat.Gid)
+		}
+	}
+	return permissionErr
+}
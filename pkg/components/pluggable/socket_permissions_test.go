@@ -0,0 +1,81 @@
+//go:build !windows
+// +build !windows
+
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSocketPermissions(t *testing.T) {
+	socket := filepath.Join(t.TempDir(), "component.sock")
+	lis, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	defer lis.Close()
+
+	t.Run("accessible sockets should not return an error", func(t *testing.T) {
+		assert.NoError(t, checkSocketPermissions(socket))
+	})
+
+	t.Run("permission denied should describe the socket mode and owner", func(t *testing.T) {
+		defaultAccess := socketAccess
+		socketAccess = func(string) error { return syscall.EACCES }
+		defer func() { socketAccess = defaultAccess }()
+
+		info, err := os.Stat(socket)
+		require.NoError(t, err)
+		stat := info.Sys().(*syscall.Stat_t)
+
+		err = checkSocketPermissions(socket)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, ErrSocketPermissionDenied)
+		assert.ErrorIs(t, err, syscall.EACCES)
+
+		var permissionErr *SocketPermissionError
+		require.ErrorAs(t, err, &permissionErr)
+		assert.Equal(t, socket, permissionErr.Socket)
+		assert.Equal(t, info.Mode(), permissionErr.Mode)
+		assert.Equal(t, int(stat.Uid), permissionErr.OwnerUID)
+		assert.Equal(t, int(stat.Gid), permissionErr.OwnerGID)
+		assert.Equal(t, os.Getuid(), permissionErr.SidecarUID)
+		assert.Contains(t, err.Error(), info.Mode().String())
+	})
+
+	t.Run("other access errors should be left to the dial", func(t *testing.T) {
+		defaultAccess := socketAccess
+		socketAccess = func(string) error { return syscall.ENOENT }
+		defer func() { socketAccess = defaultAccess }()
+
+		assert.NoError(t, checkSocketPermissions(socket))
+	})
+
+	t.Run("dial should fail with a permission error", func(t *testing.T) {
+		defaultAccess := socketAccess
+		socketAccess = func(string) error { return syscall.EACCES }
+		defer func() { socketAccess = defaultAccess }()
+
+		_, err := socketDialer(socket)(context.Background(), "")
+		assert.ErrorIs(t, err, ErrSocketPermissionDenied)
+	})
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"sync"
+)
+
+// startupStatus is the startup progress of a required pluggable component.
+type startupStatus struct {
+	initialized bool
+	pinged      bool
+}
+
+// startupTracker tracks whether the required pluggable components completed their startup.
+type startupTracker struct {
+	lock     sync.RWMutex
+	required map[string]*startupStatus
+}
+
+// startup is the startup tracker of the required pluggable components.
+var startup = &startupTracker{}
+
+// SetRequiredForStartup sets the pluggable components that must complete their init and their first ping
+// before StartupComplete reports the startup as complete, any previous startup progress is discarded.
+func SetRequiredForStartup(names []string) {
+	startup.lock.Lock()
+	defer startup.lock.Unlock()
+	startup.required = make(map[string]*startupStatus, len(names))
+	for _, name := range names {
+		startup.required[name] = &startupStatus{}
+	}
+}
+
+// StartupComplete returns true when all required pluggable components completed their init and replied to their first ping.
+// It returns true when no pluggable component is required.
+func StartupComplete() bool {
+	startup.lock.RLock()
+	defer startup.lock.RUnlock()
+	for _, status := range startup.required {
+		if !status.initialized || !status.pinged {
+			return false
+		}
+	}
+	return true
+}
+
+// markInitialized records a successful init of the given pluggable component.
+func (s *startupTracker) markInitialized(name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if status, ok := s.required[name]; ok {
+		status.initialized = true
+	}
+}
+
+// markPinged records a successful ping of the given pluggable component.
+func (s *startupTracker) markPinged(name string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if status, ok := s.required[name]; ok {
+		status.pinged = true
+	}
+}
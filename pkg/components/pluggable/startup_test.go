@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/dapr/pkg/components"
+)
+
+func TestStartupComplete(t *testing.T) {
+	t.Cleanup(func() { SetRequiredForStartup(nil) })
+
+	t.Run("startup should be complete when no component is required", func(t *testing.T) {
+		SetRequiredForStartup(nil)
+		assert.True(t, StartupComplete())
+	})
+
+	t.Run("startup should be complete only after all required components are initialized and pinged", func(t *testing.T) {
+		SetRequiredForStartup([]string{"first", "second"})
+
+		first := testPubSubConnectorFor(t, &pingServer{}, WithPluggable(components.Pluggable{Type: components.CategoryPubSub, Name: "first"}))
+		second := testPubSubConnectorFor(t, &pingServer{}, WithPluggable(components.Pluggable{Type: components.CategoryPubSub, Name: "second"}))
+		require.NoError(t, first.Dial(""))
+		require.NoError(t, second.Dial(""))
+
+		require.NoError(t, first.InitOnce("first-instance", func() error { return nil }))
+		require.NoError(t, first.Ping())
+		assert.False(t, StartupComplete(), "the second component did not start yet")
+
+		require.NoError(t, second.Ping())
+		assert.False(t, StartupComplete(), "the second component is not initialized yet")
+
+		require.NoError(t, second.InitOnce("second-instance", func() error { return nil }))
+		assert.True(t, StartupComplete())
+	})
+
+	t.Run("startup should not be complete when a required component init fails", func(t *testing.T) {
+		SetRequiredForStartup([]string{"failing"})
+
+		connector := testPubSubConnectorFor(t, &pingServer{}, WithPluggable(components.Pluggable{Type: components.CategoryPubSub, Name: "failing"}))
+		require.NoError(t, connector.Dial(""))
+		require.NoError(t, connector.Ping())
+
+		require.Error(t, connector.InitOnce("failing-instance", func() error { return errors.New("init failed") }))
+		assert.False(t, StartupComplete())
+	})
+
+	t.Run("components that are not required should not affect the startup", func(t *testing.T) {
+		SetRequiredForStartup([]string{"required"})
+
+		connector := testPubSubConnectorFor(t, &pingServer{}, WithPluggable(components.Pluggable{Type: components.CategoryPubSub, Name: "other"}))
+		require.NoError(t, connector.Dial(""))
+		require.NoError(t, connector.Ping())
+		require.NoError(t, connector.InitOnce("other-instance", func() error { return nil }))
+
+		assert.False(t, StartupComplete())
+	})
+}
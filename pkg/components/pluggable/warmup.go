@@ -0,0 +1,62 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+// defaultWarmupTimeout is the default max amount of time to wait for the component warmup.
+const defaultWarmupTimeout = 30 * time.Second
+
+// warmer is a client of a component service supporting the optional warmup rpc.
+type warmer interface {
+	Warmup(ctx context.Context, in *proto.WarmupRequest, opts ...grpc.CallOption) (*proto.WarmupResponse, error)
+}
+
+// warmupTimeoutOrDefault returns the configured warmup timeout, or the default one.
+func (o *connectorOptions) warmupTimeoutOrDefault() time.Duration {
+	if o.warmupTimeout > 0 {
+		return o.warmupTimeout
+	}
+	return defaultWarmupTimeout
+}
+
+// Warmup lets the component warm up once initialized, waiting for it up to the warmup timeout.
+// components that don't implement the warmup rpc are skipped, other failures are logged and ignored as the component is usable anyway.
+func (g *GRPCConnector[TClient]) Warmup() {
+	client, ok := any(g.Client).(warmer)
+	if !ok {
+		return
+	}
+	ctx, cancel := context.WithTimeout(g.Context, g.options.warmupTimeoutOrDefault())
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Warmup(ctx, &proto.WarmupRequest{})
+	switch status.Code(err) {
+	case codes.OK:
+		g.logger.Debugf("pluggable component warmed up in %s", time.Since(start))
+	case codes.Unimplemented:
+	default:
+		g.logger.Warnf("pluggable component warmup failed, serving traffic anyway: %v", err)
+	}
+}
@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+type warmupServer struct {
+	pingServer
+	warmupCalled atomic.Int64
+	warmupErr    error
+	onWarmup     func(context.Context)
+}
+
+func (s *warmupServer) Warmup(ctx context.Context, _ *proto.WarmupRequest) (*proto.WarmupResponse, error) {
+	s.warmupCalled.Add(1)
+	if s.onWarmup != nil {
+		s.onWarmup(ctx)
+	}
+	return &proto.WarmupResponse{}, s.warmupErr
+}
+
+// testWarmupConnectorFor returns a pubsub connector backed by the given in-memory server implementing the warmup rpc.
+func testWarmupConnectorFor(t *testing.T, svc *warmupServer, opts ...Option) *GRPCConnector[proto.PubSubClient] {
+	t.Helper()
+	return testConnectorFor(t, func(s *grpc.Server, svc *warmupServer) {
+		proto.RegisterPubSubServer(s, svc)
+	}, svc, proto.NewPubSubClient, opts...)
+}
+
+func TestWarmup(t *testing.T) {
+	t.Run("the component warmup should be called", func(t *testing.T) {
+		svc := &warmupServer{}
+		connector := testWarmupConnectorFor(t, svc)
+		require.NoError(t, connector.Dial("my-component"))
+
+		connector.Warmup()
+		assert.Equal(t, int64(1), svc.warmupCalled.Load())
+	})
+
+	t.Run("components not implementing the warmup should be skipped", func(t *testing.T) {
+		var buf bytes.Buffer
+		log.SetOutput(&buf)
+		defer log.SetOutput(os.Stdout)
+
+		connector := testPubSubConnectorFor(t, &pingServer{})
+		require.NoError(t, connector.Dial("my-component"))
+
+		connector.Warmup()
+		assert.NotContains(t, buf.String(), "warmup failed")
+	})
+
+	t.Run("warmup failures should be logged as warnings", func(t *testing.T) {
+		var buf bytes.Buffer
+		log.SetOutput(&buf)
+		defer log.SetOutput(os.Stdout)
+
+		svc := &warmupServer{warmupErr: status.Error(codes.Unavailable, "cache backend unreachable")}
+		connector := testWarmupConnectorFor(t, svc)
+		require.NoError(t, connector.Dial("my-component"))
+
+		connector.Warmup()
+		assert.Equal(t, int64(1), svc.warmupCalled.Load())
+		assert.Contains(t, buf.String(), "cache backend unreachable")
+		assert.Contains(t, buf.String(), "warmup failed")
+	})
+
+	t.Run("the component warmup should be bounded by the warmup timeout", func(t *testing.T) {
+		svc := &warmupServer{onWarmup: func(ctx context.Context) { <-ctx.Done() }}
+		connector := testWarmupConnectorFor(t, svc, WithWarmupTimeout(50*time.Millisecond))
+		require.NoError(t, connector.Dial("my-component"))
+
+		start := time.Now()
+		connector.Warmup()
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, int64(1), svc.warmupCalled.Load())
+	})
+}
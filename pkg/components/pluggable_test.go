@@ -0,0 +1,93 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package components_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	componentsV1alpha1 "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/components"
+)
+
+func TestPluggableValidate(t *testing.T) {
+	tests := map[string]struct {
+		pluggable components.Pluggable
+		valid     bool
+	}{
+		"valid":                  {pluggable: components.Pluggable{Type: components.CategoryStateStore, Name: "my-component", Version: "v1"}, valid: true},
+		"valid without version":  {pluggable: components.Pluggable{Type: components.CategoryPubSub, Name: "my_component.2"}, valid: true},
+		"empty type":             {pluggable: components.Pluggable{Name: "my-component"}, valid: false},
+		"type with separator":    {pluggable: components.Pluggable{Type: "state/redis", Name: "my-component"}, valid: false},
+		"empty name":             {pluggable: components.Pluggable{Type: components.CategoryStateStore}, valid: false},
+		"name with separator":    {pluggable: components.Pluggable{Type: components.CategoryStateStore, Name: "../my-component"}, valid: false},
+		"name with spaces":       {pluggable: components.Pluggable{Type: components.CategoryStateStore, Name: "my component"}, valid: false},
+		"name starting with dot": {pluggable: components.Pluggable{Type: components.CategoryStateStore, Name: ".my-component"}, valid: false},
+		"version with separator": {pluggable: components.Pluggable{Type: components.CategoryStateStore, Name: "my-component", Version: "v1/v2"}, valid: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := tc.pluggable.Validate()
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, components.ErrInvalidPluggable)
+			}
+		})
+	}
+}
+
+func TestValidatePluggableComponent(t *testing.T) {
+	componentOf := func(componentType, version string) componentsV1alpha1.Component {
+		return componentsV1alpha1.Component{Spec: componentsV1alpha1.ComponentSpec{Type: componentType, Version: version}}
+	}
+
+	t.Run("valid pluggable components should have no errors", func(t *testing.T) {
+		for _, category := range components.SupportedPluggableTypes() {
+			assert.Empty(t, components.ValidatePluggableComponent(componentOf(string(category)+".my-component", "v1")))
+		}
+	})
+
+	t.Run("unsupported types should return a not supported field error", func(t *testing.T) {
+		errs := components.ValidatePluggableComponent(componentOf("middleware.my-component", "v1"))
+		require.Len(t, errs, 1)
+		assert.Equal(t, field.ErrorTypeNotSupported, errs[0].Type)
+		assert.Equal(t, "spec.type", errs[0].Field)
+	})
+
+	t.Run("malformed names should return an invalid field error", func(t *testing.T) {
+		errs := components.ValidatePluggableComponent(componentOf("state.my/component", "v1"))
+		require.Len(t, errs, 1)
+		assert.Equal(t, field.ErrorTypeInvalid, errs[0].Type)
+		assert.Equal(t, "spec.type", errs[0].Field)
+	})
+
+	t.Run("types without a name should return an invalid field error", func(t *testing.T) {
+		for _, componentType := range []string{"state", "state."} {
+			errs := components.ValidatePluggableComponent(componentOf(componentType, "v1"))
+			require.Len(t, errs, 1, componentType)
+			assert.Equal(t, field.ErrorTypeInvalid, errs[0].Type)
+		}
+	})
+
+	t.Run("malformed versions should return an invalid field error", func(t *testing.T) {
+		errs := components.ValidatePluggableComponent(componentOf("state.my-component", "v1/v2"))
+		require.Len(t, errs, 1)
+		assert.Equal(t, "spec.version", errs[0].Field)
+	})
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+const (
+	// maxBulkSizeMetadataKey is the component metadata key advertising the max number of entries accepted in a single bulk publish.
+	maxBulkSizeMetadataKey = "maxBulkSize"
+	// bulkPublishConcurrencyMetadataKey is the component metadata key used to bound the number of bulk publish chunks sent at the same time.
+	bulkPublishConcurrencyMetadataKey = "bulkPublishConcurrency"
+	// emulatedBulkPublishConcurrency is the max number of publish calls sent at the same time when bulk publish is emulated.
+	emulatedBulkPublishConcurrency = 100
+)
+
+type bulkPublishFn = func(ctx context.Context, entries []pubsub.BulkMessageEntry) (pubsub.BulkPublishResponse, error)
+
+// chunkedBulkPublish splits the given entries in chunks of at most maxBulkSize entries and publishes them using at most concurrency calls at the same time.
+// chunks not sent before the context is done are reported as failed with the context error.
+// failed entries are returned in the original entries order, when every chunk fails the first chunk error is returned along with all entries.
+func chunkedBulkPublish(ctx context.Context, entries []pubsub.BulkMessageEntry, maxBulkSize, concurrency int, publish bulkPublishFn) (pubsub.BulkPublishResponse, error) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+
+	chunks := chunkEntries(entries, maxBulkSize)
+	errs := make([]error, len(chunks))
+	failed := make([]map[string]error, len(chunks))
+
+	sem := make(chan struct{}, concurrency)
+	wg := sync.WaitGroup{}
+	for i, chunk := range chunks {
+		select {
+		case <-ctx.Done():
+			errs[i] = ctx.Err()
+			continue
+		case sem <- struct{}{}:
+		}
+		if err := ctx.Err(); err != nil {
+			<-sem
+			errs[i] = err
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, chunk []pubsub.BulkMessageEntry) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			res, err := publish(ctx, chunk)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			failed[i] = make(map[string]error, len(res.FailedEntries))
+			for _, failedEntry := range res.FailedEntries {
+				failed[i][failedEntry.EntryId] = failedEntry.Error
+			}
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	var (
+		failedEntries []pubsub.BulkPublishResponseFailedEntry
+		firstErr      error
+		allFailed     = true
+	)
+	for i, chunk := range chunks {
+		if errs[i] != nil {
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			for _, entry := range chunk {
+				failedEntries = append(failedEntries, pubsub.BulkPublishResponseFailedEntry{EntryId: entry.EntryId, Error: errs[i]})
+			}
+			continue
+		}
+		allFailed = false
+		for _, entry := range chunk {
+			if err, ok := failed[i][entry.EntryId]; ok {
+				failedEntries = append(failedEntries, pubsub.BulkPublishResponseFailedEntry{EntryId: entry.EntryId, Error: err})
+			}
+		}
+	}
+
+	response := pubsub.BulkPublishResponse{FailedEntries: failedEntries}
+	if allFailed && firstErr != nil {
+		return response, firstErr
+	}
+	return response, nil
+}
+
+// chunkEntries splits the given entries in chunks of at most size entries, a non-positive size means a single chunk.
+func chunkEntries(entries []pubsub.BulkMessageEntry, size int) [][]pubsub.BulkMessageEntry {
+	if size <= 0 || len(entries) <= size {
+		return [][]pubsub.BulkMessageEntry{entries}
+	}
+	chunks := make([][]pubsub.BulkMessageEntry, 0, (len(entries)+size-1)/size)
+	for start := 0; start < len(entries); start += size {
+		end := start + size
+		if end > len(entries) {
+			end = len(entries)
+		}
+		chunks = append(chunks, entries[start:end])
+	}
+	return chunks
+}
+
+// emulatedBulkPublish publishes each of the given entries as a single publish call, for components that don't implement bulk publish.
+// entries are published in parallel so their order is not guaranteed, the failed entries are reported along with the first error.
+func emulatedBulkPublish(ctx context.Context, p pubsub.PubSub, req *pubsub.BulkPublishRequest) (pubsub.BulkPublishResponse, error) {
+	failed := make([]*pubsub.BulkPublishResponseFailedEntry, len(req.Entries))
+
+	var eg errgroup.Group
+	eg.SetLimit(emulatedBulkPublishConcurrency)
+	for i := range req.Entries {
+		i, entry := i, req.Entries[i]
+		eg.Go(func() error {
+			err := p.Publish(ctx, &pubsub.PublishRequest{
+				Data:        entry.Event,
+				PubsubName:  req.PubsubName,
+				Topic:       req.Topic,
+				Metadata:    entry.Metadata,
+				ContentType: &entry.ContentType,
+			})
+			if err != nil {
+				failed[i] = &pubsub.BulkPublishResponseFailedEntry{EntryId: entry.EntryId, Error: err}
+			}
+			return err
+		})
+	}
+	err := eg.Wait()
+
+	failedEntries := make([]pubsub.BulkPublishResponseFailedEntry, 0, len(req.Entries))
+	for _, entry := range failed {
+		if entry != nil {
+			failedEntries = append(failedEntries, *entry)
+		}
+	}
+	return pubsub.BulkPublishResponse{FailedEntries: failedEntries}, err
+}
@@ -0,0 +1,195 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/dapr/components-contrib/pubsub"
+)
+
+func bulkEntries(n int) []pubsub.BulkMessageEntry {
+	entries := make([]pubsub.BulkMessageEntry, n)
+	for i := range entries {
+		entries[i] = pubsub.BulkMessageEntry{EntryId: strconv.Itoa(i), Event: []byte("event")}
+	}
+	return entries
+}
+
+func failedIDs(res pubsub.BulkPublishResponse) []string {
+	ids := make([]string, len(res.FailedEntries))
+	for i, failed := range res.FailedEntries {
+		ids[i] = failed.EntryId
+	}
+	return ids
+}
+
+func TestChunkEntries(t *testing.T) {
+	t.Run("entries should be split in chunks of the given size", func(t *testing.T) {
+		chunks := chunkEntries(bulkEntries(7), 3)
+		require.Len(t, chunks, 3)
+		assert.Len(t, chunks[0], 3)
+		assert.Len(t, chunks[1], 3)
+		assert.Len(t, chunks[2], 1)
+		assert.Equal(t, "6", chunks[2][0].EntryId)
+	})
+
+	t.Run("a non-positive size should return a single chunk", func(t *testing.T) {
+		assert.Len(t, chunkEntries(bulkEntries(7), 0), 1)
+	})
+}
+
+func TestChunkedBulkPublish(t *testing.T) {
+	t.Run("entries should be published in chunks bounded by the max bulk size", func(t *testing.T) {
+		var (
+			lock  sync.Mutex
+			sizes []int
+		)
+		res, err := chunkedBulkPublish(context.Background(), bulkEntries(10), 4, 1, func(_ context.Context, entries []pubsub.BulkMessageEntry) (pubsub.BulkPublishResponse, error) {
+			lock.Lock()
+			defer lock.Unlock()
+			sizes = append(sizes, len(entries))
+			return pubsub.BulkPublishResponse{}, nil
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, res.FailedEntries)
+		assert.Equal(t, []int{4, 4, 2}, sizes)
+	})
+
+	t.Run("chunks should be sent with bounded concurrency", func(t *testing.T) {
+		var inFlight, maxInFlight atomic.Int64
+		release := make(chan struct{})
+		go func() {
+			for i := 0; i < 5; i++ {
+				release <- struct{}{}
+			}
+		}()
+		_, err := chunkedBulkPublish(context.Background(), bulkEntries(10), 2, 2, func(context.Context, []pubsub.BulkMessageEntry) (pubsub.BulkPublishResponse, error) {
+			current := inFlight.Add(1)
+			for {
+				max := maxInFlight.Load()
+				if current <= max || maxInFlight.CompareAndSwap(max, current) {
+					break
+				}
+			}
+			<-release
+			inFlight.Add(-1)
+			return pubsub.BulkPublishResponse{}, nil
+		})
+
+		require.NoError(t, err)
+		assert.LessOrEqual(t, maxInFlight.Load(), int64(2))
+	})
+
+	t.Run("failed entries of all chunks should be reported in the original order", func(t *testing.T) {
+		fakeErr := errors.New("fake-err")
+		res, err := chunkedBulkPublish(context.Background(), bulkEntries(9), 3, 3, func(_ context.Context, entries []pubsub.BulkMessageEntry) (pubsub.BulkPublishResponse, error) {
+			// fail the last entry of each chunk, reporting failures out of order.
+			return pubsub.BulkPublishResponse{FailedEntries: []pubsub.BulkPublishResponseFailedEntry{
+				{EntryId: entries[len(entries)-1].EntryId, Error: fakeErr},
+				{EntryId: entries[0].EntryId, Error: fakeErr},
+			}}, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"0", "2", "3", "5", "6", "8"}, failedIDs(res))
+		for _, failed := range res.FailedEntries {
+			assert.ErrorIs(t, failed.Error, fakeErr)
+		}
+	})
+
+	t.Run("entries of a failed chunk should be reported as failed with the chunk error", func(t *testing.T) {
+		fakeErr := errors.New("fake-err")
+		res, err := chunkedBulkPublish(context.Background(), bulkEntries(6), 2, 1, func(_ context.Context, entries []pubsub.BulkMessageEntry) (pubsub.BulkPublishResponse, error) {
+			if entries[0].EntryId == "2" {
+				return pubsub.BulkPublishResponse{}, fakeErr
+			}
+			return pubsub.BulkPublishResponse{}, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2", "3"}, failedIDs(res))
+		assert.ErrorIs(t, res.FailedEntries[0].Error, fakeErr)
+	})
+
+	t.Run("an error should be returned along with all entries when every chunk fails", func(t *testing.T) {
+		fakeErr := errors.New("fake-err")
+		res, err := chunkedBulkPublish(context.Background(), bulkEntries(4), 2, 1, func(context.Context, []pubsub.BulkMessageEntry) (pubsub.BulkPublishResponse, error) {
+			return pubsub.BulkPublishResponse{}, fakeErr
+		})
+
+		assert.ErrorIs(t, err, fakeErr)
+		assert.Equal(t, []string{"0", "1", "2", "3"}, failedIDs(res))
+	})
+
+	t.Run("chunks not sent before the context is done should be reported as failed", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		res, err := chunkedBulkPublish(ctx, bulkEntries(6), 2, 1, func(_ context.Context, entries []pubsub.BulkMessageEntry) (pubsub.BulkPublishResponse, error) {
+			cancel()
+			return pubsub.BulkPublishResponse{}, nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"2", "3", "4", "5"}, failedIDs(res))
+		assert.ErrorIs(t, res.FailedEntries[0].Error, context.Canceled)
+	})
+}
+
+// publishFailing is a pubsub whose publish fails for the entries with the given data.
+type publishFailing struct {
+	pubsub.PubSub
+	publishCalled atomic.Int64
+	failing       string
+}
+
+func (p *publishFailing) Publish(_ context.Context, req *pubsub.PublishRequest) error {
+	p.publishCalled.Add(1)
+	if string(req.Data) == p.failing {
+		return errors.New("rejected")
+	}
+	return nil
+}
+
+func TestEmulatedBulkPublish(t *testing.T) {
+	t.Run("every entry should be published individually", func(t *testing.T) {
+		ps := &publishFailing{}
+		res, err := emulatedBulkPublish(context.Background(), ps, &pubsub.BulkPublishRequest{Topic: "fakeTopic", Entries: bulkEntries(5)})
+
+		require.NoError(t, err)
+		assert.Empty(t, res.FailedEntries)
+		assert.Equal(t, int64(5), ps.publishCalled.Load())
+	})
+
+	t.Run("failed entries should be reported along with the error", func(t *testing.T) {
+		entries := bulkEntries(4)
+		entries[1].Event, entries[3].Event = []byte("poison"), []byte("poison")
+		ps := &publishFailing{failing: "poison"}
+		res, err := emulatedBulkPublish(context.Background(), ps, &pubsub.BulkPublishRequest{Topic: "fakeTopic", Entries: entries})
+
+		assert.EqualError(t, err, "rejected")
+		assert.Equal(t, []string{"1", "3"}, failedIDs(res))
+		assert.Equal(t, int64(4), ps.publishCalled.Load())
+	})
+}
@@ -15,38 +15,140 @@ package pubsub
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"sync"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/dapr/components-contrib/contenttype"
+	contribMetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
+	"github.com/dapr/dapr/pkg/components"
 	"github.com/dapr/dapr/pkg/components/pluggable"
+	diag "github.com/dapr/dapr/pkg/diagnostics"
 	proto "github.com/dapr/dapr/pkg/proto/components/v1"
 	"github.com/dapr/kit/logger"
 )
 
+const (
+	// FeatureMessageOrdering is the feature advertised by components that honor the message ordering key, the messages sharing the same
+	// ordering key are then handled sequentially by the subscriptions, otherwise the ordering key is ignored.
+	FeatureMessageOrdering pubsub.Feature = "MESSAGE_ORDERING"
+	// orderingKeyMetadataKey is the message metadata key used to carry the message ordering key.
+	orderingKeyMetadataKey = "partitionKey"
+	// maxInFlightMessagesMetadataKey is the subscription metadata key used to limit the number of messages being handled at the same time.
+	maxInFlightMessagesMetadataKey = "maxInFlightMessages"
+)
+
+// mapBulkPublishErrs maps the Unimplemented status of components that don't support bulk publish.
+var mapBulkPublishErrs = pluggable.NewConverterFunc(pluggable.NotSupportedConverter(string(pubsub.FeatureBulkPublish)))
+
+// optimisticFeatures are the features assumed when the component features can't be fetched and the optimistic fallback is set.
+var optimisticFeatures = []string{
+	string(pubsub.FeatureMessageTTL),
+	string(pubsub.FeatureSubscribeWildcards),
+	string(pubsub.FeatureBulkPublish),
+	string(FeatureMessageOrdering),
+}
+
 // grpcPubSub is a implementation of a pubsub over a gRPC Protocol.
 type grpcPubSub struct {
 	*pluggable.GRPCConnector[proto.PubSubClient]
+	// configLock guards the fields adopted from the component init, they are replaced on reinit.
+	configLock sync.RWMutex
 	// features is the list of pubsub implemented features.
 	features []pubsub.Feature
-	logger   logger.Logger
+	// featureSet holds the features reported by the component, used to choose between native and emulated bulk publish.
+	featureSet pluggable.FeatureSet
+	// bulkPublishFallback emulates bulk publish through single publish calls when the component doesn't support it.
+	bulkPublishFallback *pluggable.BulkFallback
+	// maxBulkSize is the max number of entries sent in a single bulk publish, zero means unbounded.
+	maxBulkSize int
+	// bulkPublishConcurrency is the max number of bulk publish chunks sent at the same time.
+	bulkPublishConcurrency int
+	// name is the pubsub component name.
+	name   string
+	logger logger.Logger
+	// newBackOff creates the backoff policy used when re-establishing broken pull streams.
+	newBackOff func() backoff.BackOff
+	// subscriptions holds the active subscriptions by topic.
+	subscriptions     map[string]*subscription
+	subscriptionsLock sync.Mutex
+}
+
+// subscription is an active topic subscription.
+type subscription struct {
+	// ctx is the subscription context, cancelled when the subscription stops.
+	ctx context.Context
+	// cancel stops the subscription.
+	cancel context.CancelFunc
+	// done is closed when the subscription delivery goroutine exits.
+	done chan struct{}
 }
 
 // Init initializes the grpc pubsub passing out the metadata to the grpc component.
 // It also fetches and set the component features.
+// Repeated init calls for the same component instance are no-ops, disabled components are not dialed.
+// The connection is closed when the given context is done before the init completes.
 func (p *grpcPubSub) Init(ctx context.Context, metadata pubsub.Metadata) error {
+	return p.InitOrAbort(ctx, func() error {
+		return p.InitOrDisable(metadata.Name, metadata.Properties, func() error {
+			return p.init(ctx, metadata)
+		})
+	})
+}
+
+// Reinit re-initializes the component with the given metadata over the existing connection.
+// The component features are refreshed only when the component init succeeds,
+// an ErrReinitUnsupported error means that the component must be reconnected instead.
+func (p *grpcPubSub) Reinit(ctx context.Context, metadata pubsub.Metadata) error {
+	return p.ReinitWith(metadata.Name, metadata.Properties, func() error {
+		return p.initComponent(metadata)
+	})
+}
+
+// init dials and initializes the grpc component.
+func (p *grpcPubSub) init(ctx context.Context, metadata pubsub.Metadata) error {
 	if err := p.Dial(metadata.Name); err != nil {
 		return err
 	}
+	p.CaptureInfo()
+	p.name = metadata.Name
+
+	return p.initComponent(metadata)
+}
 
-	protoMetadata := &proto.MetadataRequest{
-		Properties: metadata.Properties,
+// initComponent sends the init request to the component and fetches its features.
+func (p *grpcPubSub) initComponent(metadata pubsub.Metadata) error {
+	maxBulkSize, err := nonNegativeIntOf(metadata.Properties, maxBulkSizeMetadataKey)
+	if err != nil {
+		return err
+	}
+	bulkPublishConcurrency, err := nonNegativeIntOf(metadata.Properties, bulkPublishConcurrencyMetadataKey)
+	if err != nil {
+		return err
+	}
+	if err = p.ValidateMetadata(metadata.Properties, maxBulkSizeMetadataKey, bulkPublishConcurrencyMetadataKey); err != nil {
+		return err
 	}
 
-	_, err := p.Client.Init(p.Context, &proto.PubSubInitRequest{
-		Metadata: protoMetadata,
+	protoMetadata := pluggable.InitMetadata(metadata.Properties)
+
+	err = p.ObserveInit(func() error {
+		resp, initErr := p.Client.Init(p.Context, &proto.PubSubInitRequest{
+			Metadata: protoMetadata,
+		})
+		if initErr == nil {
+			p.ReportInitWarnings(resp.GetWarnings())
+		}
+		return initErr
 	})
 	if err != nil {
 		return err
@@ -54,38 +156,169 @@ func (p *grpcPubSub) Init(ctx context.Context, metadata pubsub.Metadata) error {
 
 	// TODO Static data could be retrieved in another way, a necessary discussion should start soon.
 	// we need to call the method here because features could return an error and the features interface doesn't support errors
-	featureResponse, err := p.Client.Features(p.Context, &proto.FeaturesRequest{})
+	featureResponse, err := p.FetchFeatures(optimisticFeatures...)
 	if err != nil {
 		return err
 	}
 
-	p.features = make([]pubsub.Feature, len(featureResponse.Features))
+	features := make([]pubsub.Feature, len(featureResponse.Features))
 	for idx, f := range featureResponse.Features {
-		p.features[idx] = pubsub.Feature(f)
+		features[idx] = pubsub.Feature(f)
 	}
 
+	p.configLock.Lock()
+	p.features = features
+	p.featureSet = pluggable.NewFeatureSet(featureResponse)
+	p.maxBulkSize = maxBulkSize
+	p.bulkPublishConcurrency = bulkPublishConcurrency
+	p.configLock.Unlock()
+
+	p.Warmup()
 	return nil
 }
 
 // Features lists all implemented features.
 func (p *grpcPubSub) Features() []pubsub.Feature {
+	p.configLock.RLock()
+	defer p.configLock.RUnlock()
 	return p.features
 }
 
+// componentFeatures returns the features reported by the component.
+func (p *grpcPubSub) componentFeatures() pluggable.FeatureSet {
+	p.configLock.RLock()
+	defer p.configLock.RUnlock()
+	return p.featureSet
+}
+
 // Publish publishes data to a topic.
 func (p *grpcPubSub) Publish(ctx context.Context, req *pubsub.PublishRequest) error {
-	_, err := p.Client.Publish(ctx, &proto.PublishRequest{
-		Topic:      req.Topic,
-		PubsubName: req.PubsubName,
-		Data:       req.Data,
-		Metadata:   req.Metadata,
+	rawPayload, err := contribMetadata.IsRawPayload(req.Metadata)
+	if err != nil {
+		return err
+	}
+
+	var attributes map[string]string
+	if !rawPayload {
+		attributes = extensionAttributesOf(req.Data)
+	}
+
+	_, err = p.Client.Publish(ctx, &proto.PublishRequest{
+		Topic:       req.Topic,
+		PubsubName:  req.PubsubName,
+		Data:        req.Data,
+		Metadata:    req.Metadata,
+		OrderingKey: req.Metadata[orderingKeyMetadataKey],
+		RawPayload:  rawPayload,
+		Attributes:  attributes,
 	})
 	return err
 }
 
-func (p *grpcPubSub) BulkPublish(ctx context.Context, req *pubsub.BulkPublishRequest) (pubsub.BulkPublishResponse, error) {
-	entries := make([]*proto.BulkMessageEntry, len(req.Entries))
-	for i, entry := range req.Entries {
+// cloudEventFields are the cloud event context attributes and data fields, every other top-level field is an extension attribute.
+var cloudEventFields = map[string]struct{}{
+	pubsub.SpecVersionField:     {},
+	pubsub.IDField:              {},
+	pubsub.SourceField:          {},
+	pubsub.TypeField:            {},
+	pubsub.DataContentTypeField: {},
+	"dataschema":                {},
+	pubsub.SubjectField:         {},
+	pubsub.TimeField:            {},
+	pubsub.DataField:            {},
+	pubsub.DataBase64Field:      {},
+}
+
+// extensionAttributesOf returns the extension attributes of the given cloud event, non-string values are JSON encoded.
+// it returns nil when the data is not a cloud event or it has no extension attributes.
+func extensionAttributesOf(data []byte) map[string]string {
+	var cloudEvent map[string]json.RawMessage
+	if err := json.Unmarshal(data, &cloudEvent); err != nil {
+		return nil
+	}
+	var attributes map[string]string
+	for field, value := range cloudEvent {
+		if _, ok := cloudEventFields[field]; ok {
+			continue
+		}
+		if attributes == nil {
+			attributes = make(map[string]string)
+		}
+		var str string
+		if err := json.Unmarshal(value, &str); err == nil {
+			attributes[field] = str
+		} else {
+			attributes[field] = string(value)
+		}
+	}
+	return attributes
+}
+
+// withExtensionAttributes returns the given cloud event with the extension attributes it is missing added as strings.
+// the data is returned as is when it is not a cloud event or no attribute is missing.
+func withExtensionAttributes(data []byte, attributes map[string]string) []byte {
+	var cloudEvent map[string]json.RawMessage
+	if err := json.Unmarshal(data, &cloudEvent); err != nil || cloudEvent == nil {
+		return data
+	}
+	missing := false
+	for field, value := range attributes {
+		if _, ok := cloudEventFields[field]; ok {
+			continue
+		}
+		if _, ok := cloudEvent[field]; ok {
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		cloudEvent[field] = encoded
+		missing = true
+	}
+	if !missing {
+		return data
+	}
+	restored, err := json.Marshal(cloudEvent)
+	if err != nil {
+		return data
+	}
+	return restored
+}
+
+// BulkPublish publishes the given entries to a topic.
+// it publishes each entry individually when the component doesn't implement bulk publish.
+func (p *grpcPubSub) BulkPublish(ctx context.Context, req *pubsub.BulkPublishRequest) (res pubsub.BulkPublishResponse, err error) {
+	err = p.bulkPublishFallback.Do(func() (nativeErr error) {
+		res, nativeErr = p.nativeBulkPublish(ctx, req)
+		return nativeErr
+	}, func() (emulatedErr error) {
+		res, emulatedErr = emulatedBulkPublish(ctx, p, req)
+		return emulatedErr
+	})
+	return res, err
+}
+
+// nativeBulkPublish publishes the given entries to a topic using the component bulk publish.
+// requests larger than the component max bulk size are split in chunks and the failed entries of all chunks are aggregated.
+func (p *grpcPubSub) nativeBulkPublish(ctx context.Context, req *pubsub.BulkPublishRequest) (pubsub.BulkPublishResponse, error) {
+	p.configLock.RLock()
+	maxBulkSize, concurrency := p.maxBulkSize, p.bulkPublishConcurrency
+	p.configLock.RUnlock()
+
+	publish := func(ctx context.Context, entries []pubsub.BulkMessageEntry) (pubsub.BulkPublishResponse, error) {
+		return p.bulkPublish(ctx, req, entries)
+	}
+	if maxBulkSize <= 0 || len(req.Entries) <= maxBulkSize {
+		return publish(ctx, req.Entries)
+	}
+	return chunkedBulkPublish(ctx, req.Entries, maxBulkSize, concurrency, publish)
+}
+
+// bulkPublish publishes the given entries of the request in a single call.
+func (p *grpcPubSub) bulkPublish(ctx context.Context, req *pubsub.BulkPublishRequest, reqEntries []pubsub.BulkMessageEntry) (pubsub.BulkPublishResponse, error) {
+	entries := make([]*proto.BulkMessageEntry, len(reqEntries))
+	for i, entry := range reqEntries {
 		entries[i] = &proto.BulkMessageEntry{
 			EntryId:     entry.EntryId,
 			Event:       entry.Event,
@@ -100,7 +333,7 @@ func (p *grpcPubSub) BulkPublish(ctx context.Context, req *pubsub.BulkPublishReq
 		Metadata:   req.Metadata,
 	})
 	if err != nil {
-		return pubsub.BulkPublishResponse{}, err
+		return pubsub.BulkPublishResponse{}, mapBulkPublishErrs(err)
 	}
 
 	failedEntries := make([]pubsub.BulkPublishResponseFailedEntry, len(response.FailedEntries))
@@ -116,21 +349,76 @@ func (p *grpcPubSub) BulkPublish(ctx context.Context, req *pubsub.BulkPublishReq
 
 type messageHandler = func(*proto.PullMessagesResponse)
 
+// toCloudEvent wraps the given raw message data in a cloud event envelope.
+// the message content type, when set, is kept as the cloud event data content type.
+func (p *grpcPubSub) toCloudEvent(msg *pubsub.NewMessage) error {
+	cloudEvent := pubsub.FromRawPayload(msg.Data, msg.Topic, p.name)
+	if msg.ContentType != nil && *msg.ContentType != "" {
+		cloudEvent[pubsub.DataContentTypeField] = *msg.ContentType
+	}
+	data, err := json.Marshal(cloudEvent)
+	if err != nil {
+		return fmt.Errorf("error serializing raw payload as cloud event: %w", err)
+	}
+	contentType := contenttype.CloudEventContentType
+	msg.Data = data
+	msg.ContentType = &contentType
+	return nil
+}
+
+// startMessageSpan starts the span of the given received message, a child of the trace context carried in the message attributes when present.
+// the span is ended when the message is ack'ed, so the whole message lifecycle is a single span.
+func startMessageSpan(ctx context.Context, msg *proto.PullMessagesResponse) (context.Context, trace.Span) {
+	parent, ok := diag.SpanContextFromW3CString(msg.Attributes[pubsub.TraceParentField])
+	if ok {
+		parent = parent.WithTraceState(*diag.TraceStateFromW3CString(msg.Attributes[pubsub.TraceStateField]))
+	}
+	return diag.StartConsumerSpan(ctx, "pubsub/"+msg.TopicName, parent, diag.ConstructSubscriptionSpanAttributes(msg.TopicName))
+}
+
 // adaptHandler returns a non-error function that handle the message with the given handler and ack when returns.
+// raw payload messages are wrapped in a cloud event unless the subscription is raw, which makes the runtime wrap them instead.
+// the message extension attributes missing from the delivered cloud event are restored.
+// messages without content type are delivered with the given subscription default content type.
+// when grantCredits is set every ack grants one more credit to the component, see the PullMessagesRequest credits.
+// every message is traced from its receive to its ack, see startMessageSpan.
 //
 //nolint:nosnakecase
-func (p *grpcPubSub) adaptHandler(ctx context.Context, streamingPull proto.PubSub_PullMessagesClient, handler pubsub.Handler) messageHandler {
+func (p *grpcPubSub) adaptHandler(ctx context.Context, streamingPull proto.PubSub_PullMessagesClient, handler pubsub.Handler, rawSubscription bool, defaultContentType string, grantCredits bool) messageHandler {
 	safeSend := &sync.Mutex{}
 	return func(msg *proto.PullMessagesResponse) {
+		ctx, span := startMessageSpan(ctx, msg)
+		defer span.End()
+
+		contentType := msg.ContentType
+		if contentType == "" {
+			contentType = defaultContentType
+		}
 		m := pubsub.NewMessage{
 			Data:        msg.Data,
-			ContentType: &msg.ContentType,
+			ContentType: &contentType,
 			Topic:       msg.TopicName,
 			Metadata:    msg.Metadata,
 		}
+		if msg.OrderingKey != "" {
+			if m.Metadata == nil {
+				m.Metadata = make(map[string]string, 1)
+			}
+			m.Metadata[orderingKeyMetadataKey] = msg.OrderingKey
+		}
 		var ackError *proto.AckMessageError
 
-		if err := handler(ctx, &m); err != nil {
+		var err error
+		if msg.RawPayload && !rawSubscription {
+			err = p.toCloudEvent(&m)
+		}
+		if err == nil && len(msg.Attributes) > 0 && !(msg.RawPayload && rawSubscription) {
+			m.Data = withExtensionAttributes(m.Data, msg.Attributes)
+		}
+		if err == nil {
+			err = handler(ctx, &m)
+		}
+		if err != nil {
 			p.logger.Errorf("error when handling message on topic %s", msg.TopicName)
 			ackError = &proto.AckMessageError{
 				Message: err.Error(),
@@ -146,59 +434,403 @@ func (p *grpcPubSub) adaptHandler(ctx context.Context, streamingPull proto.PubSu
 		safeSend.Lock()
 		defer safeSend.Unlock()
 
+		var credits uint32
+		if grantCredits {
+			credits = 1
+		}
 		if err := streamingPull.Send(&proto.PullMessagesRequest{
 			AckMessageId: msg.Id,
 			AckError:     ackError,
+			Credits:      credits,
 		}); err != nil {
 			p.logger.Errorf("error when ack'ing message %s from topic %s", msg.Id, msg.TopicName)
+			span.SetStatus(otelcodes.Error, err.Error())
+		} else if ackError != nil {
+			span.SetStatus(otelcodes.Error, ackError.Message)
 		}
 	}
 }
 
-// pullMessages pull messages of the given subscription and execute the handler for that messages.
-func (p *grpcPubSub) pullMessages(ctx context.Context, topic *proto.Topic, handler pubsub.Handler) error {
-	// first pull should be sync and subsequent connections can be made in background if necessary
-	pull, err := p.Client.PullMessages(ctx)
-	if err != nil {
-		return fmt.Errorf("unable to subscribe: %w", err)
+// orderedDispatcher dispatches messages concurrently while preserving the order of messages that share the same ordering key.
+// ordering keys are ignored when the component doesn't advertise the message ordering feature, see FeatureMessageOrdering.
+type orderedDispatcher struct {
+	handle messageHandler
+	// ordered is true when the messages sharing the same ordering key must be handled sequentially.
+	ordered bool
+	mu      sync.Mutex
+	// queues holds the pending messages of each ordering key that is currently being handled.
+	queues map[string][]*proto.PullMessagesResponse
+}
+
+// newOrderedDispatcher creates a new dispatcher for the given handler, preserving the ordering keys order only when ordered is set.
+func newOrderedDispatcher(handle messageHandler, ordered bool) *orderedDispatcher {
+	return &orderedDispatcher{
+		handle:  handle,
+		ordered: ordered,
+		queues:  make(map[string][]*proto.PullMessagesResponse),
+	}
+}
+
+// dispatch handles the message in background, messages without ordering key or not ordered are handled right away.
+func (d *orderedDispatcher) dispatch(msg *proto.PullMessagesResponse) {
+	key := msg.GetOrderingKey()
+	if key == "" || !d.ordered {
+		go d.handle(msg)
+		return
+	}
+
+	d.mu.Lock()
+	queue, draining := d.queues[key]
+	d.queues[key] = append(queue, msg)
+	d.mu.Unlock()
+
+	if !draining {
+		go d.drain(key)
+	}
+}
+
+// drain sequentially handles the pending messages of the given ordering key until there is no more messages.
+func (d *orderedDispatcher) drain(key string) {
+	for {
+		d.mu.Lock()
+		queue := d.queues[key]
+		if len(queue) == 0 {
+			delete(d.queues, key)
+			d.mu.Unlock()
+			return
+		}
+		msg := queue[0]
+		d.queues[key] = queue[1:]
+		d.mu.Unlock()
+
+		d.handle(msg)
+	}
+}
+
+// inFlightLimiter bounds the number of messages being handled at the same time, a nil limiter means unbounded.
+type inFlightLimiter chan struct{}
+
+// newInFlightLimiter creates a new limiter for the given max, zero means unbounded.
+func newInFlightLimiter(max int) inFlightLimiter {
+	if max <= 0 {
+		return nil
+	}
+	return make(inFlightLimiter, max)
+}
+
+// maxInFlightMessagesOf returns the max in-flight messages configured on the subscription metadata, zero means unbounded.
+func maxInFlightMessagesOf(metadata map[string]string) (int, error) {
+	return nonNegativeIntOf(metadata, maxInFlightMessagesMetadataKey)
+}
+
+// nonNegativeIntOf returns the non-negative integer value of the given metadata key, zero when the key is not present.
+func nonNegativeIntOf(metadata map[string]string, key string) (int, error) {
+	value, ok := metadata[key]
+	if !ok {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s value '%s': must be a non-negative integer", key, value)
+	}
+	return n, nil
+}
+
+// available returns the number of free slots, zero when the limiter is unbounded.
+func (l inFlightLimiter) available() uint32 {
+	if l == nil {
+		return 0
+	}
+	return uint32(cap(l) - len(l))
+}
+
+// acquire blocks until a slot is available or the context is cancelled.
+func (l inFlightLimiter) acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	select {
+	case l <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// release releases a previously acquired slot.
+func (l inFlightLimiter) release() {
+	if l != nil {
+		<-l
+	}
+}
+
+// detachedContext keeps the parent context values but it is never cancelled.
+// it is used by pull streams that should outlive their subscription context while draining in-flight messages.
+type detachedContext struct {
+	context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+// waitInFlight waits for the in-flight messages to be handled up to the given timeout, returning false if they were not handled in time.
+func waitInFlight(inFlight *sync.WaitGroup, timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
 	}
+}
 
-	streamCtx, cancel := context.WithCancel(pull.Context())
+// waitRetryAfter waits the redelivery delay suggested by the component for the given message, if any.
+// it returns false when the given context is cancelled before the delay elapses, the message is then not handled nor ack'ed so it can be redelivered.
+func waitRetryAfter(ctx context.Context, msg *proto.PullMessagesResponse) bool {
+	delay := msg.GetRetryAfter().AsDuration()
+	if delay <= 0 {
+		return true
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// openPullStream opens a new pull stream for the given topic and returns a function that receives and dispatches the stream messages.
+// receive blocks until the stream ends, returning nil when there is no more messages or the underlying stream error otherwise.
+// a new message is only received when the limiter has an available slot, which is released after the message is handled and ack'ed.
+// the free slots are granted to the component as credits so that components honoring them slow their stream instead of being buffered.
+// when the subscription buffers messages ahead of its handlers, the handlers limiter bounds the messages being handled at the same time.
+// when the given context is cancelled no new messages are received, but the pulled ones, including the buffered ones, are still delivered
+// and ack'ed within the drain timeout.
+func (p *grpcPubSub) openPullStream(ctx context.Context, topic *proto.Topic, handler pubsub.Handler, limiter, handlers inFlightLimiter) (receive func() error, err error) {
+	streamCtx, cancel := context.WithCancel(detachedContext{ctx})
+	pull, err := p.Client.PullMessages(streamCtx)
+	if err != nil {
+		cancel()
+		return nil, fmt.Errorf("unable to subscribe: %w", err)
+	}
 
 	err = pull.Send(&proto.PullMessagesRequest{
-		Topic: topic,
+		Topic:   topic,
+		Credits: limiter.available(),
 	})
 
-	cleanup := func() {
-		if closeErr := pull.CloseSend(); closeErr != nil {
-			p.logger.Warnf("could not close pull stream of topic %s: %v", topic.Name, closeErr)
+	var (
+		inFlight sync.WaitGroup
+		// draining guards new messages from being dispatched once the in-flight messages are being drained.
+		draining     bool
+		drainingLock sync.Mutex
+	)
+	drainTimeout := p.SubscribeDrainTimeout()
+	drain := func() {
+		drainingLock.Lock()
+		draining = true
+		drainingLock.Unlock()
+		if !waitInFlight(&inFlight, drainTimeout) {
+			p.logger.Warnf("in-flight messages of topic %s were not handled within the drain timeout of %s", topic.Name, drainTimeout)
 		}
-		cancel()
+	}
+
+	var cleanupOnce sync.Once
+	cleanup := func() {
+		cleanupOnce.Do(func() {
+			if ctx.Err() != nil {
+				drain()
+			}
+			if closeErr := pull.CloseSend(); closeErr != nil {
+				p.logger.Warnf("could not close pull stream of topic %s: %v", topic.Name, closeErr)
+			}
+			cancel()
+		})
 	}
 
 	if err != nil {
 		cleanup()
-		return fmt.Errorf("unable to subscribe: %w", err)
+		return nil, fmt.Errorf("unable to subscribe: %w", err)
 	}
 
-	handle := p.adaptHandler(streamCtx, pull, handler)
+	// stops receiving once the subscription is cancelled and the in-flight messages are drained.
 	go func() {
+		select {
+		case <-ctx.Done():
+			cleanup()
+		case <-streamCtx.Done():
+		}
+	}()
+
+	rawSubscription, _ := contribMetadata.IsRawPayload(topic.Metadata)
+	handle := p.adaptHandler(streamCtx, pull, handler, rawSubscription, topic.Metadata[contribMetadata.ContentType], limiter != nil)
+	// the pulled messages, either queued by the dispatcher or buffered waiting for a handler, are still dispatched while draining:
+	// they wait on the stream context that is only cancelled once drained or when the drain timeout expires.
+	dispatcher := newOrderedDispatcher(func(msg *proto.PullMessagesResponse) {
+		defer inFlight.Done()
+		defer limiter.release()
+		if !waitRetryAfter(streamCtx, msg) {
+			p.logger.Debugf("dropping delayed message %s from topic %s as the subscription drain timed out", msg.Id, msg.TopicName)
+			return
+		}
+		if err := handlers.acquire(streamCtx); err != nil {
+			p.logger.Debugf("dropping buffered message %s from topic %s as the subscription drain timed out", msg.Id, msg.TopicName)
+			return
+		}
+		defer handlers.release()
+		handle(msg)
+	}, p.componentFeatures().Has(string(FeatureMessageOrdering)))
+	return func() error {
 		defer cleanup()
 		for {
+			if err := limiter.acquire(ctx); err != nil {
+				return err
+			}
+
 			msg, err := pull.Recv()
 			if err == io.EOF { // no more messages
-				return
+				limiter.release()
+				return nil
 			}
 
-			// TODO reconnect on error
 			if err != nil {
-				p.logger.Errorf("failed to receive message: %v", err)
-				return
+				limiter.release()
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return err
 			}
 
+			drainingLock.Lock()
+			if draining || ctx.Err() != nil { // the subscription is stopping, the message is not ack'ed so it can be redelivered.
+				drainingLock.Unlock()
+				limiter.release()
+				p.logger.Debugf("dropping message %s received from topic %s while draining", msg.Id, msg.TopicName)
+				return ctx.Err()
+			}
+			inFlight.Add(1)
+			drainingLock.Unlock()
+
 			p.logger.Debugf("received message from stream on topic %s", msg.TopicName)
 
-			go handle(msg)
+			dispatcher.dispatch(msg)
+		}
+	}, nil
+}
+
+// reopenPullStream re-establishes the pull stream of the given topic, retrying with backoff until it succeeds, the context is cancelled
+// or the connector retry budget is exhausted.
+func (p *grpcPubSub) reopenPullStream(ctx context.Context, topic *proto.Topic, handler pubsub.Handler, limiter, handlers inFlightLimiter) (receive func() error, err error) {
+	err = backoff.RetryNotify(func() error {
+		p.logger.Infof("re-establishing pull stream of topic %s", topic.Name)
+		var openErr error
+		receive, openErr = p.openPullStream(ctx, topic, handler, limiter, handlers)
+		return openErr
+	}, backoff.WithContext(p.BudgetedBackOff(p.newBackOff()), ctx), func(err error, d time.Duration) {
+		p.logger.Warnf("could not re-establish pull stream of topic %s, retrying in %s: %v", topic.Name, d, err)
+	})
+	return receive, err
+}
+
+// addSubscription registers a new subscription for the given topic returning its context.
+// it fails if there is an active subscription for the same topic, stopping subscriptions are replaced.
+func (p *grpcPubSub) addSubscription(ctx context.Context, topic string) (*subscription, error) {
+	p.subscriptionsLock.Lock()
+	defer p.subscriptionsLock.Unlock()
+
+	if current, ok := p.subscriptions[topic]; ok && current.ctx.Err() == nil {
+		return nil, fmt.Errorf("topic %s is already subscribed", topic)
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	sub := &subscription{
+		ctx:    subCtx,
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	p.subscriptions[topic] = sub
+	return sub, nil
+}
+
+// removeSubscription stops and removes the given subscription, if it is still the active one.
+func (p *grpcPubSub) removeSubscription(topic string, sub *subscription) {
+	sub.cancel()
+
+	p.subscriptionsLock.Lock()
+	defer p.subscriptionsLock.Unlock()
+	if p.subscriptions[topic] == sub {
+		delete(p.subscriptions, topic)
+	}
+}
+
+// Unsubscribe stops the subscription of the given topic and waits for its delivery goroutine to exit.
+// Other topics subscriptions and the underlying connection are kept as is.
+func (p *grpcPubSub) Unsubscribe(topic string) error {
+	p.subscriptionsLock.Lock()
+	sub, ok := p.subscriptions[topic]
+	p.subscriptionsLock.Unlock()
+
+	if !ok {
+		return fmt.Errorf("topic %s is not subscribed", topic)
+	}
+
+	sub.cancel()
+	<-sub.done
+	return nil
+}
+
+// pullMessages pull messages of the given subscription and execute the handler for that messages.
+// the stream is re-established in case of errors until the given context is cancelled or the topic is unsubscribed.
+// the in-flight messages limit is shared across re-established streams of the same subscription.
+// limited subscriptions receive up to the connector subscribe buffer messages ahead of their handlers, see pluggable.WithSubscribeBuffer.
+func (p *grpcPubSub) pullMessages(parentCtx context.Context, topic *proto.Topic, handler pubsub.Handler) error {
+	maxInFlight, err := maxInFlightMessagesOf(topic.Metadata)
+	if err != nil {
+		return err
+	}
+	var limiter, handlers inFlightLimiter
+	if buffer := p.SubscribeBuffer(); maxInFlight > 0 && buffer > 0 {
+		limiter, handlers = newInFlightLimiter(maxInFlight+buffer), newInFlightLimiter(maxInFlight)
+	} else {
+		limiter = newInFlightLimiter(maxInFlight)
+	}
+
+	sub, err := p.addSubscription(parentCtx, topic.Name)
+	if err != nil {
+		return err
+	}
+	ctx := sub.ctx
+
+	// first pull should be sync and subsequent connections can be made in background if necessary
+	receive, err := p.openPullStream(ctx, topic, handler, limiter, handlers)
+	if err != nil {
+		p.removeSubscription(topic.Name, sub)
+		close(sub.done)
+		return err
+	}
+
+	go func() {
+		defer close(sub.done)
+		defer p.removeSubscription(topic.Name, sub)
+		for {
+			err := receive()
+			if err == nil || ctx.Err() != nil {
+				return
+			}
+
+			p.logger.Errorf("failed to receive message from topic %s: %v", topic.Name, err)
+
+			if receive, err = p.reopenPullStream(ctx, topic, handler, limiter, handlers); err != nil {
+				p.logger.Errorf("giving up on pull stream of topic %s: %v", topic.Name, err)
+				return
+			}
 		}
 	}()
 
@@ -214,13 +846,24 @@ func (p *grpcPubSub) Subscribe(ctx context.Context, req pubsub.SubscribeRequest,
 	return p.pullMessages(ctx, subscription, handler)
 }
 
+// newReconnectBackOff returns an exponential backoff that never stops retrying by itself, the subscription context is used instead.
+func newReconnectBackOff() backoff.BackOff {
+	bo := backoff.NewExponentialBackOff()
+	bo.MaxElapsedTime = 0
+	return bo
+}
+
 // fromConnector creates a new GRPC pubsub using the given underlying connector.
 func fromConnector(l logger.Logger, connector *pluggable.GRPCConnector[proto.PubSubClient]) *grpcPubSub {
-	return &grpcPubSub{
+	p := &grpcPubSub{
 		features:      make([]pubsub.Feature, 0),
 		GRPCConnector: connector,
 		logger:        l,
+		newBackOff:    newReconnectBackOff,
+		subscriptions: make(map[string]*subscription),
 	}
+	p.bulkPublishFallback = pluggable.NewBulkFallback(string(pubsub.FeatureBulkPublish), p.componentFeatures)
+	return p
 }
 
 // NewGRPCPubSub creates a new grpc pubsub using the given socket factory.
@@ -229,15 +872,15 @@ func NewGRPCPubSub(l logger.Logger, socket string) *grpcPubSub {
 }
 
 // newGRPCPubSub creates a new grpc pubsub for the given pluggable component.
-func newGRPCPubSub(dialer pluggable.GRPCConnectionDialer) func(l logger.Logger) pubsub.PubSub {
+func newGRPCPubSub(dialer pluggable.GRPCConnectionDialer, opts ...pluggable.Option) func(l logger.Logger) pubsub.PubSub {
 	return func(l logger.Logger) pubsub.PubSub {
-		return fromConnector(l, pluggable.NewGRPCConnectorWithDialer(dialer, proto.NewPubSubClient))
+		return fromConnector(l, pluggable.NewGRPCConnectorWithDialer(dialer, proto.NewPubSubClient, opts...))
 	}
 }
 
 func init() {
 	//nolint:nosnakecase
 	pluggable.AddServiceDiscoveryCallback(proto.PubSub_ServiceDesc.ServiceName, func(name string, dialer pluggable.GRPCConnectionDialer) {
-		DefaultRegistry.RegisterComponent(newGRPCPubSub(dialer), name)
+		DefaultRegistry.RegisterComponent(newGRPCPubSub(dialer, pluggable.WithPluggable(components.Pluggable{Type: components.CategoryPubSub, Name: name})), name)
 	})
 }
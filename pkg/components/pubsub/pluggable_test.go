@@ -15,20 +15,34 @@ package pubsub
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"os"
 	"runtime"
+	"strconv"
 	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/cenkalti/backoff/v4"
 	guuid "github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
+	"github.com/dapr/components-contrib/contenttype"
 	contribMetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/dapr/pkg/components/pluggable"
@@ -41,25 +55,35 @@ var testLogger = logger.NewLogger("pubsub-pluggable-test")
 
 type server struct {
 	proto.UnimplementedPubSubServer
-	initCalled      atomic.Int64
-	onInitCalled    func(*proto.PubSubInitRequest)
-	initErr         error
-	featuresCalled  atomic.Int64
-	featuresErr     error
-	publishCalled   atomic.Int64
-	onPublishCalled func(*proto.PublishRequest)
-	publishErr      error
-	pullChan        chan *proto.PullMessagesResponse
-	pingCalled      atomic.Int64
-	pingErr         error
-	onAckReceived   func(*proto.PullMessagesRequest)
-	pullCalled      atomic.Int64
-	pullErr         error
+	initCalled          atomic.Int64
+	onInitCalled        func(*proto.PubSubInitRequest)
+	initErr             error
+	initWarnings        []string
+	featuresCalled      atomic.Int64
+	featuresErr         error
+	publishCalled       atomic.Int64
+	onPublishCalled     func(*proto.PublishRequest)
+	publishErr          error
+	bulkPublishCalled   atomic.Int64
+	onBulkPublishCalled func(*proto.BulkPublishRequest) *proto.BulkPublishResponse
+	bulkPublishErr      error
+	pullChan            chan *proto.PullMessagesResponse
+	pingCalled          atomic.Int64
+	pingErr             error
+	onAckReceived       func(*proto.PullMessagesRequest)
+	pullCalled          atomic.Int64
+	pullErr             error
+	onPullCalled        func() error
 }
 
 //nolint:nosnakecase
 func (s *server) PullMessages(svc proto.PubSub_PullMessagesServer) error {
 	s.pullCalled.Add(1)
+	if s.onPullCalled != nil {
+		if err := s.onPullCalled(); err != nil {
+			return err
+		}
+	}
 
 	if s.onAckReceived != nil {
 		go func() {
@@ -88,7 +112,7 @@ func (s *server) Init(_ context.Context, req *proto.PubSubInitRequest) (*proto.P
 	if s.onInitCalled != nil {
 		s.onInitCalled(req)
 	}
-	return &proto.PubSubInitResponse{}, s.initErr
+	return &proto.PubSubInitResponse{Warnings: s.initWarnings}, s.initErr
 }
 
 func (s *server) Features(context.Context, *proto.FeaturesRequest) (*proto.FeaturesResponse, error) {
@@ -96,6 +120,20 @@ func (s *server) Features(context.Context, *proto.FeaturesRequest) (*proto.Featu
 	return &proto.FeaturesResponse{}, s.featuresErr
 }
 
+// warmupServer is a pubsub server implementing the optional warmup rpc.
+type warmupServer struct {
+	*server
+	// initCalledOnWarmup is the number of init calls received when the warmup was called.
+	initCalledOnWarmup atomic.Int64
+	warmupCalled       atomic.Int64
+}
+
+func (s *warmupServer) Warmup(context.Context, *proto.WarmupRequest) (*proto.WarmupResponse, error) {
+	s.warmupCalled.Add(1)
+	s.initCalledOnWarmup.Store(s.initCalled.Load())
+	return &proto.WarmupResponse{}, nil
+}
+
 func (s *server) Publish(_ context.Context, req *proto.PublishRequest) (*proto.PublishResponse, error) {
 	s.publishCalled.Add(1)
 	if s.onPublishCalled != nil {
@@ -104,11 +142,109 @@ func (s *server) Publish(_ context.Context, req *proto.PublishRequest) (*proto.P
 	return &proto.PublishResponse{}, s.publishErr
 }
 
+func (s *server) BulkPublish(_ context.Context, req *proto.BulkPublishRequest) (*proto.BulkPublishResponse, error) {
+	s.bulkPublishCalled.Add(1)
+	if s.bulkPublishErr != nil {
+		return nil, s.bulkPublishErr
+	}
+	if s.onBulkPublishCalled != nil {
+		return s.onBulkPublishCalled(req), nil
+	}
+	return &proto.BulkPublishResponse{}, nil
+}
+
 func (s *server) Ping(context.Context, *proto.PingRequest) (*proto.PingResponse, error) {
 	s.pingCalled.Add(1)
 	return &proto.PingResponse{}, s.pingErr
 }
 
+// creditsServer is a fake server that honors the credits granted by the runtime, it sends the given number of messages
+// without exceeding the available credits.
+type creditsServer struct {
+	proto.UnimplementedPubSubServer
+	total          int
+	topic          string
+	initialCredits atomic.Uint32
+	// maxOutstanding is the max number of messages sent and not ack'ed at the same time.
+	maxOutstanding atomic.Int64
+}
+
+//nolint:nosnakecase
+func (s *creditsServer) PullMessages(svc proto.PubSub_PullMessagesServer) error {
+	first, err := svc.Recv()
+	if err != nil {
+		return err
+	}
+	s.initialCredits.Store(first.Credits)
+
+	credits := make(chan struct{}, s.total)
+	for i := uint32(0); i < first.Credits; i++ {
+		credits <- struct{}{}
+	}
+	var outstanding atomic.Int64
+	go func() {
+		for {
+			req, err := svc.Recv()
+			if err != nil {
+				return
+			}
+			outstanding.Add(-1)
+			for i := uint32(0); i < req.Credits; i++ {
+				credits <- struct{}{}
+			}
+		}
+	}()
+
+	for idx := 0; idx < s.total; idx++ {
+		select {
+		case <-credits:
+		case <-svc.Context().Done():
+			return nil
+		}
+		current := outstanding.Add(1)
+		if current > s.maxOutstanding.Load() {
+			s.maxOutstanding.Store(current)
+		}
+		if err := svc.Send(&proto.PullMessagesResponse{Id: strconv.Itoa(idx), Data: []byte("data"), TopicName: s.topic}); err != nil {
+			return err
+		}
+	}
+	<-svc.Context().Done()
+	return nil
+}
+
+// topicsServer is a fake server that streams messages from a dedicated channel for each subscribed topic.
+type topicsServer struct {
+	proto.UnimplementedPubSubServer
+	topics map[string]chan *proto.PullMessagesResponse
+}
+
+//nolint:nosnakecase
+func (s *topicsServer) PullMessages(svc proto.PubSub_PullMessagesServer) error {
+	req, err := svc.Recv()
+	if err != nil {
+		return err
+	}
+	messages := s.topics[req.Topic.Name]
+	go func() { // discarding acks
+		for {
+			if _, err := svc.Recv(); err != nil {
+				return
+			}
+		}
+	}()
+	for {
+		select {
+		case msg := <-messages:
+			if err := svc.Send(msg); err != nil {
+				return err
+			}
+		case <-svc.Context().Done():
+			return nil
+		}
+	}
+}
+
 func TestPubSubPluggableCalls(t *testing.T) {
 	getPubSub := testingGrpc.TestServerFor(testLogger, func(s *grpc.Server, svc *server) {
 		proto.RegisterPubSubServer(s, svc)
@@ -159,6 +295,80 @@ func TestPubSubPluggableCalls(t *testing.T) {
 		})
 	}
 
+	t.Run("init warnings should be captured in the component status", func(t *testing.T) {
+		ps, cleanup, err := getPubSub(&server{
+			initWarnings: []string{"TLS not configured, using plaintext"},
+		})
+		require.NoError(t, err)
+		defer cleanup()
+
+		require.NoError(t, ps.initComponent(pubsub.Metadata{}))
+		assert.Equal(t, []string{"TLS not configured, using plaintext"}, ps.Status().InitWarnings)
+	})
+
+	t.Run("init should warm the component up once initialized", func(t *testing.T) {
+		svc := &warmupServer{server: &server{}}
+		ps, cleanup, err := testingGrpc.TestServerFor(testLogger, func(s *grpc.Server, svc *warmupServer) {
+			proto.RegisterPubSubServer(s, svc)
+		}, func(cci grpc.ClientConnInterface) *grpcPubSub {
+			ps := fromConnector(testLogger, pluggable.NewGRPCConnector("/tmp/socket.sock", proto.NewPubSubClient))
+			ps.Client = proto.NewPubSubClient(cci)
+			return ps
+		})(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		require.NoError(t, ps.initComponent(pubsub.Metadata{}))
+		assert.Equal(t, int64(1), svc.warmupCalled.Load())
+		assert.Equal(t, int64(1), svc.initCalledOnWarmup.Load())
+	})
+
+	t.Run("init should tolerate components not implementing the warmup", func(t *testing.T) {
+		svc := &server{}
+		ps, cleanup, err := getPubSub(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		require.NoError(t, ps.initComponent(pubsub.Metadata{}))
+		assert.Equal(t, int64(1), svc.initCalled.Load())
+	})
+
+	t.Run("init should assume no feature when the features call keeps failing", func(t *testing.T) {
+		svc := &server{featuresErr: status.Error(codes.Unavailable, "component restarting")}
+		ps, cleanup, err := testingGrpc.TestServerFor(testLogger, func(s *grpc.Server, svc *server) {
+			proto.RegisterPubSubServer(s, svc)
+		}, func(cci grpc.ClientConnInterface) *grpcPubSub {
+			ps := fromConnector(testLogger, pluggable.NewGRPCConnector("/tmp/socket.sock", proto.NewPubSubClient, pluggable.WithFeaturesRetries(2, time.Millisecond)))
+			ps.Client = proto.NewPubSubClient(cci)
+			return ps
+		})(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		require.NoError(t, ps.initComponent(pubsub.Metadata{}))
+		assert.Equal(t, int64(3), svc.featuresCalled.Load())
+		assert.Empty(t, ps.Features())
+	})
+
+	t.Run("init should assume every feature with the optimistic fallback when the features call keeps failing", func(t *testing.T) {
+		svc := &server{featuresErr: status.Error(codes.Unavailable, "component restarting")}
+		ps, cleanup, err := testingGrpc.TestServerFor(testLogger, func(s *grpc.Server, svc *server) {
+			proto.RegisterPubSubServer(s, svc)
+		}, func(cci grpc.ClientConnInterface) *grpcPubSub {
+			ps := fromConnector(testLogger, pluggable.NewGRPCConnector("/tmp/socket.sock", proto.NewPubSubClient,
+				pluggable.WithFeaturesRetries(2, time.Millisecond), pluggable.WithFeaturesFallback(pluggable.FeaturesFallbackOptimistic)))
+			ps.Client = proto.NewPubSubClient(cci)
+			return ps
+		})(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		require.NoError(t, ps.initComponent(pubsub.Metadata{}))
+		assert.Equal(t, int64(3), svc.featuresCalled.Load())
+		assert.Contains(t, ps.Features(), pubsub.FeatureBulkPublish)
+		assert.True(t, ps.componentFeatures().Has(string(pubsub.FeatureBulkPublish)))
+	})
+
 	t.Run("features should return the component features'", func(t *testing.T) {
 		ps, cleanup, err := getPubSub(&server{})
 		require.NoError(t, err)
@@ -210,76 +420,1213 @@ func TestPubSubPluggableCalls(t *testing.T) {
 		assert.Equal(t, int64(1), svc.publishCalled.Load())
 	})
 
-	t.Run("subscribe should callback handler when new messages arrive", func(t *testing.T) {
-		const fakeTopic, fakeData1, fakeData2 = "fakeTopic", "fakeData1", "fakeData2"
-		var (
-			messagesAcked     sync.WaitGroup
-			topicSent         sync.WaitGroup
-			messagesProcessed sync.WaitGroup
-			totalAckErrors    atomic.Int64
-			handleCalled      atomic.Int64
-		)
+	t.Run("bulk publish should split the entries by the component max bulk size", func(t *testing.T) {
+		svc := &server{
+			onBulkPublishCalled: func(req *proto.BulkPublishRequest) *proto.BulkPublishResponse {
+				assert.LessOrEqual(t, len(req.Entries), 2)
+				// entries are rejected by id on the component side.
+				resp := &proto.BulkPublishResponse{}
+				for _, entry := range req.Entries {
+					if entry.EntryId == "1" || entry.EntryId == "4" {
+						resp.FailedEntries = append(resp.FailedEntries, &proto.BulkPublishResponseFailedEntry{EntryId: entry.EntryId, Error: "rejected"})
+					}
+				}
+				return resp
+			},
+		}
+		ps, cleanup, err := getPubSub(svc)
+		require.NoError(t, err)
+		defer cleanup()
+		ps.maxBulkSize = 2
 
-		messagesData := [][]byte{[]byte(fakeData1), []byte(fakeData2)}
-		messages := make([]*proto.PullMessagesResponse, len(messagesData))
+		entries := make([]pubsub.BulkMessageEntry, 5)
+		for i := range entries {
+			entries[i] = pubsub.BulkMessageEntry{EntryId: strconv.Itoa(i), Event: []byte("event")}
+		}
+		res, err := ps.BulkPublish(context.Background(), &pubsub.BulkPublishRequest{
+			Topic:   "fakeTopic",
+			Entries: entries,
+		})
 
-		messagesAcked.Add(len(messages))
-		messagesProcessed.Add(len(messages))
-		topicSent.Add(1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), svc.bulkPublishCalled.Load())
+		require.Len(t, res.FailedEntries, 2)
+		assert.Equal(t, "1", res.FailedEntries[0].EntryId)
+		assert.Equal(t, "4", res.FailedEntries[1].EntryId)
+		assert.EqualError(t, res.FailedEntries[0].Error, "rejected")
+	})
 
-		for idx, data := range messagesData {
-			messages[idx] = &proto.PullMessagesResponse{
-				Data:        data,
-				TopicName:   fakeTopic,
-				Metadata:    map[string]string{},
-				ContentType: "",
-			}
+	t.Run("bulk publish should be emulated through publish when the component doesn't implement it", func(t *testing.T) {
+		svc := &server{
+			bulkPublishErr: status.Error(codes.Unimplemented, "method BulkPublish not implemented"),
 		}
+		ps, cleanup, err := getPubSub(svc)
+		require.NoError(t, err)
+		defer cleanup()
 
-		messageChan := make(chan *proto.PullMessagesResponse, len(messages))
-		defer close(messageChan)
+		req := &pubsub.BulkPublishRequest{
+			Topic: "fakeTopic",
+			Entries: []pubsub.BulkMessageEntry{
+				{EntryId: "1", Event: []byte("event")},
+				{EntryId: "2", Event: []byte("event")},
+			},
+		}
+		res, err := ps.BulkPublish(context.Background(), req)
+		require.NoError(t, err)
+		assert.Empty(t, res.FailedEntries)
+		assert.Equal(t, int64(2), svc.publishCalled.Load())
 
-		for _, message := range messages {
-			messageChan <- message
+		// the native bulk publish is not tried again once known to be unimplemented.
+		_, err = ps.BulkPublish(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), svc.bulkPublishCalled.Load())
+		assert.Equal(t, int64(4), svc.publishCalled.Load())
+	})
+
+	t.Run("publish should send the ordering key from the message metadata", func(t *testing.T) {
+		const fakeTopic, fakeOrderingKey = "fakeTopic", "fakeOrderingKey"
+
+		svc := &server{
+			onPublishCalled: func(req *proto.PublishRequest) {
+				assert.Equal(t, fakeOrderingKey, req.OrderingKey)
+			},
 		}
+		ps, cleanup, err := getPubSub(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		err = ps.Publish(context.Background(), &pubsub.PublishRequest{
+			Topic: fakeTopic,
+			Metadata: map[string]string{
+				orderingKeyMetadataKey: fakeOrderingKey,
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), svc.publishCalled.Load())
+	})
+
+	t.Run("publish should send the raw payload flag", func(t *testing.T) {
+		rawData := []byte{0xde, 0xad, 0xbe, 0xef}
 
 		svc := &server{
-			pullChan: messageChan,
-			onAckReceived: func(ma *proto.PullMessagesRequest) {
-				if ma.Topic != nil {
-					topicSent.Done()
-				} else {
-					messagesAcked.Done()
-				}
-				if ma.AckError != nil {
-					totalAckErrors.Add(1)
-				}
+			onPublishCalled: func(req *proto.PublishRequest) {
+				assert.True(t, req.RawPayload)
+				assert.Equal(t, rawData, req.Data)
 			},
 		}
+		ps, cleanup, err := getPubSub(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		err = ps.Publish(context.Background(), &pubsub.PublishRequest{
+			Topic: "fakeTopic",
+			Data:  rawData,
+			Metadata: map[string]string{
+				contribMetadata.RawPayloadKey: "true",
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), svc.publishCalled.Load())
+	})
 
+	t.Run("publish should return an error when the raw payload flag is invalid", func(t *testing.T) {
+		svc := &server{}
 		ps, cleanup, err := getPubSub(svc)
 		require.NoError(t, err)
 		defer cleanup()
 
-		handleErrors := make(chan error, 1) // simulating an ack error
-		handleErrors <- errors.New("fake-error")
-		close(handleErrors)
+		err = ps.Publish(context.Background(), &pubsub.PublishRequest{
+			Topic: "fakeTopic",
+			Metadata: map[string]string{
+				contribMetadata.RawPayloadKey: "not-a-bool",
+			},
+		})
+
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), svc.publishCalled.Load())
+	})
+
+	t.Run("subscribe should deliver raw payloads according to the subscription", func(t *testing.T) {
+		const fakeTopic = "fakeTopic"
+		rawData := []byte{0xde, 0xad, 0xbe, 0xef}
+
+		subscribe := func(t *testing.T, metadata map[string]string) *pubsub.NewMessage {
+			messageChan := make(chan *proto.PullMessagesResponse, 1)
+			defer close(messageChan)
+			messageChan <- &proto.PullMessagesResponse{
+				Data:       rawData,
+				TopicName:  fakeTopic,
+				RawPayload: true,
+			}
+
+			ps, cleanup, err := getPubSub(&server{
+				pullChan: messageChan,
+			})
+			require.NoError(t, err)
+			defer cleanup()
+
+			received := make(chan *pubsub.NewMessage, 1)
+			err = ps.Subscribe(context.Background(), pubsub.SubscribeRequest{
+				Topic:    fakeTopic,
+				Metadata: metadata,
+			}, func(_ context.Context, m *pubsub.NewMessage) error {
+				received <- m
+				return nil
+			})
+			require.NoError(t, err)
+
+			select {
+			case m := <-received:
+				return m
+			case <-time.After(time.Second):
+				require.Fail(t, "message was not received")
+				return nil
+			}
+		}
+
+		t.Run("raw payloads should be wrapped in a cloud event for non-raw subscriptions", func(t *testing.T) {
+			m := subscribe(t, nil)
+
+			assert.Equal(t, contenttype.CloudEventContentType, *m.ContentType)
+			var cloudEvent map[string]any
+			require.NoError(t, json.Unmarshal(m.Data, &cloudEvent))
+			assert.Equal(t, base64.StdEncoding.EncodeToString(rawData), cloudEvent[pubsub.DataBase64Field])
+			assert.Equal(t, fakeTopic, cloudEvent[pubsub.TopicField])
+		})
+
+		t.Run("raw payloads should be delivered as is for raw subscriptions", func(t *testing.T) {
+			m := subscribe(t, map[string]string{
+				contribMetadata.RawPayloadKey: "true",
+			})
+
+			assert.Equal(t, rawData, m.Data)
+		})
+	})
+
+	t.Run("cloud event extension attributes should round trip through publish and subscribe", func(t *testing.T) {
+		const fakeTopic = "fakeTopic"
+		cloudEvent, err := json.Marshal(map[string]any{
+			pubsub.SpecVersionField: "1.0",
+			pubsub.IDField:          "fake-id",
+			pubsub.SourceField:      "fake-source",
+			pubsub.TypeField:        "fake-type",
+			pubsub.DataField:        "fake-data",
+			"myextension":           "fake-extension",
+			"mycount":               3,
+		})
+		require.NoError(t, err)
+
+		published := make(chan *proto.PublishRequest, 1)
+		publisher, cleanup, err := getPubSub(&server{
+			onPublishCalled: func(req *proto.PublishRequest) {
+				published <- req
+			},
+		})
+		require.NoError(t, err)
+		defer cleanup()
+
+		require.NoError(t, publisher.Publish(context.Background(), &pubsub.PublishRequest{
+			Topic: fakeTopic,
+			Data:  cloudEvent,
+		}))
+		req := <-published
+		assert.Equal(t, map[string]string{"myextension": "fake-extension", "mycount": "3"}, req.Attributes)
+
+		deliver := func(t *testing.T, msg *proto.PullMessagesResponse) map[string]any {
+			messageChan := make(chan *proto.PullMessagesResponse, 1)
+			defer close(messageChan)
+			messageChan <- msg
+
+			ps, cleanup, err := getPubSub(&server{
+				pullChan: messageChan,
+			})
+			require.NoError(t, err)
+			defer cleanup()
+
+			received := make(chan *pubsub.NewMessage, 1)
+			require.NoError(t, ps.Subscribe(context.Background(), pubsub.SubscribeRequest{
+				Topic: fakeTopic,
+			}, func(_ context.Context, m *pubsub.NewMessage) error {
+				received <- m
+				return nil
+			}))
+
+			select {
+			case m := <-received:
+				var delivered map[string]any
+				require.NoError(t, json.Unmarshal(m.Data, &delivered))
+				return delivered
+			case <-time.After(time.Second):
+				require.Fail(t, "message was not received")
+				return nil
+			}
+		}
+
+		t.Run("the attributes should be restored in the cloud event wrapping a raw payload", func(t *testing.T) {
+			delivered := deliver(t, &proto.PullMessagesResponse{
+				Data:       []byte("fake-data"),
+				TopicName:  fakeTopic,
+				RawPayload: true,
+				Attributes: req.Attributes,
+			})
+
+			assert.Equal(t, "fake-extension", delivered["myextension"])
+			assert.Equal(t, "3", delivered["mycount"])
+		})
+
+		t.Run("the attributes present in the delivered cloud event should be kept as is", func(t *testing.T) {
+			delivered := deliver(t, &proto.PullMessagesResponse{
+				Data:       req.Data,
+				TopicName:  fakeTopic,
+				Attributes: req.Attributes,
+			})
+
+			assert.Equal(t, "fake-extension", delivered["myextension"])
+			assert.Equal(t, float64(3), delivered["mycount"])
+			assert.Equal(t, "fake-id", delivered[pubsub.IDField])
+		})
+	})
+
+	t.Run("publish should not send extension attributes for raw payloads", func(t *testing.T) {
+		svc := &server{
+			onPublishCalled: func(req *proto.PublishRequest) {
+				assert.Empty(t, req.Attributes)
+			},
+		}
+		ps, cleanup, err := getPubSub(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		require.NoError(t, ps.Publish(context.Background(), &pubsub.PublishRequest{
+			Topic: "fakeTopic",
+			Data:  []byte(`{"myextension":"fake-extension"}`),
+			Metadata: map[string]string{
+				contribMetadata.RawPayloadKey: "true",
+			},
+		}))
+		assert.Equal(t, int64(1), svc.publishCalled.Load())
+	})
 
+	t.Run("subscribe should deliver each message with its content type falling back to the subscription default", func(t *testing.T) {
+		const fakeTopic, defaultContentType = "fakeTopic", "text/plain"
+		messages := map[string]string{
+			"json":     "application/json",
+			"protobuf": "application/x-protobuf",
+			"default":  "",
+		}
+
+		messageChan := make(chan *proto.PullMessagesResponse, len(messages))
+		defer close(messageChan)
+		for id, contentType := range messages {
+			messageChan <- &proto.PullMessagesResponse{
+				Id:          id,
+				Data:        []byte(id),
+				TopicName:   fakeTopic,
+				ContentType: contentType,
+			}
+		}
+
+		ps, cleanup, err := getPubSub(&server{
+			pullChan: messageChan,
+		})
+		require.NoError(t, err)
+		defer cleanup()
+
+		var receivedLock sync.Mutex
+		received := make(map[string]string, len(messages))
 		err = ps.Subscribe(context.Background(), pubsub.SubscribeRequest{
 			Topic: fakeTopic,
+			Metadata: map[string]string{
+				contribMetadata.ContentType: defaultContentType,
+			},
 		}, func(_ context.Context, m *pubsub.NewMessage) error {
-			handleCalled.Add(1)
-			messagesProcessed.Done()
-			assert.Contains(t, messagesData, m.Data)
-			return <-handleErrors
+			receivedLock.Lock()
+			defer receivedLock.Unlock()
+			received[string(m.Data)] = *m.ContentType
+			return nil
 		})
 		require.NoError(t, err)
 
-		topicSent.Wait()
-		messagesProcessed.Wait()
-		messagesAcked.Wait()
+		assert.Eventually(t, func() bool {
+			receivedLock.Lock()
+			defer receivedLock.Unlock()
+			return len(received) == len(messages)
+		}, time.Second, 10*time.Millisecond)
 
-		assert.Equal(t, int64(len(messages)), handleCalled.Load())
-		assert.Equal(t, int64(1), totalAckErrors.Load()) // at least one message should be an error
+		receivedLock.Lock()
+		defer receivedLock.Unlock()
+		assert.Equal(t, map[string]string{
+			"json":     "application/json",
+			"protobuf": "application/x-protobuf",
+			"default":  defaultContentType,
+		}, received)
+	})
+
+	t.Run("raw payloads wrapped in a cloud event should keep the message content type", func(t *testing.T) {
+		const fakeTopic = "fakeTopic"
+
+		messageChan := make(chan *proto.PullMessagesResponse, 1)
+		defer close(messageChan)
+		messageChan <- &proto.PullMessagesResponse{
+			Data:        []byte(`{"key":"value"}`),
+			TopicName:   fakeTopic,
+			ContentType: "application/json",
+			RawPayload:  true,
+		}
+
+		ps, cleanup, err := getPubSub(&server{
+			pullChan: messageChan,
+		})
+		require.NoError(t, err)
+		defer cleanup()
+
+		received := make(chan *pubsub.NewMessage, 1)
+		err = ps.Subscribe(context.Background(), pubsub.SubscribeRequest{
+			Topic: fakeTopic,
+		}, func(_ context.Context, m *pubsub.NewMessage) error {
+			received <- m
+			return nil
+		})
+		require.NoError(t, err)
+
+		select {
+		case m := <-received:
+			assert.Equal(t, contenttype.CloudEventContentType, *m.ContentType)
+			var cloudEvent map[string]any
+			require.NoError(t, json.Unmarshal(m.Data, &cloudEvent))
+			assert.Equal(t, "application/json", cloudEvent[pubsub.DataContentTypeField])
+		case <-time.After(time.Second):
+			require.Fail(t, "message was not received")
+		}
+	})
+
+	t.Run("subscribe should handle messages sharing the same ordering key sequentially", func(t *testing.T) {
+		const fakeTopic, fakeOrderingKey, totalMessages = "fakeTopic", "fakeOrderingKey", 10
+
+		messageChan := make(chan *proto.PullMessagesResponse, totalMessages)
+		defer close(messageChan)
+
+		for idx := 0; idx < totalMessages; idx++ {
+			messageChan <- &proto.PullMessagesResponse{
+				Data:        []byte(strconv.Itoa(idx)),
+				TopicName:   fakeTopic,
+				OrderingKey: fakeOrderingKey,
+			}
+		}
+
+		ps, cleanup, err := getPubSub(&server{
+			pullChan: messageChan,
+		})
+		require.NoError(t, err)
+		defer cleanup()
+		ps.featureSet = pluggable.NewFeatureSet(&proto.FeaturesResponse{Features: []string{string(FeatureMessageOrdering)}})
+
+		var (
+			mu       sync.Mutex
+			received []string
+			inFlight atomic.Int64
+			handled  sync.WaitGroup
+		)
+		handled.Add(totalMessages)
+
+		err = ps.Subscribe(context.Background(), pubsub.SubscribeRequest{
+			Topic: fakeTopic,
+		}, func(_ context.Context, m *pubsub.NewMessage) error {
+			defer handled.Done()
+			assert.Equal(t, int64(1), inFlight.Add(1))
+			defer inFlight.Add(-1)
+			assert.Equal(t, fakeOrderingKey, m.Metadata[orderingKeyMetadataKey])
+
+			mu.Lock()
+			received = append(received, string(m.Data))
+			mu.Unlock()
+			return nil
+		})
+		require.NoError(t, err)
+
+		handled.Wait()
+		for idx, data := range received {
+			assert.Equal(t, strconv.Itoa(idx), data)
+		}
+	})
+
+	t.Run("subscribe should ignore the ordering key when the component doesn't advertise message ordering", func(t *testing.T) {
+		const fakeTopic, fakeOrderingKey, totalMessages = "fakeTopic", "fakeOrderingKey", 2
+
+		messageChan := make(chan *proto.PullMessagesResponse, totalMessages)
+		defer close(messageChan)
+
+		for idx := 0; idx < totalMessages; idx++ {
+			messageChan <- &proto.PullMessagesResponse{
+				Data:        []byte(strconv.Itoa(idx)),
+				TopicName:   fakeTopic,
+				OrderingKey: fakeOrderingKey,
+			}
+		}
+
+		ps, cleanup, err := getPubSub(&server{
+			pullChan: messageChan,
+		})
+		require.NoError(t, err)
+		defer cleanup()
+
+		// every handler waits for the others, so they can only complete when handled concurrently.
+		var inFlight sync.WaitGroup
+		inFlight.Add(totalMessages)
+		allInFlight := make(chan struct{})
+		go func() {
+			inFlight.Wait()
+			close(allInFlight)
+		}()
+
+		err = ps.Subscribe(context.Background(), pubsub.SubscribeRequest{
+			Topic: fakeTopic,
+		}, func(context.Context, *pubsub.NewMessage) error {
+			inFlight.Done()
+			<-allInFlight
+			return nil
+		})
+		require.NoError(t, err)
+
+		select {
+		case <-allInFlight:
+		case <-time.After(time.Second):
+			require.Fail(t, "messages sharing the same ordering key were not handled concurrently")
+		}
+	})
+
+	t.Run("subscribe should callback handler when new messages arrive", func(t *testing.T) {
+		const fakeTopic, fakeData1, fakeData2 = "fakeTopic", "fakeData1", "fakeData2"
+		var (
+			messagesAcked     sync.WaitGroup
+			topicSent         sync.WaitGroup
+			messagesProcessed sync.WaitGroup
+			totalAckErrors    atomic.Int64
+			handleCalled      atomic.Int64
+		)
+
+		messagesData := [][]byte{[]byte(fakeData1), []byte(fakeData2)}
+		messages := make([]*proto.PullMessagesResponse, len(messagesData))
+
+		messagesAcked.Add(len(messages))
+		messagesProcessed.Add(len(messages))
+		topicSent.Add(1)
+
+		for idx, data := range messagesData {
+			messages[idx] = &proto.PullMessagesResponse{
+				Data:        data,
+				TopicName:   fakeTopic,
+				Metadata:    map[string]string{},
+				ContentType: "",
+			}
+		}
+
+		messageChan := make(chan *proto.PullMessagesResponse, len(messages))
+		defer close(messageChan)
+
+		for _, message := range messages {
+			messageChan <- message
+		}
+
+		svc := &server{
+			pullChan: messageChan,
+			onAckReceived: func(ma *proto.PullMessagesRequest) {
+				if ma.Topic != nil {
+					topicSent.Done()
+				} else {
+					messagesAcked.Done()
+				}
+				if ma.AckError != nil {
+					totalAckErrors.Add(1)
+				}
+			},
+		}
+
+		ps, cleanup, err := getPubSub(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		handleErrors := make(chan error, 1) // simulating an ack error
+		handleErrors <- errors.New("fake-error")
+		close(handleErrors)
+
+		err = ps.Subscribe(context.Background(), pubsub.SubscribeRequest{
+			Topic: fakeTopic,
+		}, func(_ context.Context, m *pubsub.NewMessage) error {
+			handleCalled.Add(1)
+			messagesProcessed.Done()
+			assert.Contains(t, messagesData, m.Data)
+			return <-handleErrors
+		})
+		require.NoError(t, err)
+
+		topicSent.Wait()
+		messagesProcessed.Wait()
+		messagesAcked.Wait()
+
+		assert.Equal(t, int64(len(messages)), handleCalled.Load())
+		assert.Equal(t, int64(1), totalAckErrors.Load()) // at least one message should be an error
+	})
+
+	t.Run("subscribe should trace each message from receive to ack in a single span", func(t *testing.T) {
+		const fakeTopic = "fakeTopic"
+		const traceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+		recorder := tracetest.NewSpanRecorder()
+		tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+		defer func() { _ = tp.Shutdown(context.Background()) }()
+		otel.SetTracerProvider(tp)
+
+		messageChan := make(chan *proto.PullMessagesResponse, 2)
+		defer close(messageChan)
+		messageChan <- &proto.PullMessagesResponse{
+			Id:         "traced",
+			Data:       []byte("traced"),
+			TopicName:  fakeTopic,
+			Attributes: map[string]string{pubsub.TraceParentField: traceParent},
+		}
+		messageChan <- &proto.PullMessagesResponse{
+			Id:        "failed",
+			Data:      []byte("failed"),
+			TopicName: fakeTopic,
+		}
+
+		acked := make(chan string, 2)
+		ps, cleanup, err := getPubSub(&server{
+			pullChan: messageChan,
+			onAckReceived: func(ma *proto.PullMessagesRequest) {
+				if ma.AckMessageId != "" {
+					acked <- ma.AckMessageId
+				}
+			},
+		})
+		require.NoError(t, err)
+		defer cleanup()
+
+		handled := make(map[string]trace.SpanContext)
+		var handledLock sync.Mutex
+		require.NoError(t, ps.Subscribe(context.Background(), pubsub.SubscribeRequest{
+			Topic: fakeTopic,
+		}, func(ctx context.Context, m *pubsub.NewMessage) error {
+			handledLock.Lock()
+			defer handledLock.Unlock()
+			handled[string(m.Data)] = trace.SpanContextFromContext(ctx)
+			if string(m.Data) == "failed" {
+				return errors.New("fake-error")
+			}
+			return nil
+		}))
+
+		for i := 0; i < 2; i++ {
+			select {
+			case <-acked:
+			case <-time.After(time.Second):
+				require.Fail(t, "message was not acked")
+			}
+		}
+
+		endedSpanOf := func(sc trace.SpanContext) sdktrace.ReadOnlySpan {
+			var ended sdktrace.ReadOnlySpan
+			assert.Eventually(t, func() bool {
+				for _, span := range recorder.Ended() {
+					if span.SpanContext().SpanID() == sc.SpanID() {
+						ended = span
+						return true
+					}
+				}
+				return false
+			}, time.Second, 10*time.Millisecond)
+			return ended
+		}
+
+		handledLock.Lock()
+		defer handledLock.Unlock()
+		require.Len(t, handled, 2)
+
+		t.Run("the message span should be a child of the trace context carried in the attributes", func(t *testing.T) {
+			sc := handled["traced"]
+			require.True(t, sc.IsValid())
+			assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", sc.TraceID().String())
+
+			span := endedSpanOf(sc)
+			require.NotNil(t, span)
+			assert.Equal(t, "00f067aa0ba902b7", span.Parent().SpanID().String())
+			assert.Equal(t, trace.SpanKindConsumer, span.SpanKind())
+			assert.Equal(t, otelcodes.Unset, span.Status().Code)
+		})
+
+		t.Run("nacked messages should end their span with an error", func(t *testing.T) {
+			sc := handled["failed"]
+			require.True(t, sc.IsValid())
+			assert.NotEqual(t, handled["traced"].TraceID(), sc.TraceID())
+
+			span := endedSpanOf(sc)
+			require.NotNil(t, span)
+			assert.False(t, span.Parent().IsValid())
+			assert.Equal(t, otelcodes.Error, span.Status().Code)
+		})
+	})
+
+	t.Run("subscribe should re-establish the pull stream when it breaks", func(t *testing.T) {
+		const fakeTopic, fakeData = "fakeTopic", "fakeData"
+
+		messageChan := make(chan *proto.PullMessagesResponse, 1)
+		defer close(messageChan)
+		messageChan <- &proto.PullMessagesResponse{
+			Data:      []byte(fakeData),
+			TopicName: fakeTopic,
+		}
+
+		var dropped atomic.Bool
+		svc := &server{
+			pullChan: messageChan,
+			onPullCalled: func() error {
+				if dropped.CompareAndSwap(false, true) {
+					return status.Error(codes.Unavailable, "fake-stream-dropped")
+				}
+				return nil
+			},
+		}
+
+		ps, cleanup, err := getPubSub(svc)
+		require.NoError(t, err)
+		defer cleanup()
+		ps.newBackOff = func() backoff.BackOff {
+			return backoff.NewConstantBackOff(10 * time.Millisecond)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		received := make(chan *pubsub.NewMessage, 2)
+		err = ps.Subscribe(ctx, pubsub.SubscribeRequest{
+			Topic: fakeTopic,
+		}, func(_ context.Context, m *pubsub.NewMessage) error {
+			received <- m
+			return nil
+		})
+		require.NoError(t, err)
+
+		select {
+		case m := <-received:
+			assert.Equal(t, fakeData, string(m.Data))
+		case <-time.After(5 * time.Second):
+			t.Fatal("message was not delivered after re-establishing the stream")
+		}
+		assert.Equal(t, int64(2), svc.pullCalled.Load())
+		assert.Empty(t, received)
+	})
+
+	t.Run("subscribe should stop re-establishing the pull stream when the context is cancelled", func(t *testing.T) {
+		const fakeTopic = "fakeTopic"
+
+		svc := &server{
+			onPullCalled: func() error {
+				return status.Error(codes.Unavailable, "fake-stream-dropped")
+			},
+		}
+
+		ps, cleanup, err := getPubSub(svc)
+		require.NoError(t, err)
+		defer cleanup()
+		ps.newBackOff = func() backoff.BackOff {
+			return backoff.NewConstantBackOff(10 * time.Millisecond)
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		err = ps.Subscribe(ctx, pubsub.SubscribeRequest{
+			Topic: fakeTopic,
+		}, func(context.Context, *pubsub.NewMessage) error {
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			return svc.pullCalled.Load() > 2
+		}, 5*time.Second, 10*time.Millisecond)
+		cancel()
+
+		time.Sleep(50 * time.Millisecond) // waiting for in-flight attempts
+		pullCalled := svc.pullCalled.Load()
+		time.Sleep(100 * time.Millisecond)
+		assert.Equal(t, pullCalled, svc.pullCalled.Load())
+	})
+
+	t.Run("subscribe should not exceed the max in-flight messages configured", func(t *testing.T) {
+		const fakeTopic, totalMessages, maxInFlight = "fakeTopic", 20, 3
+
+		messageChan := make(chan *proto.PullMessagesResponse, totalMessages)
+		defer close(messageChan)
+		for idx := 0; idx < totalMessages; idx++ {
+			messageChan <- &proto.PullMessagesResponse{
+				Data:      []byte(strconv.Itoa(idx)),
+				TopicName: fakeTopic,
+			}
+		}
+
+		var acked sync.WaitGroup
+		acked.Add(totalMessages)
+		ps, cleanup, err := getPubSub(&server{
+			pullChan: messageChan,
+			onAckReceived: func(req *proto.PullMessagesRequest) {
+				if req.Topic == nil {
+					acked.Done()
+				}
+			},
+		})
+		require.NoError(t, err)
+		defer cleanup()
+
+		var inFlight, maxObserved atomic.Int64
+		err = ps.Subscribe(context.Background(), pubsub.SubscribeRequest{
+			Topic: fakeTopic,
+			Metadata: map[string]string{
+				maxInFlightMessagesMetadataKey: strconv.Itoa(maxInFlight),
+			},
+		}, func(context.Context, *pubsub.NewMessage) error {
+			current := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				observed := maxObserved.Load()
+				if current <= observed || maxObserved.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			return nil
+		})
+		require.NoError(t, err)
+
+		acked.Wait()
+		assert.LessOrEqual(t, maxObserved.Load(), int64(maxInFlight))
+		assert.Positive(t, maxObserved.Load())
+	})
+
+	t.Run("subscribe should grant credits that gate the component send rate", func(t *testing.T) {
+		const fakeTopic, totalMessages, maxInFlight = "fakeTopic", 10, 2
+
+		svc := &creditsServer{total: totalMessages, topic: fakeTopic}
+		ps, cleanup, err := testingGrpc.TestServerFor(testLogger, func(s *grpc.Server, svc *creditsServer) {
+			proto.RegisterPubSubServer(s, svc)
+		}, func(cci grpc.ClientConnInterface) *grpcPubSub {
+			pubsub := fromConnector(testLogger, pluggable.NewGRPCConnector("/tmp/socket.sock", proto.NewPubSubClient))
+			pubsub.Client = proto.NewPubSubClient(cci)
+			return pubsub
+		})(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		var received atomic.Int64
+		err = ps.Subscribe(context.Background(), pubsub.SubscribeRequest{
+			Topic: fakeTopic,
+			Metadata: map[string]string{
+				maxInFlightMessagesMetadataKey: strconv.Itoa(maxInFlight),
+			},
+		}, func(context.Context, *pubsub.NewMessage) error {
+			time.Sleep(5 * time.Millisecond)
+			received.Add(1)
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			return received.Load() == totalMessages
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, uint32(maxInFlight), svc.initialCredits.Load())
+		assert.LessOrEqual(t, svc.maxOutstanding.Load(), int64(maxInFlight))
+		assert.Positive(t, svc.maxOutstanding.Load())
+	})
+
+	subscribeBuffered := func(t *testing.T, svc *creditsServer, buffer, maxInFlight int, handler pubsub.Handler) {
+		t.Helper()
+		ps, cleanup, err := testingGrpc.TestServerFor(testLogger, func(s *grpc.Server, svc *creditsServer) {
+			proto.RegisterPubSubServer(s, svc)
+		}, func(cci grpc.ClientConnInterface) *grpcPubSub {
+			pubsub := fromConnector(testLogger, pluggable.NewGRPCConnector("/tmp/socket.sock", proto.NewPubSubClient, pluggable.WithSubscribeBuffer(buffer)))
+			pubsub.Client = proto.NewPubSubClient(cci)
+			return pubsub
+		})(svc)
+		require.NoError(t, err)
+		t.Cleanup(cleanup)
+
+		require.NoError(t, ps.Subscribe(context.Background(), pubsub.SubscribeRequest{
+			Topic: svc.topic,
+			Metadata: map[string]string{
+				maxInFlightMessagesMetadataKey: strconv.Itoa(maxInFlight),
+			},
+		}, handler))
+	}
+
+	t.Run("subscribe should buffer up to the subscribe buffer messages ahead of the handlers", func(t *testing.T) {
+		const fakeTopic, totalMessages, maxInFlight, buffer = "fakeTopic", 10, 1, 2
+
+		svc := &creditsServer{total: totalMessages, topic: fakeTopic}
+		unblock := make(chan struct{})
+		var handling, maxHandling, received atomic.Int64
+		subscribeBuffered(t, svc, buffer, maxInFlight, func(context.Context, *pubsub.NewMessage) error {
+			current := handling.Add(1)
+			defer handling.Add(-1)
+			for {
+				observed := maxHandling.Load()
+				if current <= observed || maxHandling.CompareAndSwap(observed, current) {
+					break
+				}
+			}
+			<-unblock
+			received.Add(1)
+			return nil
+		})
+
+		assert.Eventually(t, func() bool {
+			return svc.maxOutstanding.Load() == maxInFlight+buffer
+		}, 5*time.Second, 10*time.Millisecond, "the component should be able to send the buffered messages")
+		// give the runtime a chance to exceed the bounds before checking them.
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, int64(maxInFlight+buffer), svc.maxOutstanding.Load())
+		assert.Equal(t, int64(maxInFlight), maxHandling.Load())
+		assert.Equal(t, uint32(maxInFlight+buffer), svc.initialCredits.Load())
+
+		close(unblock)
+		assert.Eventually(t, func() bool {
+			return received.Load() == totalMessages
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.LessOrEqual(t, svc.maxOutstanding.Load(), int64(maxInFlight+buffer))
+		assert.LessOrEqual(t, maxHandling.Load(), int64(maxInFlight))
+	})
+
+	t.Run("buffered messages should be delivered when the subscription is cancelled", func(t *testing.T) {
+		const fakeTopic, totalMessages, maxInFlight, buffer = "fakeTopic", 20, 1, 4
+
+		svc := &creditsServer{total: totalMessages, topic: fakeTopic}
+		ps, cleanup, err := testingGrpc.TestServerFor(testLogger, func(s *grpc.Server, svc *creditsServer) {
+			proto.RegisterPubSubServer(s, svc)
+		}, func(cci grpc.ClientConnInterface) *grpcPubSub {
+			pubsub := fromConnector(testLogger, pluggable.NewGRPCConnector("/tmp/socket.sock", proto.NewPubSubClient, pluggable.WithSubscribeBuffer(buffer)))
+			pubsub.Client = proto.NewPubSubClient(cci)
+			return pubsub
+		})(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		unblock := make(chan struct{})
+		var delivered atomic.Int64
+		require.NoError(t, ps.Subscribe(ctx, pubsub.SubscribeRequest{
+			Topic: fakeTopic,
+			Metadata: map[string]string{
+				maxInFlightMessagesMetadataKey: strconv.Itoa(maxInFlight),
+			},
+		}, func(context.Context, *pubsub.NewMessage) error {
+			<-unblock
+			delivered.Add(1)
+			return nil
+		}))
+
+		assert.Eventually(t, func() bool {
+			return svc.maxOutstanding.Load() == maxInFlight+buffer
+		}, 5*time.Second, 10*time.Millisecond, "the component should be able to send the buffered messages")
+
+		cancel()
+		time.Sleep(50 * time.Millisecond)
+		close(unblock)
+
+		assert.Eventually(t, func() bool {
+			return delivered.Load() == maxInFlight+buffer
+		}, 5*time.Second, 10*time.Millisecond, "every pulled message should be delivered while draining")
+		// give the runtime a chance to deliver messages received after the cancellation before checking it didn't.
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, int64(maxInFlight+buffer), delivered.Load())
+	})
+
+	t.Run("a zero subscribe buffer should only receive messages when a handler is available", func(t *testing.T) {
+		const fakeTopic, totalMessages, maxInFlight = "fakeTopic", 5, 1
+
+		svc := &creditsServer{total: totalMessages, topic: fakeTopic}
+		unblock := make(chan struct{})
+		var handled, received atomic.Int64
+		subscribeBuffered(t, svc, 0, maxInFlight, func(context.Context, *pubsub.NewMessage) error {
+			handled.Add(1)
+			<-unblock
+			received.Add(1)
+			return nil
+		})
+
+		assert.Eventually(t, func() bool {
+			return handled.Load() == 1
+		}, 5*time.Second, 10*time.Millisecond)
+		// give the runtime a chance to receive messages ahead of the handler before checking it didn't.
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, int64(maxInFlight), svc.maxOutstanding.Load())
+		assert.Equal(t, uint32(maxInFlight), svc.initialCredits.Load())
+
+		close(unblock)
+		assert.Eventually(t, func() bool {
+			return received.Load() == totalMessages
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.Equal(t, int64(maxInFlight), svc.maxOutstanding.Load())
+	})
+
+	t.Run("messages with a retry after should be delayed without delaying the others", func(t *testing.T) {
+		const fakeTopic, retryAfter = "fakeTopic", 200 * time.Millisecond
+
+		messageChan := make(chan *proto.PullMessagesResponse, 2)
+		defer close(messageChan)
+		messageChan <- &proto.PullMessagesResponse{
+			Id:         "redelivered",
+			Data:       []byte("redelivered"),
+			TopicName:  fakeTopic,
+			RetryAfter: durationpb.New(retryAfter),
+		}
+		messageChan <- &proto.PullMessagesResponse{
+			Id:        "new",
+			Data:      []byte("new"),
+			TopicName: fakeTopic,
+		}
+
+		ps, cleanup, err := getPubSub(&server{pullChan: messageChan})
+		require.NoError(t, err)
+		defer cleanup()
+
+		var (
+			handledLock sync.Mutex
+			handled     []string
+		)
+		start := time.Now()
+		var delayedAt atomic.Int64
+		err = ps.Subscribe(context.Background(), pubsub.SubscribeRequest{Topic: fakeTopic}, func(_ context.Context, msg *pubsub.NewMessage) error {
+			handledLock.Lock()
+			defer handledLock.Unlock()
+			handled = append(handled, string(msg.Data))
+			if len(handled) == 2 {
+				delayedAt.Store(int64(time.Since(start)))
+			}
+			return nil
+		})
+		require.NoError(t, err)
+
+		assert.Eventually(t, func() bool {
+			return delayedAt.Load() > 0
+		}, 5*time.Second, 10*time.Millisecond)
+		assert.GreaterOrEqual(t, time.Duration(delayedAt.Load()), retryAfter)
+		handledLock.Lock()
+		defer handledLock.Unlock()
+		assert.Equal(t, []string{"new", "redelivered"}, handled)
+	})
+
+	t.Run("delayed messages should not be handled when the subscription stops", func(t *testing.T) {
+		const fakeTopic = "fakeTopic"
+
+		messageChan := make(chan *proto.PullMessagesResponse, 1)
+		defer close(messageChan)
+		messageChan <- &proto.PullMessagesResponse{
+			Id:         "redelivered",
+			TopicName:  fakeTopic,
+			RetryAfter: durationpb.New(time.Hour),
+		}
+
+		svc := &server{pullChan: messageChan}
+		ps, cleanup, err := getPubSub(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		var handled atomic.Int64
+		err = ps.Subscribe(context.Background(), pubsub.SubscribeRequest{Topic: fakeTopic}, func(context.Context, *pubsub.NewMessage) error {
+			handled.Add(1)
+			return nil
+		})
+		require.NoError(t, err)
+
+		time.Sleep(50 * time.Millisecond)
+		require.NoError(t, ps.Unsubscribe(fakeTopic))
+		assert.Equal(t, int64(0), handled.Load())
+	})
+
+	t.Run("subscribe should return an error when max in-flight messages is invalid", func(t *testing.T) {
+		svc := &server{}
+		ps, cleanup, err := getPubSub(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		err = ps.Subscribe(context.Background(), pubsub.SubscribeRequest{
+			Topic: "fakeTopic",
+			Metadata: map[string]string{
+				maxInFlightMessagesMetadataKey: "not-a-number",
+			},
+		}, func(context.Context, *pubsub.NewMessage) error {
+			return nil
+		})
+		assert.Error(t, err)
+		assert.Equal(t, int64(0), svc.pullCalled.Load())
+	})
+
+	t.Run("unsubscribe should stop the topic deliveries while others keep flowing", func(t *testing.T) {
+		const topicA, topicB = "topicA", "topicB"
+
+		svc := &topicsServer{
+			topics: map[string]chan *proto.PullMessagesResponse{
+				topicA: make(chan *proto.PullMessagesResponse, 1),
+				topicB: make(chan *proto.PullMessagesResponse, 1),
+			},
+		}
+		ps, cleanup, err := testingGrpc.TestServerFor(testLogger, func(s *grpc.Server, svc *topicsServer) {
+			proto.RegisterPubSubServer(s, svc)
+		}, func(cci grpc.ClientConnInterface) *grpcPubSub {
+			ps := fromConnector(testLogger, pluggable.NewGRPCConnector("/tmp/socket.sock", proto.NewPubSubClient))
+			ps.Client = proto.NewPubSubClient(cci)
+			return ps
+		})(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		received := map[string]chan *pubsub.NewMessage{
+			topicA: make(chan *pubsub.NewMessage, 1),
+			topicB: make(chan *pubsub.NewMessage, 1),
+		}
+		for topic := range received {
+			err = ps.Subscribe(context.Background(), pubsub.SubscribeRequest{
+				Topic: topic,
+			}, func(_ context.Context, m *pubsub.NewMessage) error {
+				received[m.Topic] <- m
+				return nil
+			})
+			require.NoError(t, err)
+		}
+
+		publish := func(topic string) {
+			svc.topics[topic] <- &proto.PullMessagesResponse{TopicName: topic}
+		}
+		isReceived := func(topic string) bool {
+			select {
+			case <-received[topic]:
+				return true
+			case <-time.After(200 * time.Millisecond):
+				return false
+			}
+		}
+
+		publish(topicA)
+		publish(topicB)
+		assert.True(t, isReceived(topicA))
+		assert.True(t, isReceived(topicB))
+
+		require.NoError(t, ps.Unsubscribe(topicA))
+
+		publish(topicA)
+		publish(topicB)
+		assert.False(t, isReceived(topicA))
+		assert.True(t, isReceived(topicB))
+
+		assert.Error(t, ps.Unsubscribe(topicA))
+	})
+
+	t.Run("subscribe should drain in-flight messages on shutdown", func(t *testing.T) {
+		const fakeTopic = "fakeTopic"
+
+		messageChan := make(chan *proto.PullMessagesResponse, 2)
+		defer close(messageChan)
+		acks := make(chan string, 2)
+		ps, cleanup, err := getPubSub(&server{
+			pullChan: messageChan,
+			onAckReceived: func(req *proto.PullMessagesRequest) {
+				if req.Topic == nil {
+					acks <- req.AckMessageId
+				}
+			},
+		})
+		require.NoError(t, err)
+		defer cleanup()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		started, release := make(chan string, 2), make(chan struct{})
+		var handlerCtxErr atomic.Value
+		err = ps.Subscribe(ctx, pubsub.SubscribeRequest{
+			Topic: fakeTopic,
+		}, func(handlerCtx context.Context, m *pubsub.NewMessage) error {
+			started <- string(m.Data)
+			<-release
+			if handlerCtx.Err() != nil {
+				handlerCtxErr.Store(handlerCtx.Err())
+			}
+			return nil
+		})
+		require.NoError(t, err)
+
+		messageChan <- &proto.PullMessagesResponse{Id: "in-flight", Data: []byte("in-flight"), TopicName: fakeTopic}
+		assert.Equal(t, "in-flight", <-started)
+
+		cancel()
+		messageChan <- &proto.PullMessagesResponse{Id: "new", Data: []byte("new"), TopicName: fakeTopic}
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+
+		select {
+		case ack := <-acks:
+			assert.Equal(t, "in-flight", ack)
+		case <-time.After(time.Second):
+			assert.Fail(t, "in-flight message was not ack'ed while draining")
+		}
+		assert.Nil(t, handlerCtxErr.Load())
+
+		select {
+		case msg := <-started:
+			assert.Failf(t, "new message delivered after shutdown", "message %s", msg)
+		case <-time.After(100 * time.Millisecond):
+		}
+	})
+
+	t.Run("subscribe should stop draining after the drain timeout", func(t *testing.T) {
+		const fakeTopic = "fakeTopic"
+
+		messageChan := make(chan *proto.PullMessagesResponse, 1)
+		defer close(messageChan)
+		ps, cleanup, err := testingGrpc.TestServerFor(testLogger, func(s *grpc.Server, svc *server) {
+			proto.RegisterPubSubServer(s, svc)
+		}, func(cci grpc.ClientConnInterface) *grpcPubSub {
+			ps := fromConnector(testLogger, pluggable.NewGRPCConnector("/tmp/socket.sock", proto.NewPubSubClient, pluggable.WithSubscribeDrainTimeout(50*time.Millisecond)))
+			ps.Client = proto.NewPubSubClient(cci)
+			return ps
+		})(&server{pullChan: messageChan})
+		require.NoError(t, err)
+		defer cleanup()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		started, cancelled := make(chan struct{}), make(chan struct{})
+		err = ps.Subscribe(ctx, pubsub.SubscribeRequest{
+			Topic: fakeTopic,
+		}, func(handlerCtx context.Context, m *pubsub.NewMessage) error {
+			close(started)
+			<-handlerCtx.Done()
+			close(cancelled)
+			return handlerCtx.Err()
+		})
+		require.NoError(t, err)
+
+		messageChan <- &proto.PullMessagesResponse{Id: "stuck", TopicName: fakeTopic}
+		<-started
+
+		start := time.Now()
+		cancel()
+		select {
+		case <-cancelled:
+			assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+		case <-time.After(time.Second):
+			assert.Fail(t, "stuck message was not cancelled after the drain timeout")
+		}
+	})
+
+	t.Run("subscribe should fail when the topic is already subscribed", func(t *testing.T) {
+		ps, cleanup, err := getPubSub(&server{})
+		require.NoError(t, err)
+		defer cleanup()
+
+		req := pubsub.SubscribeRequest{Topic: "fakeTopic"}
+		noop := func(context.Context, *pubsub.NewMessage) error { return nil }
+		require.NoError(t, ps.Subscribe(context.Background(), req, noop))
+		assert.Error(t, ps.Subscribe(context.Background(), req, noop))
+
+		require.NoError(t, ps.Unsubscribe(req.Topic))
+		require.NoError(t, ps.Subscribe(context.Background(), req, noop))
 	})
 }
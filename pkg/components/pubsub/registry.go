@@ -16,6 +16,7 @@ package pubsub
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/dapr/components-contrib/pubsub"
 	"github.com/dapr/dapr/pkg/components"
@@ -25,6 +26,8 @@ import (
 type Registry struct {
 	Logger       logger.Logger
 	messageBuses map[string]func(logger.Logger) pubsub.PubSub
+	// lock guards the registered components, which can be changed at runtime by the pluggable components health monitor.
+	lock sync.RWMutex
 }
 
 // DefaultRegistry is the singleton with the registry.
@@ -39,6 +42,9 @@ func NewRegistry() *Registry {
 
 // RegisterComponent adds a new message bus to the registry.
 func (p *Registry) RegisterComponent(componentFactory func(logger.Logger) pubsub.PubSub, names ...string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
 	for _, name := range names {
 		p.messageBuses[createFullName(name)] = componentFactory
 	}
@@ -53,6 +59,9 @@ func (p *Registry) Create(name, version, logName string) (pubsub.PubSub, error)
 }
 
 func (p *Registry) getPubSub(name, version, logName string) (func() pubsub.PubSub, bool) {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+
 	nameLower := strings.ToLower(name)
 	versionLower := strings.ToLower(version)
 	pubSubFn, ok := p.messageBuses[nameLower+"/"+versionLower]
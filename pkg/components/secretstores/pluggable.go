@@ -15,32 +15,77 @@ package secretstores
 
 import (
 	"context"
+	"sync"
 
 	"github.com/dapr/components-contrib/secretstores"
+	"github.com/dapr/dapr/pkg/components"
 	"github.com/dapr/dapr/pkg/components/pluggable"
 	proto "github.com/dapr/dapr/pkg/proto/components/v1"
 	"github.com/dapr/kit/logger"
 )
 
+// mapBulkGetErrs maps the Unimplemented status of components that don't support getting all secrets at once.
+var mapBulkGetErrs = pluggable.NewConverterFunc(pluggable.NotSupportedConverter(pluggable.FeatureBulkGet))
+
+// optimisticFeatures are the features assumed when the component features can't be fetched and the optimistic fallback is set.
+var optimisticFeatures = []string{
+	string(secretstores.FeatureMultipleKeyValuesPerSecret),
+}
+
 // grpcSecretStore is a implementation of a secret store over a gRPC Protocol.
 type grpcSecretStore struct {
 	*pluggable.GRPCConnector[proto.SecretStoreClient]
+	// featuresLock guards the features, they are replaced on reinit.
+	featuresLock sync.RWMutex
 	// features is the list of state store implemented features.
 	features []secretstores.Feature
 }
 
 // Init initializes the grpc secret store passing out the metadata to the grpc component.
+// Repeated init calls for the same component instance are no-ops, disabled components are not dialed.
+// The connection is closed when the given context is done before the init completes.
 func (gss *grpcSecretStore) Init(ctx context.Context, metadata secretstores.Metadata) error {
+	return gss.InitOrAbort(ctx, func() error {
+		return gss.InitOrDisable(metadata.Name, metadata.Properties, func() error {
+			return gss.init(ctx, metadata)
+		})
+	})
+}
+
+// Reinit re-initializes the component with the given metadata over the existing connection.
+// The component features are refreshed only when the component init succeeds,
+// an ErrReinitUnsupported error means that the component must be reconnected instead.
+func (gss *grpcSecretStore) Reinit(ctx context.Context, metadata secretstores.Metadata) error {
+	return gss.ReinitWith(metadata.Name, metadata.Properties, func() error {
+		return gss.initComponent(metadata)
+	})
+}
+
+// init dials and initializes the grpc component.
+func (gss *grpcSecretStore) init(ctx context.Context, metadata secretstores.Metadata) error {
 	if err := gss.Dial(metadata.Name); err != nil {
 		return err
 	}
+	gss.CaptureInfo()
 
-	protoMetadata := &proto.MetadataRequest{
-		Properties: metadata.Properties,
-	}
+	return gss.initComponent(metadata)
+}
 
-	_, err := gss.Client.Init(gss.Context, &proto.SecretStoreInitRequest{
-		Metadata: protoMetadata,
+// initComponent sends the init request to the component and fetches its features.
+func (gss *grpcSecretStore) initComponent(metadata secretstores.Metadata) error {
+	if err := gss.ValidateMetadata(metadata.Properties); err != nil {
+		return err
+	}
+	protoMetadata := pluggable.InitMetadata(metadata.Properties)
+
+	err := gss.ObserveInit(func() error {
+		resp, initErr := gss.Client.Init(gss.Context, &proto.SecretStoreInitRequest{
+			Metadata: protoMetadata,
+		})
+		if initErr == nil {
+			gss.ReportInitWarnings(resp.GetWarnings())
+		}
+		return initErr
 	})
 	if err != nil {
 		return err
@@ -48,21 +93,28 @@ func (gss *grpcSecretStore) Init(ctx context.Context, metadata secretstores.Meta
 
 	// TODO Static data could be retrieved in another way, a necessary discussion should start soon.
 	// we need to call the method here because features could return an error and the features interface doesn't support errors
-	featureResponse, err := gss.Client.Features(gss.Context, &proto.FeaturesRequest{})
+	featureResponse, err := gss.FetchFeatures(optimisticFeatures...)
 	if err != nil {
 		return err
 	}
 
-	gss.features = make([]secretstores.Feature, len(featureResponse.Features))
+	features := make([]secretstores.Feature, len(featureResponse.Features))
 	for idx, f := range featureResponse.Features {
-		gss.features[idx] = secretstores.Feature(f)
+		features[idx] = secretstores.Feature(f)
 	}
 
+	gss.featuresLock.Lock()
+	gss.features = features
+	gss.featuresLock.Unlock()
+
+	gss.Warmup()
 	return nil
 }
 
 // Features lists all implemented features.
 func (gss *grpcSecretStore) Features() []secretstores.Feature {
+	gss.featuresLock.RLock()
+	defer gss.featuresLock.RUnlock()
 	return gss.features
 }
 
@@ -86,7 +138,7 @@ func (gss *grpcSecretStore) BulkGetSecret(ctx context.Context, req secretstores.
 		Metadata: req.Metadata,
 	})
 	if err != nil {
-		return secretstores.BulkGetSecretResponse{}, err
+		return secretstores.BulkGetSecretResponse{}, mapBulkGetErrs(err)
 	}
 
 	items := make(map[string]map[string]string, len(resp.GetData()))
@@ -116,15 +168,15 @@ func NewGRPCSecretStore(l logger.Logger, socket string) *grpcSecretStore {
 }
 
 // newGRPCSecretStore creates a new grpc pubsub for the given pluggable component.
-func newGRPCSecretStore(dialer pluggable.GRPCConnectionDialer) func(l logger.Logger) secretstores.SecretStore {
+func newGRPCSecretStore(dialer pluggable.GRPCConnectionDialer, opts ...pluggable.Option) func(l logger.Logger) secretstores.SecretStore {
 	return func(l logger.Logger) secretstores.SecretStore {
-		return fromConnector(l, pluggable.NewGRPCConnectorWithDialer(dialer, proto.NewSecretStoreClient))
+		return fromConnector(l, pluggable.NewGRPCConnectorWithDialer(dialer, proto.NewSecretStoreClient, opts...))
 	}
 }
 
 func init() {
 	//nolint:nosnakecase
 	pluggable.AddServiceDiscoveryCallback(proto.SecretStore_ServiceDesc.ServiceName, func(name string, dialer pluggable.GRPCConnectionDialer) {
-		DefaultRegistry.RegisterComponent(newGRPCSecretStore(dialer), name)
+		DefaultRegistry.RegisterComponent(newGRPCSecretStore(dialer, pluggable.WithPluggable(components.Pluggable{Type: components.CategorySecretStore, Name: name})), name)
 	})
 }
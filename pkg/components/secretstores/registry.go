@@ -16,6 +16,7 @@ package secretstores
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/dapr/components-contrib/secretstores"
 	"github.com/dapr/dapr/pkg/components"
@@ -29,6 +30,8 @@ const BuiltinKubernetesSecretStore = "kubernetes"
 type Registry struct {
 	Logger       logger.Logger
 	secretStores map[string]func(logger.Logger) secretstores.SecretStore
+	// lock guards the registered components, which can be changed at runtime by the pluggable components health monitor.
+	lock sync.RWMutex
 }
 
 // DefaultRegistry is the singleton with the registry.
@@ -43,6 +46,9 @@ func NewRegistry() *Registry {
 
 // RegisterComponent adds a new secret store to the registry.
 func (s *Registry) RegisterComponent(componentFactory func(logger.Logger) secretstores.SecretStore, names ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	for _, name := range names {
 		s.secretStores[createFullName(name)] = componentFactory
 	}
@@ -58,6 +64,9 @@ func (s *Registry) Create(name, version, logName string) (secretstores.SecretSto
 }
 
 func (s *Registry) getSecretStore(name, version, logName string) (func() secretstores.SecretStore, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
 	nameLower := strings.ToLower(name)
 	versionLower := strings.ToLower(version)
 	secretStoreFn, ok := s.secretStores[nameLower+"/"+versionLower]
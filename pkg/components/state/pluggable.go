@@ -19,10 +19,12 @@ import (
 	"errors"
 	"fmt"
 	"strconv"
+	"sync"
 
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/components-contrib/state/query"
 	"github.com/dapr/components-contrib/state/utils"
+	"github.com/dapr/dapr/pkg/components"
 	"github.com/dapr/dapr/pkg/components/pluggable"
 	proto "github.com/dapr/dapr/pkg/proto/components/v1"
 	"github.com/dapr/kit/logger"
@@ -47,6 +49,17 @@ var (
 	GRPCCodeBulkDeleteRowMismatch = codes.Internal
 )
 
+// optimisticFeatures are the features assumed when the component features can't be fetched and the optimistic fallback is set.
+var optimisticFeatures = []string{
+	string(state.FeatureETag),
+	string(state.FeatureTransactional),
+	string(state.FeatureQueryAPI),
+	string(state.FeatureTTL),
+	pluggable.FeatureBulkGet,
+	pluggable.FeatureBulkSet,
+	pluggable.FeatureBulkDelete,
+}
+
 const (
 	// etagField is the field that should be specified on gRPC error response.
 	etagField = "etag"
@@ -54,6 +67,8 @@ const (
 	affectedRowsMetadataKey = "affected"
 	// expectedRowsMetadataKey is the metadata key used to return bulkdelete mismatch errors expected rows.
 	expectedRowsMetadataKey = "expected"
+	// bulkGetConcurrencyMetadataKey is the component metadata key used to bound the concurrency of the bulk get fan out.
+	bulkGetConcurrencyMetadataKey = "bulkGetConcurrency"
 )
 
 // etagErrFromStatus get the etag error from the given gRPC status, if the error is not an etag kind error the return is the original error.
@@ -138,28 +153,109 @@ var (
 	mapDeleteErrs     = mapETagErrs
 	mapBulkSetErrs    = mapETagErrs
 	mapBulkDeleteErrs = pluggable.NewConverterFunc(etagErrorsConverters.Merge(bulkDeleteErrors))
+	mapQueryErrs      = pluggable.NewConverterFunc(pluggable.NotSupportedConverter(string(state.FeatureQueryAPI)))
+	mapTransactErrs   = pluggable.NewConverterFunc(pluggable.NotSupportedConverter(string(state.FeatureTransactional)))
 )
 
 // grpcStateStore is a implementation of a state store over a gRPC Protocol.
 type grpcStateStore struct {
 	*pluggable.GRPCConnector[stateStoreClient]
+	// configLock guards the fields adopted from the component init, they are replaced together on reinit.
+	configLock sync.RWMutex
 	// features is the list of state store implemented features.
 	features []state.Feature
+	// featureSet holds the features reported by the component, used to choose between native and emulated bulk operations.
+	featureSet pluggable.FeatureSet
+	// initMetadata is the component init metadata, merged into each request metadata.
+	initMetadata map[string]string
+	// bulkGetConcurrency is the default concurrency of the bulk get fan out, zero means unbounded.
+	bulkGetConcurrency int
+	// bulkGet, bulkSet and bulkDelete emulate the bulk operations through single-key operations when the component doesn't support them.
+	bulkGet, bulkSet, bulkDelete *pluggable.BulkFallback
+}
+
+// componentFeatures returns the features reported by the component.
+func (ss *grpcStateStore) componentFeatures() pluggable.FeatureSet {
+	ss.configLock.RLock()
+	defer ss.configLock.RUnlock()
+	return ss.featureSet
+}
+
+// withInitMetadata merges the given request metadata over the component init metadata.
+// request metadata takes precedence when the same key is present in both.
+func (ss *grpcStateStore) withInitMetadata(reqMetadata map[string]string) map[string]string {
+	ss.configLock.RLock()
+	initMetadata := ss.initMetadata
+	ss.configLock.RUnlock()
+
+	if len(initMetadata) == 0 {
+		return reqMetadata
+	}
+	merged := make(map[string]string, len(initMetadata)+len(reqMetadata))
+	for k, v := range initMetadata {
+		merged[k] = v
+	}
+	for k, v := range reqMetadata {
+		merged[k] = v
+	}
+	return merged
 }
 
 // Init initializes the grpc state passing out the metadata to the grpc component.
 // It also fetches and set the current components features.
+// Repeated init calls for the same component instance are no-ops, disabled components are not dialed.
+// The connection is closed when the given context is done before the init completes.
 func (ss *grpcStateStore) Init(ctx context.Context, metadata state.Metadata) error {
+	return ss.InitOrAbort(ctx, func() error {
+		return ss.InitOrDisable(metadata.Name, metadata.Properties, func() error {
+			return ss.init(ctx, metadata)
+		})
+	})
+}
+
+// Reinit re-initializes the component with the given metadata over the existing connection.
+// The new metadata and features are adopted only when the component init succeeds,
+// an ErrReinitUnsupported error means that the component must be reconnected instead.
+func (ss *grpcStateStore) Reinit(ctx context.Context, metadata state.Metadata) error {
+	return ss.ReinitWith(metadata.Name, metadata.Properties, func() error {
+		return ss.initComponent(metadata)
+	})
+}
+
+// init dials and initializes the grpc component.
+func (ss *grpcStateStore) init(ctx context.Context, metadata state.Metadata) error {
+	if _, err := bulkGetConcurrencyOf(metadata.Properties); err != nil {
+		return err
+	}
+
 	if err := ss.Dial(metadata.Name); err != nil {
 		return err
 	}
+	ss.CaptureInfo()
 
-	protoMetadata := &proto.MetadataRequest{
-		Properties: metadata.Properties,
+	return ss.initComponent(metadata)
+}
+
+// initComponent sends the init request to the component and adopts the given metadata along with the component features.
+func (ss *grpcStateStore) initComponent(metadata state.Metadata) error {
+	bulkGetConcurrency, err := bulkGetConcurrencyOf(metadata.Properties)
+	if err != nil {
+		return err
+	}
+	if err = ss.ValidateMetadata(metadata.Properties, bulkGetConcurrencyMetadataKey); err != nil {
+		return err
 	}
 
-	_, err := ss.Client.Init(ss.Context, &proto.InitRequest{
-		Metadata: protoMetadata,
+	protoMetadata := pluggable.InitMetadata(metadata.Properties)
+
+	err = ss.ObserveInit(func() error {
+		resp, initErr := ss.Client.Init(ss.Context, &proto.InitRequest{
+			Metadata: protoMetadata,
+		})
+		if initErr == nil {
+			ss.ReportInitWarnings(resp.GetWarnings())
+		}
+		return initErr
 	})
 	if err != nil {
 		return err
@@ -167,34 +263,59 @@ func (ss *grpcStateStore) Init(ctx context.Context, metadata state.Metadata) err
 
 	// TODO Static data could be retrieved in another way, a necessary discussion should start soon.
 	// we need to call the method here because features could return an error and the features interface doesn't support errors
-	featureResponse, err := ss.Client.Features(ss.Context, &proto.FeaturesRequest{})
+	featureResponse, err := ss.FetchFeatures(optimisticFeatures...)
 	if err != nil {
 		return err
 	}
 
-	ss.features = make([]state.Feature, len(featureResponse.Features))
+	features := make([]state.Feature, len(featureResponse.Features))
 	for idx, f := range featureResponse.Features {
-		ss.features[idx] = state.Feature(f)
+		features[idx] = state.Feature(f)
 	}
 
+	ss.Warmup()
+
+	ss.configLock.Lock()
+	defer ss.configLock.Unlock()
+	ss.initMetadata = metadata.Properties
+	ss.bulkGetConcurrency = bulkGetConcurrency
+	ss.features = features
+	ss.featureSet = pluggable.NewFeatureSet(featureResponse)
+
 	return nil
 }
 
 // Features list all implemented features.
 func (ss *grpcStateStore) Features() []state.Feature {
+	ss.configLock.RLock()
+	defer ss.configLock.RUnlock()
 	return ss.features
 }
 
 // Delete performs a delete operation.
+// the request metadata is merged over the component init metadata, the request wins on key conflicts.
 func (ss *grpcStateStore) Delete(ctx context.Context, req *state.DeleteRequest) error {
-	_, err := ss.Client.Delete(ctx, toDeleteRequest(req))
+	protoRequest := toDeleteRequest(req)
+	if protoRequest != nil {
+		protoRequest.Metadata = ss.withInitMetadata(protoRequest.Metadata)
+	}
+	_, err := ss.Client.Delete(ctx, protoRequest)
 
 	return mapDeleteErrs(err)
 }
 
 // Get performs a get on the state store.
+// missing keys, either reported as an empty response or as a NotFound error, are returned as an empty response without error.
+// the request metadata is merged over the component init metadata, the request wins on key conflicts.
 func (ss *grpcStateStore) Get(ctx context.Context, req *state.GetRequest) (*state.GetResponse, error) {
-	response, err := ss.Client.Get(ctx, toGetRequest(req))
+	protoRequest := toGetRequest(req)
+	if protoRequest != nil {
+		protoRequest.Metadata = ss.withInitMetadata(protoRequest.Metadata)
+	}
+	response, err := ss.Client.Get(ctx, protoRequest)
+	if status.Code(err) == codes.NotFound {
+		return &state.GetResponse{}, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -206,22 +327,52 @@ func (ss *grpcStateStore) Get(ctx context.Context, req *state.GetRequest) (*stat
 	return fromGetResponse(response), nil
 }
 
+// ETagSetter is a state store that returns the etag of the stored value on set.
+type ETagSetter interface {
+	SetWithETag(ctx context.Context, req *state.SetRequest) (string, error)
+}
+
 // Set performs a set operation on the state store.
+// the request metadata is merged over the component init metadata, the request wins on key conflicts.
 func (ss *grpcStateStore) Set(ctx context.Context, req *state.SetRequest) error {
+	_, err := ss.SetWithETag(ctx, req)
+	return err
+}
+
+// SetWithETag performs a set operation on the state store and returns the etag of the stored value.
+// the etag is empty when the store doesn't support etags.
+func (ss *grpcStateStore) SetWithETag(ctx context.Context, req *state.SetRequest) (string, error) {
 	protoRequest, err := toSetRequest(req)
 	if err != nil {
-		return err
+		return "", err
+	}
+	if protoRequest != nil {
+		protoRequest.Metadata = ss.withInitMetadata(protoRequest.Metadata)
 	}
-	_, err = ss.Client.Set(ctx, protoRequest)
-	return mapSetErrs(err)
+	resp, err := ss.Client.Set(ctx, protoRequest)
+	if err != nil {
+		return "", mapSetErrs(err)
+	}
+	return resp.GetEtag().GetValue(), nil
 }
 
 // BulkDelete performs a delete operation for many keys at once.
+// it deletes each key individually when the component doesn't implement bulk delete.
 func (ss *grpcStateStore) BulkDelete(ctx context.Context, reqs []state.DeleteRequest, opts state.BulkStoreOpts) error {
+	return ss.bulkDelete.Do(func() error {
+		return ss.nativeBulkDelete(ctx, reqs, opts)
+	}, func() error {
+		return state.DoBulkSetDelete(ctx, reqs, ss.Delete, opts)
+	})
+}
+
+// nativeBulkDelete performs a bulk delete operation on the component.
+func (ss *grpcStateStore) nativeBulkDelete(ctx context.Context, reqs []state.DeleteRequest, opts state.BulkStoreOpts) error {
 	protoRequests := make([]*proto.DeleteRequest, len(reqs))
 
 	for idx := range reqs {
 		protoRequests[idx] = toDeleteRequest(&reqs[idx])
+		protoRequests[idx].Metadata = ss.withInitMetadata(protoRequests[idx].Metadata)
 	}
 
 	bulkDeleteRequest := &proto.BulkDeleteRequest{
@@ -235,11 +386,50 @@ func (ss *grpcStateStore) BulkDelete(ctx context.Context, reqs []state.DeleteReq
 	return mapBulkDeleteErrs(err)
 }
 
+// bulkGetConcurrencyOf returns the bulk get concurrency from the given component metadata.
+func bulkGetConcurrencyOf(metadata map[string]string) (int, error) {
+	value, ok := metadata[bulkGetConcurrencyMetadataKey]
+	if !ok || value == "" {
+		return 0, nil
+	}
+	concurrency, err := strconv.Atoi(value)
+	if err != nil || concurrency < 0 {
+		return 0, fmt.Errorf("invalid %s value '%s': must be a non-negative integer", bulkGetConcurrencyMetadataKey, value)
+	}
+	return concurrency, nil
+}
+
+// bulkGetFanOut performs a get operation for each key when the component doesn't implement bulk get.
+// the fan out concurrency is bounded by the request parallelism or the component bulk get concurrency when not set.
+// results keep the requests order and per-key errors are returned within each result.
+func (ss *grpcStateStore) bulkGetFanOut(ctx context.Context, req []state.GetRequest, opts state.BulkGetOpts) ([]state.BulkGetResponse, error) {
+	if opts.Parallelism <= 0 {
+		ss.configLock.RLock()
+		opts.Parallelism = ss.bulkGetConcurrency
+		ss.configLock.RUnlock()
+	}
+	return state.DoBulkGet(ctx, req, opts, ss.Get)
+}
+
 // BulkGet performs a get operation for many keys at once.
-func (ss *grpcStateStore) BulkGet(ctx context.Context, req []state.GetRequest, opts state.BulkGetOpts) ([]state.BulkGetResponse, error) {
+// it fans out single-key get operations when the component doesn't implement bulk get.
+func (ss *grpcStateStore) BulkGet(ctx context.Context, req []state.GetRequest, opts state.BulkGetOpts) (items []state.BulkGetResponse, err error) {
+	err = ss.bulkGet.Do(func() (nativeErr error) {
+		items, nativeErr = ss.nativeBulkGet(ctx, req, opts)
+		return nativeErr
+	}, func() (fanOutErr error) {
+		items, fanOutErr = ss.bulkGetFanOut(ctx, req, opts)
+		return fanOutErr
+	})
+	return items, err
+}
+
+// nativeBulkGet performs a bulk get operation on the component.
+func (ss *grpcStateStore) nativeBulkGet(ctx context.Context, req []state.GetRequest, opts state.BulkGetOpts) ([]state.BulkGetResponse, error) {
 	protoRequests := make([]*proto.GetRequest, len(req))
 	for idx := range req {
 		protoRequests[idx] = toGetRequest(&req[idx])
+		protoRequests[idx].Metadata = ss.withInitMetadata(protoRequests[idx].Metadata)
 	}
 
 	bulkGetRequest := &proto.BulkGetRequest{
@@ -268,23 +458,103 @@ func (ss *grpcStateStore) BulkGet(ctx context.Context, req []state.GetRequest, o
 	return items, nil
 }
 
+// BulkSetResult is the result of setting a single key of a bulk set.
+type BulkSetResult struct {
+	// Key is the key of the set item.
+	Key string
+	// ETag is the etag of the stored value, empty when the item was not set or the store doesn't support etags.
+	ETag string
+	// Err is the error setting the item, if any.
+	Err error
+}
+
+// ETagBulkSetter is a state store that returns the result of each key on bulk set.
+type ETagBulkSetter interface {
+	BulkSetWithETags(ctx context.Context, req []state.SetRequest, opts state.BulkStoreOpts) ([]BulkSetResult, error)
+}
+
 // BulkSet performs a set operation for many keys at once.
+// it sets each key individually when the component doesn't implement bulk set.
+// the keys that could not be set are reported as state.BulkStoreError joined in the returned error.
 func (ss *grpcStateStore) BulkSet(ctx context.Context, req []state.SetRequest, opts state.BulkStoreOpts) error {
+	results, err := ss.BulkSetWithETags(ctx, req, opts)
+	if err != nil {
+		return err
+	}
+	errs := make([]error, 0, len(results))
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, state.NewBulkStoreError(result.Key, result.Err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// BulkSetWithETags performs a set operation for many keys at once and returns the result of each key, in the request order.
+// the returned error is only set when the whole bulk set fails, the keys that could not be set have their result error set instead.
+func (ss *grpcStateStore) BulkSetWithETags(ctx context.Context, req []state.SetRequest, opts state.BulkStoreOpts) (results []BulkSetResult, err error) {
+	err = ss.bulkSet.Do(func() (nativeErr error) {
+		results, nativeErr = ss.nativeBulkSet(ctx, req, opts)
+		return nativeErr
+	}, func() error {
+		results = ss.bulkSetFanOut(ctx, req, opts)
+		return nil
+	})
+	return results, err
+}
+
+// bulkSetFanOut sets each key individually.
+func (ss *grpcStateStore) bulkSetFanOut(ctx context.Context, req []state.SetRequest, opts state.BulkStoreOpts) []BulkSetResult {
+	results := make([]BulkSetResult, len(req))
+	resultOf := make(map[*state.SetRequest]*BulkSetResult, len(req))
+	for idx := range req {
+		results[idx].Key = req[idx].Key
+		resultOf[&req[idx]] = &results[idx]
+	}
+	// every request is set once so each call writes to its own result.
+	_ = state.DoBulkSetDelete(ctx, req, func(ctx context.Context, r *state.SetRequest) error {
+		result := resultOf[r]
+		result.ETag, result.Err = ss.SetWithETag(ctx, r)
+		return result.Err
+	}, opts)
+	return results
+}
+
+// nativeBulkSet performs a bulk set operation on the component.
+// the result of each item is taken from the response items, which are in the request order.
+func (ss *grpcStateStore) nativeBulkSet(ctx context.Context, req []state.SetRequest, opts state.BulkStoreOpts) ([]BulkSetResult, error) {
 	requests := []*proto.SetRequest{}
 	for idx := range req {
 		protoRequest, err := toSetRequest(&req[idx])
 		if err != nil {
-			return err
+			return nil, err
 		}
+		protoRequest.Metadata = ss.withInitMetadata(protoRequest.Metadata)
 		requests = append(requests, protoRequest)
 	}
-	_, err := ss.Client.BulkSet(ctx, &proto.BulkSetRequest{
+	resp, err := ss.Client.BulkSet(ctx, &proto.BulkSetRequest{
 		Items: requests,
 		Options: &proto.BulkSetRequestOptions{
 			Parallelism: int64(opts.Parallelism),
 		},
 	})
-	return mapBulkSetErrs(err)
+	if err != nil {
+		return nil, mapBulkSetErrs(err)
+	}
+
+	items := resp.GetItems()
+	results := make([]BulkSetResult, len(req))
+	for idx := range req {
+		results[idx].Key = req[idx].Key
+		if idx >= len(items) {
+			continue
+		}
+		results[idx].ETag = items[idx].GetEtag().GetValue()
+		if items[idx].GetError() != "" {
+			results[idx].Err = errors.New(items[idx].GetError())
+		}
+	}
+	return results, nil
 }
 
 // Query performsn a query in the state store
@@ -296,10 +566,10 @@ func (ss *grpcStateStore) Query(ctx context.Context, req *state.QueryRequest) (*
 
 	resp, err := ss.Client.Query(ctx, &proto.QueryRequest{
 		Query:    q,
-		Metadata: req.Metadata,
+		Metadata: ss.withInitMetadata(req.Metadata),
 	})
 	if err != nil {
-		return nil, err
+		return nil, mapQueryErrs(err)
 	}
 	return fromQueryResponse(resp), nil
 }
@@ -316,9 +586,9 @@ func (ss *grpcStateStore) Multi(ctx context.Context, request *state.Transactiona
 	}
 	_, err := ss.Client.Transact(ctx, &proto.TransactionalStateRequest{
 		Operations: operations,
-		Metadata:   request.Metadata,
+		Metadata:   ss.withInitMetadata(request.Metadata),
 	})
-	return err
+	return mapTransactErrs(err)
 }
 
 // mappers and helpers.
@@ -440,7 +710,13 @@ func toSetRequest(req *state.SetRequest) (*proto.SetRequest, error) {
 	}, nil
 }
 
+// fromGetResponse maps the given get response, an empty data without etag means that the key was not found.
 func fromGetResponse(resp *proto.GetResponse) *state.GetResponse {
+	if len(resp.GetData()) == 0 && resp.GetEtag() == nil {
+		return &state.GetResponse{
+			Metadata: resp.GetMetadata(),
+		}
+	}
 	return &state.GetResponse{
 		Data:        resp.GetData(),
 		ETag:        fromETagResponse(resp.GetEtag()),
@@ -555,10 +831,14 @@ func newStateStoreClient(cc grpc.ClientConnInterface) stateStoreClient {
 
 // fromConnector creates a new GRPC state store using the given underlying connector.
 func fromConnector(_ logger.Logger, connector *pluggable.GRPCConnector[stateStoreClient]) *grpcStateStore {
-	return &grpcStateStore{
+	ss := &grpcStateStore{
 		features:      make([]state.Feature, 0),
 		GRPCConnector: connector,
 	}
+	ss.bulkGet = pluggable.NewBulkFallback(pluggable.FeatureBulkGet, ss.componentFeatures)
+	ss.bulkSet = pluggable.NewBulkFallback(pluggable.FeatureBulkSet, ss.componentFeatures)
+	ss.bulkDelete = pluggable.NewBulkFallback(pluggable.FeatureBulkDelete, ss.componentFeatures)
+	return ss
 }
 
 // NewGRPCStateStore creates a new grpc state store using the given socket factory.
@@ -567,15 +847,15 @@ func NewGRPCStateStore(l logger.Logger, socket string) *grpcStateStore {
 }
 
 // newGRPCStateStore creates a new state store for the given pluggable component.
-func newGRPCStateStore(dialer pluggable.GRPCConnectionDialer) func(l logger.Logger) state.Store {
+func newGRPCStateStore(dialer pluggable.GRPCConnectionDialer, opts ...pluggable.Option) func(l logger.Logger) state.Store {
 	return func(l logger.Logger) state.Store {
-		return fromConnector(l, pluggable.NewGRPCConnectorWithDialer(dialer, newStateStoreClient))
+		return fromConnector(l, pluggable.NewGRPCConnectorWithDialer(dialer, newStateStoreClient, opts...))
 	}
 }
 
 func init() {
 	//nolint:nosnakecase
 	pluggable.AddServiceDiscoveryCallback(proto.StateStore_ServiceDesc.ServiceName, func(name string, dialer pluggable.GRPCConnectionDialer) {
-		DefaultRegistry.RegisterComponent(newGRPCStateStore(dialer), name)
+		DefaultRegistry.RegisterComponent(newGRPCStateStore(dialer, pluggable.WithPluggable(components.Pluggable{Type: components.CategoryStateStore, Name: name})), name)
 	})
 }
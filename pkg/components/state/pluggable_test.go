@@ -17,18 +17,26 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"runtime"
+	"strings"
+	"sync"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	guuid "github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 
 	contribMetadata "github.com/dapr/components-contrib/metadata"
 	"github.com/dapr/components-contrib/state"
@@ -36,6 +44,7 @@ import (
 	"github.com/dapr/dapr/pkg/components/pluggable"
 	proto "github.com/dapr/dapr/pkg/proto/components/v1"
 	testingGrpc "github.com/dapr/dapr/pkg/testing/grpc"
+	testingPluggable "github.com/dapr/dapr/pkg/testing/pluggable"
 	"github.com/dapr/kit/logger"
 )
 
@@ -43,7 +52,10 @@ type server struct {
 	proto.UnimplementedStateStoreServer
 	proto.UnimplementedTransactionalStateStoreServer
 	initCalled         atomic.Int64
+	onInitCalled       func(*proto.InitRequest)
+	initErr            error
 	featuresCalled     atomic.Int64
+	featuresResponse   *proto.FeaturesResponse
 	deleteCalled       atomic.Int64
 	onDeleteCalled     func(*proto.DeleteRequest)
 	deleteErr          error
@@ -54,6 +66,7 @@ type server struct {
 	setCalled          atomic.Int64
 	onSetCalled        func(*proto.SetRequest)
 	setErr             error
+	setResponse        *proto.SetResponse
 	pingCalled         atomic.Int64
 	pingErr            error
 	bulkDeleteCalled   atomic.Int64
@@ -66,6 +79,7 @@ type server struct {
 	bulkSetCalled      atomic.Int64
 	onBulkSetCalled    func(*proto.BulkSetRequest)
 	bulkSetErr         error
+	bulkSetResponse    *proto.BulkSetResponse
 	transactCalled     atomic.Int64
 	onTransactCalled   func(*proto.TransactionalStateRequest)
 	transactErr        error
@@ -112,6 +126,9 @@ func (s *server) Set(ctx context.Context, req *proto.SetRequest) (*proto.SetResp
 	if s.onSetCalled != nil {
 		s.onSetCalled(req)
 	}
+	if s.setResponse != nil {
+		return s.setResponse, s.setErr
+	}
 	return &proto.SetResponse{}, s.setErr
 }
 
@@ -141,16 +158,25 @@ func (s *server) BulkSet(ctx context.Context, req *proto.BulkSetRequest) (*proto
 	if s.onBulkSetCalled != nil {
 		s.onBulkSetCalled(req)
 	}
+	if s.bulkSetResponse != nil {
+		return s.bulkSetResponse, s.bulkSetErr
+	}
 	return &proto.BulkSetResponse{}, s.bulkSetErr
 }
 
-func (s *server) Init(context.Context, *proto.InitRequest) (*proto.InitResponse, error) {
+func (s *server) Init(_ context.Context, req *proto.InitRequest) (*proto.InitResponse, error) {
 	s.initCalled.Add(1)
-	return &proto.InitResponse{}, nil
+	if s.onInitCalled != nil {
+		s.onInitCalled(req)
+	}
+	return &proto.InitResponse{}, s.initErr
 }
 
 func (s *server) Features(context.Context, *proto.FeaturesRequest) (*proto.FeaturesResponse, error) {
 	s.featuresCalled.Add(1)
+	if s.featuresResponse != nil {
+		return s.featuresResponse, nil
+	}
 	return &proto.FeaturesResponse{}, nil
 }
 
@@ -404,6 +430,78 @@ func TestComponentCalls(t *testing.T) {
 		assert.Equal(t, resp.Data, fakeData)
 	})
 
+	t.Run("get should return an empty response without error when the key is missing", func(t *testing.T) {
+		svc := &server{
+			getResponse: &proto.GetResponse{},
+		}
+		stStore, cleanup, err := getStateStore(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		resp, err := stStore.Get(context.Background(), &state.GetRequest{
+			Key: "missingKey",
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		assert.Empty(t, resp.Data)
+		assert.Nil(t, resp.ETag)
+		assert.Nil(t, resp.ContentType)
+	})
+
+	t.Run("get should return an empty response without error when grpc get returns not found", func(t *testing.T) {
+		svc := &server{
+			getErr: status.Error(codes.NotFound, "key not found"),
+		}
+		stStore, cleanup, err := getStateStore(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		resp, err := stStore.Get(context.Background(), &state.GetRequest{
+			Key: "missingKey",
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, resp)
+		assert.Empty(t, resp.Data)
+	})
+
+	t.Run("get should surface backend errors", func(t *testing.T) {
+		svc := &server{
+			getErr: status.Error(codes.Internal, "backend unavailable"),
+		}
+		stStore, cleanup, err := getStateStore(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		resp, err := stStore.Get(context.Background(), &state.GetRequest{
+			Key: "fakeKey",
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Nil(t, resp)
+	})
+
+	t.Run("get should return the etag of empty values", func(t *testing.T) {
+		svc := &server{
+			getResponse: &proto.GetResponse{
+				Etag: &proto.Etag{Value: "fake-etag"},
+			},
+		}
+		stStore, cleanup, err := getStateStore(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		resp, err := stStore.Get(context.Background(), &state.GetRequest{
+			Key: "fakeKey",
+		})
+
+		require.NoError(t, err)
+		require.NotNil(t, resp.ETag)
+		assert.Equal(t, "fake-etag", *resp.ETag)
+	})
+
 	t.Run("set should return an err when grpc set returns it", func(t *testing.T) {
 		const fakeKey, fakeData = "fakeKey", "fakeData"
 
@@ -449,16 +547,49 @@ func TestComponentCalls(t *testing.T) {
 		assert.Equal(t, int64(1), svc.setCalled.Load())
 	})
 
+	t.Run("set should return the etag of the stored value when the store supports etags", func(t *testing.T) {
+		svc := &server{
+			setResponse: &proto.SetResponse{Etag: &proto.Etag{Value: "fake-etag"}},
+		}
+		stStore, cleanup, err := getStateStore(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		etag, err := stStore.SetWithETag(context.Background(), &state.SetRequest{
+			Key:   "fakeKey",
+			Value: "fakeData",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "fake-etag", etag)
+	})
+
+	t.Run("set should return an empty etag when the store doesn't support etags", func(t *testing.T) {
+		svc := &server{}
+		stStore, cleanup, err := getStateStore(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		etag, err := stStore.SetWithETag(context.Background(), &state.SetRequest{
+			Key:   "fakeKey",
+			Value: "fakeData",
+		})
+
+		require.NoError(t, err)
+		assert.Empty(t, etag)
+	})
+
 	t.Run("ping should not return an err when grpc not returns an error", func(t *testing.T) {
 		svc := &server{}
 		stStore, cleanup, err := getStateStore(svc)
 		require.NoError(t, err)
 		defer cleanup()
 
+		pingCalled := svc.pingCalled.Load() // the version info is captured on init.
 		err = stStore.Ping()
 
 		require.NoError(t, err)
-		assert.Equal(t, int64(1), svc.pingCalled.Load())
+		assert.Equal(t, pingCalled+1, svc.pingCalled.Load())
 	})
 
 	t.Run("ping should return an err when grpc returns an error", func(t *testing.T) {
@@ -469,10 +600,11 @@ func TestComponentCalls(t *testing.T) {
 		require.NoError(t, err)
 		defer cleanup()
 
+		pingCalled := svc.pingCalled.Load() // the version info is captured on init.
 		err = stStore.Ping()
 
 		assert.NotNil(t, err)
-		assert.Equal(t, int64(1), svc.pingCalled.Load())
+		assert.Equal(t, pingCalled+1, svc.pingCalled.Load())
 	})
 
 	t.Run("bulkSet should return an err when grpc returns an error", func(t *testing.T) {
@@ -537,6 +669,41 @@ func TestComponentCalls(t *testing.T) {
 		assert.Equal(t, int64(1), svc.bulkSetCalled.Load())
 	})
 
+	t.Run("bulkSet should return the result of each item with the etag of the set ones", func(t *testing.T) {
+		requests := []state.SetRequest{
+			{Key: "key-1", Value: "value-1"},
+			{Key: "failing", Value: "value-2"},
+			{Key: "key-3", Value: "value-3"},
+		}
+		svc := &server{
+			bulkSetResponse: &proto.BulkSetResponse{
+				Items: []*proto.BulkSetResponseItem{
+					{Key: "key-1", Etag: &proto.Etag{Value: "etag-1"}},
+					{Key: "failing", Error: "fake-set-error"},
+					{Key: "key-3", Etag: &proto.Etag{Value: "etag-3"}},
+				},
+			},
+		}
+		stStore, cleanup, err := getStateStore(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		results, err := stStore.BulkSetWithETags(context.Background(), requests, state.BulkStoreOpts{})
+		require.NoError(t, err)
+		require.Len(t, results, len(requests))
+		assert.Equal(t, BulkSetResult{Key: "key-1", ETag: "etag-1"}, results[0])
+		assert.Equal(t, "failing", results[1].Key)
+		assert.Empty(t, results[1].ETag)
+		assert.EqualError(t, results[1].Err, "fake-set-error")
+		assert.Equal(t, BulkSetResult{Key: "key-3", ETag: "etag-3"}, results[2])
+
+		err = stStore.BulkSet(context.Background(), requests, state.BulkStoreOpts{})
+		var bulkErr state.BulkStoreError
+		require.ErrorAs(t, err, &bulkErr)
+		assert.Equal(t, "failing", bulkErr.Key())
+		assert.ErrorContains(t, err, "fake-set-error")
+	})
+
 	t.Run("bulkDelete should send a bulkDeleteRequest containing all deleted items", func(t *testing.T) {
 		const fakeKey, otherFakeKey = "fakeKey", "otherFakeKey"
 		requests := []state.DeleteRequest{
@@ -689,6 +856,45 @@ func TestComponentCalls(t *testing.T) {
 		assert.Equal(t, int64(1), svc.transactCalled.Load())
 	})
 
+	t.Run("transact should return a feature not supported error when the component doesn't implement it", func(t *testing.T) {
+		svc := &server{
+			transactErr: status.Error(codes.Unimplemented, "method Transact not implemented"),
+		}
+		stStore, cleanup, err := getStateStore(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		err = stStore.Multi(context.Background(), &state.TransactionalStateRequest{})
+
+		require.ErrorIs(t, err, pluggable.ErrFeatureNotSupported)
+		assert.Contains(t, err.Error(), string(state.FeatureTransactional))
+	})
+
+	t.Run("set should return the component error details sent by the component", func(t *testing.T) {
+		s, err := status.New(codes.ResourceExhausted, "too many requests").WithDetails(&proto.ComponentError{
+			Kind:       "THROTTLED",
+			Resource:   "fakeKey",
+			RetryAfter: durationpb.New(time.Second),
+		})
+		require.NoError(t, err)
+		svc := &server{
+			setErr: s.Err(),
+		}
+		stStore, cleanup, err := getStateStore(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		err = stStore.Set(context.Background(), &state.SetRequest{Key: "fakeKey", Value: "fakeValue"})
+
+		details, ok := pluggable.ExtractDetails(err)
+		require.True(t, ok)
+		assert.Equal(t, "THROTTLED", details.GetKind())
+		assert.Equal(t, "fakeKey", details.GetResource())
+		var detailedErr *pluggable.DetailedError
+		require.ErrorAs(t, err, &detailedErr)
+		assert.Equal(t, time.Second, detailedErr.RetryAfter())
+	})
+
 	t.Run("transact should send a transact containing all operations", func(t *testing.T) {
 		const fakeKey, otherFakeKey, fakeData = "fakeKey", "otherFakeKey", "fakeData"
 		operations := []state.SetRequest{
@@ -736,6 +942,21 @@ func TestComponentCalls(t *testing.T) {
 		assert.Equal(t, int64(1), svc.queryCalled.Load())
 	})
 
+	t.Run("query should return a feature not supported error when the component doesn't implement it", func(t *testing.T) {
+		svc := &server{
+			queryErr: status.Error(codes.Unimplemented, "method Query not implemented"),
+		}
+		stStore, cleanup, err := getStateStore(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		resp, err := stStore.Query(context.Background(), &state.QueryRequest{})
+
+		require.ErrorIs(t, err, pluggable.ErrFeatureNotSupported)
+		assert.Contains(t, err.Error(), string(state.FeatureQueryAPI))
+		assert.Nil(t, resp)
+	})
+
 	t.Run("query should send a QueryRequest containing all filters", func(t *testing.T) {
 		filters := map[string]interface{}{
 			"a": []string{"a"},
@@ -779,6 +1000,131 @@ func TestComponentCalls(t *testing.T) {
 }
 
 //nolint:nosnakecase
+func TestComponentCallsWithTestConnector(t *testing.T) {
+	connectorFor := testingPluggable.TestConnectorFor(testLogger, func(s *grpc.Server, svc *server) {
+		proto.RegisterStateStoreServer(s, svc)
+	}, newStateStoreClient)
+
+	t.Run("get should call the in-memory component", func(t *testing.T) {
+		const fakeKey = "fakeKey"
+
+		svc := &server{
+			onGetCalled: func(req *proto.GetRequest) {
+				assert.Equal(t, fakeKey, req.Key)
+			},
+			getResponse: &proto.GetResponse{
+				Data: []byte("fake-data"),
+			},
+		}
+		connector, cleanup, err := connectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		stStore := fromConnector(testLogger, connector)
+		require.NoError(t, stStore.Init(context.Background(), state.Metadata{}))
+
+		resp, err := stStore.Get(context.Background(), &state.GetRequest{
+			Key: fakeKey,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []byte("fake-data"), resp.Data)
+		assert.Equal(t, int64(1), svc.initCalled.Load())
+		assert.Equal(t, int64(1), svc.getCalled.Load())
+	})
+
+	t.Run("request metadata should be merged over the init metadata", func(t *testing.T) {
+		initMetadata := state.Metadata{
+			Base: contribMetadata.Base{
+				Properties: map[string]string{
+					"partitionKey": "init-partition",
+					"tableName":    "init-table",
+				},
+			},
+		}
+		reqMetadata := map[string]string{
+			"partitionKey": "request-partition",
+			"ttlInSeconds": "10",
+		}
+		expectedMetadata := map[string]string{
+			"partitionKey": "request-partition",
+			"tableName":    "init-table",
+			"ttlInSeconds": "10",
+		}
+
+		svc := &server{
+			onGetCalled: func(req *proto.GetRequest) {
+				assert.Equal(t, expectedMetadata, req.Metadata)
+			},
+			onSetCalled: func(req *proto.SetRequest) {
+				assert.Equal(t, expectedMetadata, req.Metadata)
+			},
+			getResponse: &proto.GetResponse{},
+		}
+		connector, cleanup, err := connectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		stStore := fromConnector(testLogger, connector)
+		require.NoError(t, stStore.Init(context.Background(), initMetadata))
+
+		_, err = stStore.Get(context.Background(), &state.GetRequest{
+			Key:      "fakeKey",
+			Metadata: reqMetadata,
+		})
+		require.NoError(t, err)
+
+		err = stStore.Set(context.Background(), &state.SetRequest{
+			Key:      "fakeKey",
+			Value:    "fakeValue",
+			Metadata: reqMetadata,
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, int64(1), svc.getCalled.Load())
+		assert.Equal(t, int64(1), svc.setCalled.Load())
+		assert.Equal(t, "init-partition", initMetadata.Properties["partitionKey"], "init metadata should not be modified")
+	})
+
+	t.Run("init should call the component init only once", func(t *testing.T) {
+		svc := &server{}
+		connector, cleanup, err := connectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		stStore := fromConnector(testLogger, connector)
+		require.NoError(t, stStore.Init(context.Background(), state.Metadata{}))
+		require.NoError(t, stStore.Init(context.Background(), state.Metadata{}))
+
+		assert.True(t, stStore.Initialized())
+		assert.Equal(t, int64(1), svc.initCalled.Load())
+		assert.Equal(t, int64(1), svc.featuresCalled.Load())
+	})
+
+	t.Run("init metadata should be sent when the request has no metadata", func(t *testing.T) {
+		svc := &server{
+			onGetCalled: func(req *proto.GetRequest) {
+				assert.Equal(t, map[string]string{"tableName": "init-table"}, req.Metadata)
+			},
+			getResponse: &proto.GetResponse{},
+		}
+		connector, cleanup, err := connectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		stStore := fromConnector(testLogger, connector)
+		require.NoError(t, stStore.Init(context.Background(), state.Metadata{
+			Base: contribMetadata.Base{
+				Properties: map[string]string{"tableName": "init-table"},
+			},
+		}))
+
+		_, err = stStore.Get(context.Background(), &state.GetRequest{Key: "fakeKey"})
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), svc.getCalled.Load())
+	})
+}
+
 func TestMappers(t *testing.T) {
 	t.Run("consistencyOf should return unspecified for unknown consistency", func(t *testing.T) {
 		assert.Equal(t, proto.StateOptions_CONSISTENCY_UNSPECIFIED, consistencyOf(""))
@@ -957,3 +1303,438 @@ func (failingTransactOperation) GetKey() string {
 func (failingTransactOperation) GetMetadata() map[string]string {
 	return nil
 }
+
+// noBulkGetServer is a state store server that doesn't implement bulk get.
+type noBulkGetServer struct {
+	proto.UnimplementedStateStoreServer
+	values      map[string]string
+	inFlight    atomic.Int64
+	maxInFlight atomic.Int64
+}
+
+func (s *noBulkGetServer) Get(_ context.Context, req *proto.GetRequest) (*proto.GetResponse, error) {
+	inFlight := s.inFlight.Add(1)
+	defer s.inFlight.Add(-1)
+	for {
+		maxInFlight := s.maxInFlight.Load()
+		if inFlight <= maxInFlight || s.maxInFlight.CompareAndSwap(maxInFlight, inFlight) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+
+	if req.Key == "failing" {
+		return nil, status.Error(codes.Internal, "fake-get-err")
+	}
+	value, ok := s.values[req.Key]
+	if !ok {
+		return &proto.GetResponse{}, nil
+	}
+	return &proto.GetResponse{Data: []byte(value)}, nil
+}
+
+// singleKeyServer is a state store server that only implements single-key operations.
+type singleKeyServer struct {
+	proto.UnimplementedStateStoreServer
+	lock    sync.Mutex
+	set     []string
+	deleted []string
+}
+
+func (s *singleKeyServer) Set(_ context.Context, req *proto.SetRequest) (*proto.SetResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if req.Key == "failing" {
+		return nil, errors.New("fake-set-error")
+	}
+	s.set = append(s.set, req.Key)
+	return &proto.SetResponse{Etag: &proto.Etag{Value: "etag-" + req.Key}}, nil
+}
+
+func (s *singleKeyServer) Delete(_ context.Context, req *proto.DeleteRequest) (*proto.DeleteResponse, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.deleted = append(s.deleted, req.Key)
+	return &proto.DeleteResponse{}, nil
+}
+
+func TestBulkFallback(t *testing.T) {
+	connectorFor := testingPluggable.TestConnectorFor(testLogger, func(s *grpc.Server, svc *singleKeyServer) {
+		proto.RegisterStateStoreServer(s, svc)
+	}, newStateStoreClient)
+
+	t.Run("bulk set should be emulated through set when the component doesn't implement it", func(t *testing.T) {
+		svc := &singleKeyServer{}
+		connector, cleanup, err := connectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+		stStore := fromConnector(testLogger, connector)
+
+		require.NoError(t, stStore.BulkSet(context.Background(), []state.SetRequest{
+			{Key: "key1", Value: "value1"},
+			{Key: "key2", Value: "value2"},
+		}, state.BulkStoreOpts{}))
+		assert.ElementsMatch(t, []string{"key1", "key2"}, svc.set)
+	})
+
+	t.Run("emulated bulk set should return the result of each item with the etag of the set ones", func(t *testing.T) {
+		svc := &singleKeyServer{}
+		connector, cleanup, err := connectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+		stStore := fromConnector(testLogger, connector)
+
+		results, err := stStore.BulkSetWithETags(context.Background(), []state.SetRequest{
+			{Key: "key1", Value: "value1"},
+			{Key: "failing", Value: "value2"},
+			{Key: "key3", Value: "value3"},
+		}, state.BulkStoreOpts{Parallelism: 2})
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		assert.Equal(t, BulkSetResult{Key: "key1", ETag: "etag-key1"}, results[0])
+		assert.Equal(t, "failing", results[1].Key)
+		assert.Empty(t, results[1].ETag)
+		assert.ErrorContains(t, results[1].Err, "fake-set-error")
+		assert.Equal(t, BulkSetResult{Key: "key3", ETag: "etag-key3"}, results[2])
+	})
+
+	t.Run("bulk delete should be emulated through delete when the component doesn't implement it", func(t *testing.T) {
+		svc := &singleKeyServer{}
+		connector, cleanup, err := connectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+		stStore := fromConnector(testLogger, connector)
+
+		require.NoError(t, stStore.BulkDelete(context.Background(), []state.DeleteRequest{
+			{Key: "key1"},
+			{Key: "key2"},
+		}, state.BulkStoreOpts{}))
+		assert.ElementsMatch(t, []string{"key1", "key2"}, svc.deleted)
+	})
+}
+
+func TestBulkGetFanOut(t *testing.T) {
+	connectorFor := testingPluggable.TestConnectorFor(testLogger, func(s *grpc.Server, svc *noBulkGetServer) {
+		proto.RegisterStateStoreServer(s, svc)
+	}, newStateStoreClient)
+
+	keys := []string{"hit1", "miss1", "hit2", "failing", "miss2", "hit3", "hit4", "miss3"}
+	requests := make([]state.GetRequest, len(keys))
+	for idx, key := range keys {
+		requests[idx] = state.GetRequest{Key: key}
+	}
+
+	newServer := func() *noBulkGetServer {
+		return &noBulkGetServer{
+			values: map[string]string{
+				"hit1": "value1",
+				"hit2": "value2",
+				"hit3": "value3",
+				"hit4": "value4",
+			},
+		}
+	}
+
+	assertResults := func(t *testing.T, results []state.BulkGetResponse) {
+		require.Len(t, results, len(keys))
+		for idx, key := range keys {
+			result := results[idx]
+			assert.Equal(t, key, result.Key, "results should preserve the requests order")
+			switch {
+			case key == "failing":
+				assert.Contains(t, result.Error, "fake-get-err")
+			case strings.HasPrefix(key, "hit"):
+				assert.Empty(t, result.Error)
+				assert.Equal(t, "value"+strings.TrimPrefix(key, "hit"), string(result.Data))
+			default:
+				assert.Empty(t, result.Error)
+				assert.Empty(t, result.Data)
+			}
+		}
+	}
+
+	t.Run("bulk get should fan out bounded by the request parallelism", func(t *testing.T) {
+		svc := newServer()
+		connector, cleanup, err := connectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+		stStore := fromConnector(testLogger, connector)
+
+		results, err := stStore.BulkGet(context.Background(), requests, state.BulkGetOpts{Parallelism: 2})
+		require.NoError(t, err)
+
+		assertResults(t, results)
+		assert.LessOrEqual(t, svc.maxInFlight.Load(), int64(2))
+	})
+
+	t.Run("bulk get should fan out bounded by the component concurrency when parallelism is not set", func(t *testing.T) {
+		svc := newServer()
+		connector, cleanup, err := connectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+		stStore := fromConnector(testLogger, connector)
+		stStore.bulkGetConcurrency = 3
+
+		results, err := stStore.BulkGet(context.Background(), requests, state.BulkGetOpts{})
+		require.NoError(t, err)
+
+		assertResults(t, results)
+		assert.LessOrEqual(t, svc.maxInFlight.Load(), int64(3))
+	})
+
+	t.Run("invalid bulk get concurrency should return an error", func(t *testing.T) {
+		_, err := bulkGetConcurrencyOf(map[string]string{bulkGetConcurrencyMetadataKey: "not-a-number"})
+		assert.Error(t, err)
+		_, err = bulkGetConcurrencyOf(map[string]string{bulkGetConcurrencyMetadataKey: "-1"})
+		assert.Error(t, err)
+		concurrency, err := bulkGetConcurrencyOf(map[string]string{bulkGetConcurrencyMetadataKey: "5"})
+		require.NoError(t, err)
+		assert.Equal(t, 5, concurrency)
+	})
+}
+
+func TestComponentCallsWithFakeServer(t *testing.T) {
+	connectorFor := testingPluggable.TestConnectorFor(testLogger, func(s *grpc.Server, svc *testingPluggable.FakeStateServer) {
+		svc.Register(s)
+	}, newStateStoreClient)
+
+	t.Run("set should return etag mismatch err when the component returns etag mismatch", func(t *testing.T) {
+		st, err := status.New(GRPCCodeETagMismatch, "fake-err-msg").WithDetails(&errdetails.BadRequest{
+			FieldViolations: []*errdetails.BadRequest_FieldViolation{{
+				Field:       etagField,
+				Description: "etag mismatch",
+			}},
+		})
+		require.NoError(t, err)
+
+		svc := testingPluggable.NewFakeStateServer(testingPluggable.WithFakeError("Set", st.Err()))
+		connector, cleanup, err := connectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		stStore := fromConnector(testLogger, connector)
+		require.NoError(t, stStore.Init(context.Background(), state.Metadata{}))
+
+		err = stStore.Set(context.Background(), &state.SetRequest{
+			Key:   "fakeKey",
+			Value: "fakeValue",
+		})
+		var etagErr *state.ETagError
+		require.ErrorAs(t, err, &etagErr)
+		assert.Equal(t, state.ETagMismatch, etagErr.Kind())
+		require.Len(t, svc.Requests("Set"), 1)
+		assert.Equal(t, "fakeKey", svc.Requests("Set")[0].(*proto.SetRequest).Key)
+	})
+}
+
+func TestReinit(t *testing.T) {
+	connectorFor := testingPluggable.TestConnectorFor(testLogger, func(s *grpc.Server, svc *server) {
+		proto.RegisterStateStoreServer(s, svc)
+	}, newStateStoreClient)
+
+	t.Run("reinit should send a new init request and adopt the new metadata when succeeded", func(t *testing.T) {
+		svc := &server{}
+		connector, cleanup, err := connectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		stStore := fromConnector(testLogger, connector)
+		require.NoError(t, stStore.Init(context.Background(), state.Metadata{Base: contribMetadata.Base{
+			Name:       "reinit",
+			Properties: map[string]string{"connectionString": "old"},
+		}}))
+
+		var received *proto.InitRequest
+		svc.onInitCalled = func(req *proto.InitRequest) {
+			received = req
+		}
+		require.NoError(t, stStore.Reinit(context.Background(), state.Metadata{Base: contribMetadata.Base{
+			Name:       "reinit",
+			Properties: map[string]string{"connectionString": "new", bulkGetConcurrencyMetadataKey: "2"},
+		}}))
+
+		assert.Equal(t, int64(2), svc.initCalled.Load())
+		require.NotNil(t, received)
+		assert.Equal(t, "new", received.Metadata.Properties["connectionString"])
+		assert.Equal(t, map[string]string{"connectionString": "new", bulkGetConcurrencyMetadataKey: "2"}, stStore.withInitMetadata(nil))
+		assert.Equal(t, 2, stStore.bulkGetConcurrency)
+	})
+
+	t.Run("init request should include the app identity", func(t *testing.T) {
+		pluggable.SetAppIdentity("myapp", "mynamespace")
+		defer pluggable.SetAppIdentity("", "")
+
+		var received *proto.InitRequest
+		svc := &server{onInitCalled: func(req *proto.InitRequest) {
+			received = req
+		}}
+		connector, cleanup, err := connectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		stStore := fromConnector(testLogger, connector)
+		require.NoError(t, stStore.Init(context.Background(), state.Metadata{Base: contribMetadata.Base{
+			Name:       "identity",
+			Properties: map[string]string{"connectionString": "fake"},
+		}}))
+
+		require.NotNil(t, received)
+		assert.Equal(t, "myapp", received.Metadata.Properties[pluggable.AppIDMetadataKey])
+		assert.Equal(t, "mynamespace", received.Metadata.Properties[pluggable.NamespaceMetadataKey])
+		assert.Equal(t, "fake", received.Metadata.Properties["connectionString"])
+		assert.Equal(t, map[string]string{"connectionString": "fake"}, stStore.withInitMetadata(nil))
+	})
+
+	t.Run("reinit should re-fetch the component features", func(t *testing.T) {
+		svc := &server{featuresResponse: &proto.FeaturesResponse{Features: []string{string(state.FeatureETag)}}}
+		connector, cleanup, err := connectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		stStore := fromConnector(testLogger, connector)
+		require.NoError(t, stStore.Init(context.Background(), state.Metadata{Base: contribMetadata.Base{Name: "reinit"}}))
+		assert.Equal(t, []state.Feature{state.FeatureETag}, stStore.Features())
+
+		svc.featuresResponse = &proto.FeaturesResponse{Features: []string{string(state.FeatureETag), string(state.FeatureTTL)}}
+		require.NoError(t, stStore.Reinit(context.Background(), state.Metadata{Base: contribMetadata.Base{
+			Name:       "reinit",
+			Properties: map[string]string{"ttlEnabled": "true"},
+		}}))
+
+		assert.Equal(t, int64(2), svc.featuresCalled.Load())
+		assert.Equal(t, []state.Feature{state.FeatureETag, state.FeatureTTL}, stStore.Features())
+	})
+
+	t.Run("reinit should return ErrReinitUnsupported and keep the current metadata when the component replies unimplemented", func(t *testing.T) {
+		svc := &server{}
+		connector, cleanup, err := connectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		stStore := fromConnector(testLogger, connector)
+		require.NoError(t, stStore.Init(context.Background(), state.Metadata{Base: contribMetadata.Base{
+			Name:       "reinit",
+			Properties: map[string]string{"connectionString": "old"},
+		}}))
+
+		svc.initErr = status.Error(codes.Unimplemented, "reinit not supported")
+		err = stStore.Reinit(context.Background(), state.Metadata{Base: contribMetadata.Base{
+			Name:       "reinit",
+			Properties: map[string]string{"connectionString": "new"},
+		}})
+
+		assert.ErrorIs(t, err, pluggable.ErrReinitUnsupported)
+		assert.Equal(t, map[string]string{"connectionString": "old"}, stStore.withInitMetadata(nil))
+	})
+
+	t.Run("reinit should return ErrReinitUnsupported when the component was not initialized", func(t *testing.T) {
+		svc := &server{}
+		connector, cleanup, err := connectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		stStore := fromConnector(testLogger, connector)
+		err = stStore.Reinit(context.Background(), state.Metadata{})
+
+		assert.ErrorIs(t, err, pluggable.ErrReinitUnsupported)
+		assert.Equal(t, int64(0), svc.initCalled.Load())
+	})
+}
+
+func TestSharedSocket(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Logf("skipping state pluggable component shared socket test due to the lack of OS (%s) support", runtime.GOOS)
+		return
+	}
+
+	socket := fmt.Sprintf("/tmp/%s.sock", guuid.New().String())
+	defer os.Remove(socket)
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	var lock sync.Mutex
+	received := map[string]string{}
+	s := grpc.NewServer(grpc.UnaryInterceptor(func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if initReq, ok := req.(*proto.InitRequest); ok {
+			md, _ := metadata.FromIncomingContext(ctx)
+			lock.Lock()
+			for _, instanceID := range md.Get("x-component-instance") {
+				received[instanceID] = initReq.Metadata.Properties["connectionString"]
+			}
+			lock.Unlock()
+		}
+		return handler(ctx, req)
+	}))
+	srv := &server{}
+	proto.RegisterStateStoreServer(s, srv)
+	go func() {
+		if serveErr := s.Serve(listener); serveErr != nil {
+			testLogger.Debugf("Server exited with error: %v", serveErr)
+		}
+	}()
+	defer s.Stop()
+
+	// both components are served by the same pluggable component process through its single socket.
+	storeA := fromConnector(testLogger, pluggable.NewGRPCConnector(socket, newStateStoreClient))
+	defer storeA.Close()
+	storeB := fromConnector(testLogger, pluggable.NewGRPCConnector(socket, newStateStoreClient))
+	defer storeB.Close()
+
+	require.NoError(t, storeA.Init(context.Background(), state.Metadata{Base: contribMetadata.Base{
+		Name:       "statestore-a",
+		Properties: map[string]string{"connectionString": "a"},
+	}}))
+	require.NoError(t, storeB.Init(context.Background(), state.Metadata{Base: contribMetadata.Base{
+		Name:       "statestore-b",
+		Properties: map[string]string{"connectionString": "b"},
+	}}))
+
+	assert.Equal(t, int64(2), srv.initCalled.Load())
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, map[string]string{"statestore-a": "a", "statestore-b": "b"}, received)
+}
+
+func TestDiscoveredComponentMetadataOptions(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Logf("skipping state pluggable component discovery test due to the lack of OS (%s) support", runtime.GOOS)
+		return
+	}
+
+	socketFolder := t.TempDir()
+	t.Setenv(pluggable.SocketFolderEnvVar, socketFolder)
+
+	listener, err := net.Listen("unix", socketFolder+"/discovered.sock")
+	require.NoError(t, err)
+	defer listener.Close()
+
+	s := grpc.NewServer()
+	srv := &server{getResponse: &proto.GetResponse{}}
+	proto.RegisterStateStoreServer(s, srv)
+	reflection.Register(s)
+	go func() {
+		if serveErr := s.Serve(listener); serveErr != nil {
+			testLogger.Debugf("Server exited with error: %v", serveErr)
+		}
+	}()
+	defer s.Stop()
+
+	// the component is registered by the discovery callback, its connector options are only set through its metadata.
+	require.NoError(t, pluggable.Discover(context.Background()))
+	store, err := DefaultRegistry.Create("state.discovered", "v1", "")
+	require.NoError(t, err)
+	defer store.(io.Closer).Close()
+
+	require.NoError(t, store.Init(context.Background(), state.Metadata{Base: contribMetadata.Base{
+		Name:       "statestore",
+		Properties: map[string]string{pluggable.RateLimitMetadataKey: "1"},
+	}}))
+
+	_, err = store.Get(context.Background(), &state.GetRequest{Key: "key"})
+	require.NoError(t, err)
+	_, err = store.Get(context.Background(), &state.GetRequest{Key: "key"})
+	assert.Error(t, err)
+	assert.Equal(t, int64(1), srv.getCalled.Load())
+}
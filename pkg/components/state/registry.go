@@ -16,6 +16,7 @@ package state
 import (
 	"fmt"
 	"strings"
+	"sync"
 
 	"github.com/dapr/components-contrib/state"
 	"github.com/dapr/dapr/pkg/components"
@@ -26,6 +27,8 @@ import (
 type Registry struct {
 	Logger      logger.Logger
 	stateStores map[string]func(logger.Logger) state.Store
+	// lock guards the registered components, which can be changed at runtime by the pluggable components health monitor.
+	lock sync.RWMutex
 	// versionsSet holds a set of component types version information for
 	// component types that have multiple versions.
 	versionsSet map[string]components.Versioning
@@ -45,6 +48,9 @@ func NewRegistry() *Registry {
 
 // RegisterComponent adds a new state store to the registry.
 func (s *Registry) RegisterComponent(componentFactory func(logger.Logger) state.Store, names ...string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	for _, name := range names {
 		s.stateStores[createFullName(name)] = componentFactory
 	}
@@ -52,6 +58,9 @@ func (s *Registry) RegisterComponent(componentFactory func(logger.Logger) state.
 
 // RegisterComponent adds a new state store to the registry.
 func (s *Registry) RegisterComponentWithVersions(name string, versions components.Versioning) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
 	if len(versions.Default) == 0 {
 		// Panicking here is appropriate because this is a programming error, and
 		// will happen at init time when registering components.
@@ -88,6 +97,9 @@ func (s *Registry) Create(name, version, logName string) (state.Store, error) {
 }
 
 func (s *Registry) getStateStore(name, version, logName string) (func() state.Store, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
 	name = strings.ToLower(name)
 	version = strings.ToLower(version)
 
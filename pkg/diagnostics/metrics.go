@@ -40,6 +40,8 @@ var (
 	DefaultComponentMonitoring = newComponentMetrics()
 	// DefaultResiliencyMonitoring holds resiliency specific metrics.
 	DefaultResiliencyMonitoring = newResiliencyMetrics()
+	// DefaultPluggableComponentMonitoring holds pluggable components specific metrics.
+	DefaultPluggableComponentMonitoring = newPluggableComponentMetrics()
 	// Rules holds regex expressions for metrics labels
 	Rules map[string]string
 )
@@ -66,6 +68,10 @@ func InitMetrics(appID, namespace string, rules []config.MetricsRule) error {
 		return err
 	}
 
+	if err := DefaultPluggableComponentMonitoring.Init(appID); err != nil {
+		return err
+	}
+
 	// Set reporting period of views
 	view.SetReportingPeriod(DefaultReportingPeriod)
 	return utils.CreateRulesMap(rules)
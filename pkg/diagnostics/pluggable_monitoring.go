@@ -0,0 +1,103 @@
+package diagnostics
+
+import (
+	"context"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"google.golang.org/grpc/codes"
+
+	diagUtils "github.com/dapr/dapr/pkg/diagnostics/utils"
+)
+
+var (
+	pluggableComponentTypeKey = tag.MustNewKey("component_type")
+	pluggableComponentNameKey = tag.MustNewKey("component_name")
+	codeKey                   = tag.MustNewKey("code")
+	connectionStateKey        = tag.MustNewKey("state")
+
+	// pluggableLatencyDistribution is the rpc latency distribution in seconds.
+	pluggableLatencyDistribution = view.Distribution(0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10)
+	// pluggableConnectionsAggregation is shared so the connections view can be registered again on re-initialization.
+	pluggableConnectionsAggregation = view.LastValue()
+)
+
+// pluggableComponentMetrics holds dapr runtime metrics for pluggable components rpcs.
+type pluggableComponentMetrics struct {
+	rpcErrorsCount *stats.Int64Measure
+	rpcLatency     *stats.Float64Measure
+	connections    *stats.Int64Measure
+	initDuration   *stats.Float64Measure
+
+	appID   string
+	enabled bool
+}
+
+// newPluggableComponentMetrics returns a pluggableComponentMetrics instance with default stats.
+func newPluggableComponentMetrics() *pluggableComponentMetrics {
+	return &pluggableComponentMetrics{
+		rpcErrorsCount: stats.Int64(
+			"pluggable_component/rpc_errors_total",
+			"The number of pluggable component rpcs that returned a non-OK status.",
+			stats.UnitDimensionless),
+		rpcLatency: stats.Float64(
+			"pluggable_component/rpc_latency_seconds",
+			"The latency of the pluggable component rpcs.",
+			stats.UnitSeconds),
+		connections: stats.Int64(
+			"pluggable_component/connections",
+			"The number of pluggable component connections by connectivity state.",
+			stats.UnitDimensionless),
+		initDuration: stats.Float64(
+			"pluggable_component/init_duration_seconds",
+			"The time spent by the pluggable components initializing.",
+			stats.UnitSeconds),
+	}
+}
+
+// Init registers the pluggable component metrics views.
+func (p *pluggableComponentMetrics) Init(appID string) error {
+	p.appID = appID
+	p.enabled = true
+
+	return view.Register(
+		diagUtils.NewMeasureView(p.rpcErrorsCount, []tag.Key{appIDKey, pluggableComponentTypeKey, pluggableComponentNameKey, methodKey, codeKey}, view.Count()),
+		diagUtils.NewMeasureView(p.rpcLatency, []tag.Key{appIDKey, pluggableComponentTypeKey, pluggableComponentNameKey, methodKey, codeKey}, pluggableLatencyDistribution),
+		diagUtils.NewMeasureView(p.connections, []tag.Key{appIDKey, connectionStateKey}, pluggableConnectionsAggregation),
+		diagUtils.NewMeasureView(p.initDuration, []tag.Key{appIDKey, pluggableComponentTypeKey, pluggableComponentNameKey}, pluggableLatencyDistribution),
+	)
+}
+
+// RPCCompleted records the latency of a pluggable component rpc and increments the errors counter when the rpc returned a non-OK status.
+func (p *pluggableComponentMetrics) RPCCompleted(ctx context.Context, componentType, componentName, method string, code codes.Code, elapsed time.Duration) {
+	if !p.enabled {
+		return
+	}
+
+	tags := diagUtils.WithTags(p.rpcLatency.Name(), appIDKey, p.appID, pluggableComponentTypeKey, componentType, pluggableComponentNameKey, componentName, methodKey, method, codeKey, code.String())
+	stats.RecordWithTags(ctx, tags, p.rpcLatency.M(elapsed.Seconds()))
+
+	if code != codes.OK {
+		stats.RecordWithTags(ctx, tags, p.rpcErrorsCount.M(1))
+	}
+}
+
+// ConnectionsChanged records the current number of pluggable component connections in the given connectivity state.
+func (p *pluggableComponentMetrics) ConnectionsChanged(ctx context.Context, state string, count int64) {
+	if !p.enabled {
+		return
+	}
+
+	stats.RecordWithTags(ctx, diagUtils.WithTags(p.connections.Name(), appIDKey, p.appID, connectionStateKey, state), p.connections.M(count))
+}
+
+// InitCompleted records the time spent by a pluggable component on its Init rpc.
+func (p *pluggableComponentMetrics) InitCompleted(ctx context.Context, componentType, componentName string, elapsed time.Duration) {
+	if !p.enabled {
+		return
+	}
+
+	stats.RecordWithTags(ctx, diagUtils.WithTags(p.initDuration.Name(), appIDKey, p.appID, pluggableComponentTypeKey, componentType, pluggableComponentNameKey, componentName), p.initDuration.M(elapsed.Seconds()))
+}
@@ -0,0 +1,105 @@
+package diagnostics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"google.golang.org/grpc/codes"
+)
+
+func pluggableComponentsMetrics() *pluggableComponentMetrics {
+	p := newPluggableComponentMetrics()
+	p.Init("test")
+
+	return p
+}
+
+func TestPluggableComponentRPC(t *testing.T) {
+	const (
+		rpcErrorsViewName  = "pluggable_component/rpc_errors_total"
+		rpcLatencyViewName = "pluggable_component/rpc_latency_seconds"
+	)
+
+	t.Run("record rpc latency", func(t *testing.T) {
+		defer CleanupRegisteredViews(rpcErrorsViewName, rpcLatencyViewName)
+		p := pluggableComponentsMetrics()
+
+		p.RPCCompleted(context.Background(), "state", componentName, "/dapr.proto.components.v1.StateStore/Get", codes.OK, time.Second)
+
+		viewData, _ := view.RetrieveData(rpcLatencyViewName)
+		v := view.Find(rpcLatencyViewName)
+
+		require.Len(t, viewData, 1)
+		allTagsPresent(t, v, viewData[0].Tags)
+		assert.Equal(t, float64(1), viewData[0].Data.(*view.DistributionData).Min)
+	})
+
+	t.Run("record rpc errors only for non-OK codes", func(t *testing.T) {
+		defer CleanupRegisteredViews(rpcErrorsViewName, rpcLatencyViewName)
+		p := pluggableComponentsMetrics()
+
+		p.RPCCompleted(context.Background(), "state", componentName, "/dapr.proto.components.v1.StateStore/Get", codes.OK, time.Millisecond)
+		p.RPCCompleted(context.Background(), "state", componentName, "/dapr.proto.components.v1.StateStore/Get", codes.Unavailable, time.Millisecond)
+
+		viewData, _ := view.RetrieveData(rpcErrorsViewName)
+		v := view.Find(rpcErrorsViewName)
+
+		require.Len(t, viewData, 1)
+		allTagsPresent(t, v, viewData[0].Tags)
+		assert.Equal(t, int64(1), viewData[0].Data.(*view.CountData).Value)
+		assert.Contains(t, viewData[0].Tags, NewTag(codeKey.Name(), codes.Unavailable.String()))
+	})
+}
+
+func TestPluggableComponentConnections(t *testing.T) {
+	const (
+		rpcErrorsViewName   = "pluggable_component/rpc_errors_total"
+		rpcLatencyViewName  = "pluggable_component/rpc_latency_seconds"
+		connectionsViewName = "pluggable_component/connections"
+	)
+
+	t.Run("record connections by state", func(t *testing.T) {
+		defer CleanupRegisteredViews(rpcErrorsViewName, rpcLatencyViewName, connectionsViewName)
+		p := pluggableComponentsMetrics()
+
+		p.ConnectionsChanged(context.Background(), "READY", 2)
+		p.ConnectionsChanged(context.Background(), "IDLE", 1)
+		p.ConnectionsChanged(context.Background(), "READY", 1)
+
+		viewData, _ := view.RetrieveData(connectionsViewName)
+		v := view.Find(connectionsViewName)
+
+		require.Len(t, viewData, 2)
+		for _, row := range viewData {
+			allTagsPresent(t, v, row.Tags)
+			assert.Equal(t, float64(1), row.Data.(*view.LastValueData).Value)
+		}
+	})
+}
+
+func TestPluggableComponentInit(t *testing.T) {
+	const (
+		rpcErrorsViewName    = "pluggable_component/rpc_errors_total"
+		rpcLatencyViewName   = "pluggable_component/rpc_latency_seconds"
+		connectionsViewName  = "pluggable_component/connections"
+		initDurationViewName = "pluggable_component/init_duration_seconds"
+	)
+
+	t.Run("record init duration", func(t *testing.T) {
+		defer CleanupRegisteredViews(rpcErrorsViewName, rpcLatencyViewName, connectionsViewName, initDurationViewName)
+		p := pluggableComponentsMetrics()
+
+		p.InitCompleted(context.Background(), "state", componentName, 2*time.Second)
+
+		viewData, _ := view.RetrieveData(initDurationViewName)
+		v := view.Find(initDurationViewName)
+
+		require.Len(t, viewData, 1)
+		allTagsPresent(t, v, viewData[0].Tags)
+		assert.Equal(t, float64(2), viewData[0].Data.(*view.DistributionData).Min)
+	})
+}
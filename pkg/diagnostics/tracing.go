@@ -226,6 +226,17 @@ func StartInternalCallbackSpan(ctx context.Context, spanName string, parent trac
 	return ctx, span
 }
 
+// StartConsumerSpan starts trace span for a message received from a component, a child of the given parent when it is valid.
+func StartConsumerSpan(ctx context.Context, spanName string, parent trace.SpanContext, attributes map[string]string) (context.Context, trace.Span) {
+	if parent.IsValid() {
+		ctx = trace.ContextWithRemoteSpanContext(ctx, parent)
+	}
+	ctx, span := tracer.Start(ctx, spanName, trace.WithSpanKind(trace.SpanKindConsumer))
+	AddAttributesToSpan(span, attributes)
+
+	return ctx, span
+}
+
 func TraceIDAndStateFromSpan(span trace.Span) (string, string) {
 	var corID, traceState string
 
@@ -17,9 +17,13 @@ import (
 	"bytes"
 	b64 "encoding/base64"
 	"fmt"
+	"sync"
 )
 
-var encryptedStateStores = map[string]ComponentEncryptionKeys{}
+var (
+	encryptedStateStores     = map[string]ComponentEncryptionKeys{}
+	encryptedStateStoresLock sync.RWMutex
+)
 
 const (
 	separator = "||"
@@ -27,6 +31,9 @@ const (
 
 // AddEncryptedStateStore adds an encrypted state store and an associated encryption key to a list.
 func AddEncryptedStateStore(storeName string, keys ComponentEncryptionKeys) bool {
+	encryptedStateStoresLock.Lock()
+	defer encryptedStateStoresLock.Unlock()
+
 	if _, ok := encryptedStateStores[storeName]; ok {
 		return false
 	}
@@ -35,9 +42,29 @@ func AddEncryptedStateStore(storeName string, keys ComponentEncryptionKeys) bool
 	return true
 }
 
+// UpdateEncryptedStateStore sets the encryption keys of a state store, replacing the previous ones if any, e.g. when they are rotated.
+// It returns true when the state store was not encrypted before.
+func UpdateEncryptedStateStore(storeName string, keys ComponentEncryptionKeys) bool {
+	encryptedStateStoresLock.Lock()
+	defer encryptedStateStoresLock.Unlock()
+
+	_, ok := encryptedStateStores[storeName]
+	encryptedStateStores[storeName] = keys
+	return !ok
+}
+
+// encryptionKeysOf returns the encryption keys of the given state store.
+func encryptionKeysOf(storeName string) (ComponentEncryptionKeys, bool) {
+	encryptedStateStoresLock.RLock()
+	defer encryptedStateStoresLock.RUnlock()
+
+	keys, ok := encryptedStateStores[storeName]
+	return keys, ok
+}
+
 // EncryptedStateStore returns a bool that indicates if a state stores supports encryption.
 func EncryptedStateStore(storeName string) bool {
-	_, ok := encryptedStateStores[storeName]
+	_, ok := encryptionKeysOf(storeName)
 	return ok
 }
 
@@ -45,7 +72,7 @@ func EncryptedStateStore(storeName string) bool {
 // The function will append the name of the key to the value for later extraction.
 // If no encryption keys exist, the function will return the bytes unmodified.
 func TryEncryptValue(storeName string, value []byte) ([]byte, error) {
-	keys := encryptedStateStores[storeName]
+	keys, _ := encryptionKeysOf(storeName)
 	enc, err := encrypt(value, keys.Primary)
 	if err != nil {
 		return value, err
@@ -62,7 +89,7 @@ func TryDecryptValue(storeName string, value []byte) ([]byte, error) {
 		return []byte(""), nil
 	}
 
-	keys := encryptedStateStores[storeName]
+	keys, _ := encryptionKeysOf(storeName)
 	// extract the decryption key that should be appended to the value
 	ind := bytes.LastIndex(value, []byte(separator))
 	keyName := string(value[ind+len(separator):])
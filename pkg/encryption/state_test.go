@@ -58,6 +58,40 @@ func TestAddEncryptedStateStore(t *testing.T) {
 	})
 }
 
+func TestUpdateEncryptedStateStore(t *testing.T) {
+	t.Run("state store doesn't exist", func(t *testing.T) {
+		encryptedStateStores = map[string]ComponentEncryptionKeys{}
+		r := UpdateEncryptedStateStore("test", ComponentEncryptionKeys{
+			Primary: Key{
+				Name: "primary",
+				Key:  "1234",
+			},
+		})
+		assert.True(t, r)
+		assert.Equal(t, "primary", encryptedStateStores["test"].Primary.Name)
+	})
+
+	t.Run("state store exists, keys are replaced", func(t *testing.T) {
+		encryptedStateStores = map[string]ComponentEncryptionKeys{}
+		UpdateEncryptedStateStore("test", ComponentEncryptionKeys{
+			Primary: Key{
+				Name: "primary",
+				Key:  "1234",
+			},
+		})
+
+		r := UpdateEncryptedStateStore("test", ComponentEncryptionKeys{
+			Primary: Key{
+				Name: "rotated",
+				Key:  "5678",
+			},
+		})
+
+		assert.False(t, r)
+		assert.Equal(t, "rotated", encryptedStateStores["test"].Primary.Name)
+	})
+}
+
 func TestTryEncryptValue(t *testing.T) {
 	t.Run("state store without keys", func(t *testing.T) {
 		encryptedStateStores = map[string]ComponentEncryptionKeys{}
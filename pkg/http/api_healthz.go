@@ -16,6 +16,7 @@ package http
 import (
 	"net/http"
 
+	"github.com/dapr/dapr/pkg/components/pluggable"
 	"github.com/dapr/dapr/pkg/messages"
 )
 
@@ -37,6 +38,14 @@ func (a *api) constructHealthzEndpoints() []Endpoint {
 			AlwaysAllowed: true,
 			IsHealthCheck: true,
 		},
+		{
+			Methods:       []string{http.MethodGet},
+			Route:         "healthz/startup",
+			Version:       apiVersionV1,
+			Handler:       a.onGetStartupHealthz,
+			AlwaysAllowed: true,
+			IsHealthCheck: true,
+		},
 	}
 }
 
@@ -71,3 +80,15 @@ func (a *api) onGetOutboundHealthz(w http.ResponseWriter, r *http.Request) {
 
 	respondWithEmpty(w)
 }
+
+// onGetStartupHealthz reports whether the required pluggable components completed their startup, it is meant to back a Kubernetes startup probe.
+func (a *api) onGetStartupHealthz(w http.ResponseWriter, r *http.Request) {
+	if !pluggable.StartupComplete() {
+		msg := messages.ErrStartupHealthNotReady
+		respondWithError(w, msg)
+		log.Debug(msg)
+		return
+	}
+
+	respondWithEmpty(w)
+}
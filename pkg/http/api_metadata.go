@@ -20,6 +20,7 @@ import (
 	"github.com/go-chi/chi/v5"
 	"google.golang.org/protobuf/types/known/emptypb"
 
+	"github.com/dapr/dapr/pkg/components/pluggable"
 	"github.com/dapr/dapr/pkg/messages"
 	runtimev1pb "github.com/dapr/dapr/pkg/proto/runtime/v1"
 )
@@ -57,6 +58,7 @@ func (a *api) onGetMetadata() http.HandlerFunc {
 					HTTPEndpoints:        out.HttpEndpoints,
 					RuntimeVersion:       out.RuntimeVersion,
 					EnabledFeatures:      out.EnabledFeatures,
+					PluggableComponents:  pluggable.DefaultRegistry.Snapshot(),
 				}
 
 				// Copy the app connection properties into a custom struct
@@ -140,6 +142,7 @@ type metadataResponse struct {
 	Subscriptions           []metadataResponsePubsubSubscription    `json:"subscriptions,omitempty"`
 	HTTPEndpoints           []*runtimev1pb.MetadataHTTPEndpoint     `json:"httpEndpoints,omitempty"`
 	AppConnectionProperties metadataResponseAppConnectionProperties `json:"appConnectionProperties,omitempty"`
+	PluggableComponents     []pluggable.PluggableStatus             `json:"pluggableComponents,omitempty"`
 }
 
 type metadataResponsePubsubSubscription struct {
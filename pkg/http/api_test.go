@@ -32,6 +32,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 	"github.com/valyala/fasthttp"
+	"google.golang.org/grpc"
 	"google.golang.org/grpc/test/bufconn"
 	apiextensionsV1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -50,7 +51,9 @@ import (
 	httpEndpointsV1alpha1 "github.com/dapr/dapr/pkg/apis/httpEndpoint/v1alpha1"
 	"github.com/dapr/dapr/pkg/apis/resiliency/v1alpha1"
 	"github.com/dapr/dapr/pkg/channel/http"
+	"github.com/dapr/dapr/pkg/components"
 	httpMiddlewareLoader "github.com/dapr/dapr/pkg/components/middleware/http"
+	"github.com/dapr/dapr/pkg/components/pluggable"
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/dapr/pkg/encryption"
@@ -59,11 +62,13 @@ import (
 	"github.com/dapr/dapr/pkg/messages"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	httpMiddleware "github.com/dapr/dapr/pkg/middleware/http"
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
 	"github.com/dapr/dapr/pkg/resiliency"
 	"github.com/dapr/dapr/pkg/runtime/channels"
 	"github.com/dapr/dapr/pkg/runtime/compstore"
 	runtimePubsub "github.com/dapr/dapr/pkg/runtime/pubsub"
 	daprt "github.com/dapr/dapr/pkg/testing"
+	testingPluggable "github.com/dapr/dapr/pkg/testing/pluggable"
 	testtrace "github.com/dapr/dapr/pkg/testing/trace"
 	"github.com/dapr/dapr/utils"
 	"github.com/dapr/kit/logger"
@@ -1959,6 +1964,26 @@ func TestV1MetadataEndpoint(t *testing.T) {
 		mockActors.AssertNumberOfCalls(t, "GetActiveActorsCount", 1)
 	})
 
+	t.Run("Get Metadata with pluggable components", func(t *testing.T) {
+		connector, cleanup, err := testingPluggable.TestConnectorFor(log, func(s *grpc.Server, svc *proto.UnimplementedPubSubServer) {
+			proto.RegisterPubSubServer(s, svc)
+		}, proto.NewPubSubClient, pluggable.WithPluggable(components.Pluggable{Type: components.CategoryPubSub, Name: "my-component"}))(&proto.UnimplementedPubSubServer{})
+		require.NoError(t, err)
+		defer cleanup()
+		require.NoError(t, connector.Dial("my-pluggable"))
+
+		resp := fakeServer.DoRequest("GET", "v1.0/metadata", nil, nil)
+		require.Equal(t, 200, resp.StatusCode)
+
+		var body metadataResponse
+		require.NoError(t, json.Unmarshal(resp.RawBody, &body))
+		require.Len(t, body.PluggableComponents, 1)
+		assert.Equal(t, "pubsub", body.PluggableComponents[0].Type)
+		assert.Equal(t, "my-pluggable", body.PluggableComponents[0].Name)
+		assert.Equal(t, "my-component", body.PluggableComponents[0].Component)
+		assert.NotEmpty(t, body.PluggableComponents[0].State)
+	})
+
 	fakeServer.Shutdown()
 }
 
@@ -4490,6 +4515,26 @@ func TestV1HealthzEndpoint(t *testing.T) {
 		assert.Equal(t, 204, resp.StatusCode)
 	})
 
+	t.Run("Startup Healthz - 500 ERR_STARTUP_HEALTH_NOT_READY", func(t *testing.T) {
+		pluggable.SetRequiredForStartup([]string{"my-component"})
+		t.Cleanup(func() { pluggable.SetRequiredForStartup(nil) })
+
+		apiPath := "v1.0/healthz/startup"
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+
+		assert.Equal(t, 500, resp.StatusCode, "pluggable components startup not complete should return 500")
+		assert.Equal(t, "ERR_STARTUP_HEALTH_NOT_READY", resp.ErrorBody["errorCode"])
+	})
+
+	t.Run("Startup Healthz - 204 No Content", func(t *testing.T) {
+		pluggable.SetRequiredForStartup(nil)
+
+		apiPath := "v1.0/healthz/startup"
+		resp := fakeServer.DoRequest("GET", apiPath, nil, nil)
+
+		assert.Equal(t, 204, resp.StatusCode)
+	})
+
 	fakeServer.Shutdown()
 }
 
@@ -27,6 +27,7 @@ import (
 const (
 	healthzEndpoint         = "healthz"
 	healthzOutboundEndpoint = "healthz/outbound"
+	healthzStartupEndpoint  = "healthz/startup"
 )
 
 func TestAPIAllowlist(t *testing.T) {
@@ -66,7 +67,7 @@ func TestAPIAllowlist(t *testing.T) {
 			valid := e.IsAllowed(allowed, nil)
 			switch e.Route {
 			// healthz endpoints are always allowed
-			case healthzEndpoint, healthzOutboundEndpoint:
+			case healthzEndpoint, healthzOutboundEndpoint, healthzStartupEndpoint:
 				assert.True(t, valid)
 			default:
 				assert.False(t, valid)
@@ -148,7 +149,7 @@ func TestAPIAllowlist(t *testing.T) {
 			valid := e.IsAllowed(allowed, nil)
 			switch e.Route {
 			// healthz endpoints are always allowed
-			case healthzEndpoint, healthzOutboundEndpoint:
+			case healthzEndpoint, healthzOutboundEndpoint, healthzStartupEndpoint:
 				assert.True(t, valid)
 			default:
 				assert.False(t, valid)
@@ -186,7 +187,7 @@ func TestAPIAllowlist(t *testing.T) {
 		for _, e := range allOtherEndpoints {
 			valid := e.IsAllowed(allowed, nil)
 			switch e.Route {
-			case healthzEndpoint, healthzOutboundEndpoint:
+			case healthzEndpoint, healthzOutboundEndpoint, healthzStartupEndpoint:
 				assert.True(t, valid)
 			default:
 				assert.False(t, valid)
@@ -225,7 +226,7 @@ func TestAPIAllowlist(t *testing.T) {
 			valid := e.IsAllowed(allowed, nil)
 			switch e.Route {
 			// healthz endpoints are always allowed
-			case healthzEndpoint, healthzOutboundEndpoint:
+			case healthzEndpoint, healthzOutboundEndpoint, healthzStartupEndpoint:
 				assert.True(t, valid)
 			default:
 				assert.False(t, valid)
@@ -264,7 +265,7 @@ func TestAPIAllowlist(t *testing.T) {
 			valid := e.IsAllowed(allowed, nil)
 			switch e.Route {
 			// healthz endpoints are always allowed
-			case healthzEndpoint, healthzOutboundEndpoint:
+			case healthzEndpoint, healthzOutboundEndpoint, healthzStartupEndpoint:
 				assert.True(t, valid)
 			default:
 				assert.False(t, valid)
@@ -303,7 +304,7 @@ func TestAPIAllowlist(t *testing.T) {
 			valid := e.IsAllowed(allowed, nil)
 			switch e.Route {
 			// healthz endpoints are always allowed
-			case healthzEndpoint, healthzOutboundEndpoint:
+			case healthzEndpoint, healthzOutboundEndpoint, healthzStartupEndpoint:
 				assert.True(t, valid)
 			default:
 				assert.False(t, valid)
@@ -342,7 +343,7 @@ func TestAPIAllowlist(t *testing.T) {
 			valid := e.IsAllowed(allowed, nil)
 			switch e.Route {
 			// healthz endpoints are always allowed
-			case healthzEndpoint, healthzOutboundEndpoint:
+			case healthzEndpoint, healthzOutboundEndpoint, healthzStartupEndpoint:
 				assert.True(t, valid)
 			default:
 				assert.False(t, valid)
@@ -388,7 +389,7 @@ func TestAPIAllowlist(t *testing.T) {
 			valid := e.IsAllowed(allowed, denied)
 			switch {
 			// healthz endpoints are always allowed
-			case e.Route == healthzEndpoint, e.Route == healthzOutboundEndpoint:
+			case e.Route == healthzEndpoint, e.Route == healthzOutboundEndpoint, e.Route == healthzStartupEndpoint:
 				assert.True(t, valid, e.Route)
 			case strings.HasPrefix(e.Route, "invoke"):
 				assert.True(t, valid, e.Route)
@@ -436,7 +437,7 @@ func TestAPIAllowlist(t *testing.T) {
 			valid := e.IsAllowed(allowed, denied)
 			switch {
 			// healthz endpoints are always allowed
-			case e.Route == healthzEndpoint, e.Route == healthzOutboundEndpoint:
+			case e.Route == healthzEndpoint, e.Route == healthzOutboundEndpoint, e.Route == healthzStartupEndpoint:
 				assert.True(t, valid, e.Route)
 			// Only alpha APIs are allowed
 			case strings.HasPrefix(e.Route, "state") && e.Version != "v1.0":
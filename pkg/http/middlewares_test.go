@@ -115,6 +115,14 @@ func TestAPITokenAuthMiddleware(t *testing.T) {
 			assertPass(t, w)
 		})
 
+		t.Run("startup healthz", func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/v1.0/healthz/startup", nil)
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, r)
+
+			assertPass(t, w)
+		})
+
 		t.Run("querystring params are ignored", func(t *testing.T) {
 			r := httptest.NewRequest(http.MethodGet, "/v1.0/healthz?appid=myapp", nil)
 			w := httptest.NewRecorder()
@@ -39,6 +39,8 @@ const (
 	KeyCPULimit                         = "dapr.io/sidecar-cpu-limit"
 	KeyMemoryRequest                    = "dapr.io/sidecar-memory-request"
 	KeyMemoryLimit                      = "dapr.io/sidecar-memory-limit"
+	KeyMemoryPerComponent               = "dapr.io/sidecar-memory-per-component"
+	KeyComponentCount                   = "dapr.io/sidecar-component-count"
 	KeySidecarListenAddresses           = "dapr.io/sidecar-listen-addresses"
 	KeyLivenessProbeDelaySeconds        = "dapr.io/sidecar-liveness-probe-delay-seconds"
 	KeyLivenessProbeTimeoutSeconds      = "dapr.io/sidecar-liveness-probe-timeout-seconds"
@@ -48,8 +50,17 @@ const (
 	KeyReadinessProbeTimeoutSeconds     = "dapr.io/sidecar-readiness-probe-timeout-seconds"
 	KeyReadinessProbePeriodSeconds      = "dapr.io/sidecar-readiness-probe-period-seconds"
 	KeyReadinessProbeThreshold          = "dapr.io/sidecar-readiness-probe-threshold"
+	KeyStartupProbeTimeoutSeconds       = "dapr.io/sidecar-startup-probe-timeout-seconds"
+	KeyStartupProbePeriodSeconds        = "dapr.io/sidecar-startup-probe-period-seconds"
+	KeyStartupProbeThreshold            = "dapr.io/sidecar-startup-probe-threshold"
 	KeySidecarImage                     = "dapr.io/sidecar-image"
 	KeySidecarSeccompProfileType        = "dapr.io/sidecar-seccomp-profile-type"
+	KeySidecarRunAsNonRoot              = "dapr.io/sidecar-run-as-non-root"
+	KeySidecarReadOnlyRootFilesystem    = "dapr.io/sidecar-read-only-root-filesystem"
+	KeySidecarDropAllCapabilities       = "dapr.io/sidecar-drop-all-capabilities"
+	KeySidecarRunAsUser                 = "dapr.io/sidecar-run-as-user"
+	KeySidecarRunAsGroup                = "dapr.io/sidecar-run-as-group"
+	KeySidecarExtraArgs                 = "dapr.io/sidecar-extra-args"
 	KeyHTTPMaxRequestSize               = "dapr.io/http-max-request-size"
 	KeyHTTPReadBufferSize               = "dapr.io/http-read-buffer-size"
 	KeyGracefulShutdownSeconds          = "dapr.io/graceful-shutdown-seconds"
@@ -66,6 +77,7 @@ const (
 	KeyPlacementHostAddresses           = "dapr.io/placement-host-address"
 	KeyPluggableComponents              = "dapr.io/pluggable-components"
 	KeyPluggableComponentsSocketsFolder = "dapr.io/pluggable-components-sockets-folder"
+	KeyPluggableComponentsVolumeClasses = "dapr.io/pluggable-components-volume-classes"
 	KeyPluggableComponentContainer      = "dapr.io/component-container"
 	KeyPluggableComponentsInjection     = "dapr.io/inject-pluggable-components"
 	KeyAppChannel                       = "dapr.io/app-channel-address"
@@ -28,6 +28,7 @@ const (
 	SidecarMetricsPortName         = "dapr-metrics"
 	SidecarDebugPortName           = "dapr-debug"
 	SidecarHealthzPath             = "healthz"
+	SidecarStartupHealthzPath      = "healthz/startup"
 	SidecarInjectedLabel           = "dapr.io/sidecar-injected"
 	SidecarAppIDLabel              = "dapr.io/app-id"
 	SidecarMetricsEnabledLabel     = "dapr.io/metrics-enabled"
@@ -42,6 +43,7 @@ const (
 	ComponentsUDSVolumeName        = "dapr-components-unix-domain-socket"   // Name of the Unix domain socket volume for components.
 	ComponentsUDSMountPathEnvVar   = "DAPR_COMPONENT_SOCKETS_FOLDER"
 	ComponentsUDSDefaultFolder     = "/tmp/dapr-components-sockets"
+	ComponentsUDSClassEnvVarPrefix = "DAPR_PLUGGABLE_SOCKETS_FOLDER_" // Prefix of the variables exposed to daprd and pluggable components containing the socket folder of each volume class.
 
 	ModeKubernetes = modes.KubernetesMode // KubernetesMode is a Kubernetes Dapr mode.
 	ModeStandalone = modes.StandaloneMode // StandaloneMode is a Standalone Dapr mode.
@@ -14,6 +14,7 @@ limitations under the License.
 package patcher
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
 
@@ -32,6 +33,33 @@ const (
 	PatchPathLabels = "/metadata/labels"
 )
 
+const (
+	// DefaultMaxEnvVars is the default max number of environment variables a container can have once patched.
+	DefaultMaxEnvVars = 1000
+	// DefaultMaxEnvVarsSize is the default max aggregate size in bytes of the environment variables of a container once patched.
+	// The environment is passed to the container process on exec, which fails when the environment and the arguments exceed the system limit, 2MiB by default on Linux.
+	DefaultMaxEnvVarsSize = 1 << 20
+)
+
+// EnvLimits are the limits the environment variables of a container must fit in once patched, zero means the default.
+type EnvLimits struct {
+	// MaxCount is the max number of environment variables.
+	MaxCount int
+	// MaxSize is the max aggregate size in bytes of the environment variables names and values.
+	MaxSize int
+}
+
+// orDefault returns the limits with the default value set for the unset ones.
+func (l EnvLimits) orDefault() EnvLimits {
+	if l.MaxCount <= 0 {
+		l.MaxCount = DefaultMaxEnvVars
+	}
+	if l.MaxSize <= 0 {
+		l.MaxSize = DefaultMaxEnvVarsSize
+	}
+	return l
+}
+
 // NewPatchOperation returns a jsonpatch.Operation with the provided properties.
 // This patch represents a discrete change to be applied to a Kubernetes resource.
 func NewPatchOperation(op string, path string, value any) jsonpatch.Operation {
@@ -50,6 +78,32 @@ func NewPatchOperation(op string, path string, value any) jsonpatch.Operation {
 	return patchOp
 }
 
+// MarshalPatch returns the canonical JSON encoding of the given patch.
+// Operation values are re-encoded with sorted object keys and without insignificant whitespace,
+// so logically identical patches are always serialized to the same bytes.
+func MarshalPatch(patch jsonpatch.Patch) ([]byte, error) {
+	canonical := make([]map[string]any, len(patch))
+	for i, op := range patch {
+		canonicalOp := make(map[string]any, len(op))
+		for k, raw := range op {
+			if raw == nil {
+				canonicalOp[k] = nil
+				continue
+			}
+			dec := json.NewDecoder(bytes.NewReader(*raw))
+			dec.UseNumber()
+			var v any
+			if err := dec.Decode(&v); err != nil {
+				return nil, fmt.Errorf("invalid value for patch operation field '%s': %w", k, err)
+			}
+			canonicalOp[k] = v
+		}
+		canonical[i] = canonicalOp
+	}
+	// maps are always encoded with sorted keys.
+	return json.Marshal(canonical)
+}
+
 // GetEnvPatchOperations adds new environment variables only if they do not exist.
 // It does not override existing values for those variables if they have been defined already.
 func GetEnvPatchOperations(envs []corev1.EnvVar, addEnv []corev1.EnvVar, containerIdx int) jsonpatch.Patch {
@@ -85,6 +139,37 @@ func GetEnvPatchOperations(envs []corev1.EnvVar, addEnv []corev1.EnvVar, contain
 	return patchOps[:n]
 }
 
+// ValidateEnvLimits returns an error when the environment variables of a container exceed the given limits once the given ones are added
+// the way GetEnvPatchOperations does, so that the patch is rejected with a clear error instead of failing on admission or on exec.
+// The size of the variables set from a source, e.g. a secret, only accounts for their name as their value is not known.
+func ValidateEnvLimits(envs []corev1.EnvVar, addEnv []corev1.EnvVar, limits EnvLimits) error {
+	limits = limits.orDefault()
+
+	existing := make(map[string]struct{}, len(envs))
+	count, size := 0, 0
+	add := func(env corev1.EnvVar) {
+		existing[env.Name] = struct{}{}
+		count++
+		size += len(env.Name) + len(env.Value)
+	}
+	for _, env := range envs {
+		add(env)
+	}
+	for _, env := range addEnv {
+		if _, ok := existing[env.Name]; !ok {
+			add(env)
+		}
+	}
+
+	if count > limits.MaxCount {
+		return fmt.Errorf("too many environment variables: %d once injected, the limit is %d", count, limits.MaxCount)
+	}
+	if size > limits.MaxSize {
+		return fmt.Errorf("environment variables too large: %d bytes once injected, the limit is %d bytes", size, limits.MaxSize)
+	}
+	return nil
+}
+
 // GetVolumeMountPatchOperations gets the patch operations for volume mounts
 func GetVolumeMountPatchOperations(volumeMounts []corev1.VolumeMount, addMounts []corev1.VolumeMount, containerIdx int) jsonpatch.Patch {
 	path := fmt.Sprintf("%s/%d/volumeMounts", PatchPathContainers, containerIdx)
@@ -0,0 +1,130 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patcher
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/dapr/kit/ptr"
+)
+
+func TestMarshalPatch(t *testing.T) {
+	const path = "/spec/containers/0/resources"
+
+	t.Run("equivalent resource requirements patches should serialize identically", func(t *testing.T) {
+		typed := jsonpatch.Patch{
+			NewPatchOperation("add", path, corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+					corev1.ResourceCPU:    resource.MustParse("500m"),
+				},
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("100m"),
+				},
+			}),
+		}
+		// the same patch, with keys in a different order and extra whitespace.
+		raw := jsonpatch.Patch{
+			{
+				"value": ptr.Of(json.RawMessage(`{ "requests": {"cpu": "100m"}, "limits": {"memory": "1Gi", "cpu": "500m"} }`)),
+				"path":  ptr.Of(json.RawMessage(`"` + path + `"`)),
+				"op":    ptr.Of(json.RawMessage(`"add"`)),
+			},
+		}
+
+		typedJSON, err := MarshalPatch(typed)
+		require.NoError(t, err)
+		rawJSON, err := MarshalPatch(raw)
+		require.NoError(t, err)
+
+		assert.Equal(t, string(typedJSON), string(rawJSON))
+		assert.JSONEq(t, `[{"op":"add","path":"`+path+`","value":{"limits":{"cpu":"500m","memory":"1Gi"},"requests":{"cpu":"100m"}}}]`, string(typedJSON))
+	})
+
+	t.Run("marshaled patch should be applicable as a json patch", func(t *testing.T) {
+		patch := jsonpatch.Patch{
+			NewPatchOperation("add", PatchPathLabels, map[string]string{"b": "2", "a": "1"}),
+		}
+		patchJSON, err := MarshalPatch(patch)
+		require.NoError(t, err)
+
+		decoded, err := jsonpatch.DecodePatch(patchJSON)
+		require.NoError(t, err)
+		patched, err := decoded.Apply([]byte(`{"metadata":{}}`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"metadata":{"labels":{"a":"1","b":"2"}}}`, string(patched))
+	})
+
+	t.Run("large numbers should keep their precision", func(t *testing.T) {
+		patch := jsonpatch.Patch{
+			NewPatchOperation("add", "/spec/activeDeadlineSeconds", int64(9007199254740993)),
+		}
+		patchJSON, err := MarshalPatch(patch)
+		require.NoError(t, err)
+		assert.Contains(t, string(patchJSON), "9007199254740993")
+	})
+}
+
+func TestValidateEnvLimits(t *testing.T) {
+	existing := []corev1.EnvVar{
+		{Name: "A", Value: "12345"},
+		{Name: "B", Value: "12345"},
+	}
+	added := []corev1.EnvVar{
+		{Name: "B", Value: "overridden"},
+		{Name: "C", Value: "12345"},
+	}
+
+	t.Run("environment variables at the limits should be allowed", func(t *testing.T) {
+		require.NoError(t, ValidateEnvLimits(existing, added, EnvLimits{MaxCount: 3, MaxSize: 18}))
+	})
+
+	t.Run("environment variables over the max count should be rejected", func(t *testing.T) {
+		err := ValidateEnvLimits(existing, added, EnvLimits{MaxCount: 2, MaxSize: 18})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "3 once injected, the limit is 2")
+	})
+
+	t.Run("environment variables over the max size should be rejected", func(t *testing.T) {
+		err := ValidateEnvLimits(existing, added, EnvLimits{MaxCount: 3, MaxSize: 17})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "18 bytes once injected, the limit is 17 bytes")
+	})
+
+	t.Run("variables set from a source should only account for their name", func(t *testing.T) {
+		fromSecret := []corev1.EnvVar{{Name: "SECRET", ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{Key: "key"}}}}
+		require.NoError(t, ValidateEnvLimits(nil, fromSecret, EnvLimits{MaxSize: 6}))
+	})
+
+	t.Run("the default limits should be used when not set", func(t *testing.T) {
+		many := make([]corev1.EnvVar, DefaultMaxEnvVars)
+		for i := range many {
+			many[i] = corev1.EnvVar{Name: "VAR_" + strconv.Itoa(i)}
+		}
+		require.NoError(t, ValidateEnvLimits(many, nil, EnvLimits{}))
+		require.Error(t, ValidateEnvLimits(many, []corev1.EnvVar{{Name: "ONE_MORE"}}, EnvLimits{}))
+
+		large := []corev1.EnvVar{{Name: "LARGE", Value: strings.Repeat("x", DefaultMaxEnvVarsSize)}}
+		require.Error(t, ValidateEnvLimits(large, nil, EnvLimits{}))
+	})
+}
@@ -49,6 +49,8 @@ type SidecarConfig struct {
 	ReadOnlyRootFilesystem      bool
 	SidecarDropALLCapabilities  bool
 	DisableTokenVolume          bool
+	MaxEnvVars                  int
+	MaxEnvVarsSize              int
 	SidecarHTTPPort             int32 `default:"3500"`
 	SidecarAPIGRPCPort          int32 `default:"50001"`
 	SidecarInternalGRPCPort     int32 `default:"50002"`
@@ -75,6 +77,8 @@ type SidecarConfig struct {
 	SidecarCPULimit                     string `annotation:"dapr.io/sidecar-cpu-limit"`
 	SidecarMemoryRequest                string `annotation:"dapr.io/sidecar-memory-request"`
 	SidecarMemoryLimit                  string `annotation:"dapr.io/sidecar-memory-limit"`
+	SidecarMemoryPerComponent           string `annotation:"dapr.io/sidecar-memory-per-component"`
+	SidecarComponentCount               int    `annotation:"dapr.io/sidecar-component-count"`
 	SidecarListenAddresses              string `annotation:"dapr.io/sidecar-listen-addresses" default:"[::1],127.0.0.1"`
 	SidecarLivenessProbeDelaySeconds    int32  `annotation:"dapr.io/sidecar-liveness-probe-delay-seconds" default:"3"`
 	SidecarLivenessProbeTimeoutSeconds  int32  `annotation:"dapr.io/sidecar-liveness-probe-timeout-seconds" default:"3"`
@@ -84,8 +88,17 @@ type SidecarConfig struct {
 	SidecarReadinessProbeTimeoutSeconds int32  `annotation:"dapr.io/sidecar-readiness-probe-timeout-seconds" default:"3"`
 	SidecarReadinessProbePeriodSeconds  int32  `annotation:"dapr.io/sidecar-readiness-probe-period-seconds" default:"6"`
 	SidecarReadinessProbeThreshold      int32  `annotation:"dapr.io/sidecar-readiness-probe-threshold" default:"3"`
+	SidecarStartupProbeTimeoutSeconds   int32  `annotation:"dapr.io/sidecar-startup-probe-timeout-seconds" default:"3"`
+	SidecarStartupProbePeriodSeconds    int32  `annotation:"dapr.io/sidecar-startup-probe-period-seconds" default:"5"`
+	SidecarStartupProbeThreshold        int32  `annotation:"dapr.io/sidecar-startup-probe-threshold" default:"60"`
 	SidecarImage                        string `annotation:"dapr.io/sidecar-image"`
 	SidecarSeccompProfileType           string `annotation:"dapr.io/sidecar-seccomp-profile-type"`
+	SidecarRunAsNonRoot                 string `annotation:"dapr.io/sidecar-run-as-non-root"`           // Validated when building the patch
+	SidecarReadOnlyRootFilesystem       string `annotation:"dapr.io/sidecar-read-only-root-filesystem"` // Validated when building the patch
+	SidecarDropAllCapabilities          string `annotation:"dapr.io/sidecar-drop-all-capabilities"`     // Validated when building the patch
+	SidecarRunAsUser                    string `annotation:"dapr.io/sidecar-run-as-user"`               // Validated when building the patch
+	SidecarRunAsGroup                   string `annotation:"dapr.io/sidecar-run-as-group"`              // Validated when building the patch
+	SidecarExtraArgs                    string `annotation:"dapr.io/sidecar-extra-args"`                // Validated when building the patch
 	HTTPMaxRequestSize                  *int   `annotation:"dapr.io/http-max-request-size"`
 	HTTPReadBufferSize                  *int   `annotation:"dapr.io/http-read-buffer-size"`
 	GracefulShutdownSeconds             int    `annotation:"dapr.io/graceful-shutdown-seconds" default:"-1"`
@@ -102,6 +115,7 @@ type SidecarConfig struct {
 	PlacementAddress                    string `annotation:"dapr.io/placement-host-address"`
 	PluggableComponents                 string `annotation:"dapr.io/pluggable-components"`
 	PluggableComponentsSocketsFolder    string `annotation:"dapr.io/pluggable-components-sockets-folder"`
+	PluggableComponentsVolumeClasses    string `annotation:"dapr.io/pluggable-components-volume-classes"`
 	ComponentContainer                  string `annotation:"dapr.io/component-container"`
 	InjectPluggableComponents           bool   `annotation:"dapr.io/inject-pluggable-components"`
 	AppChannelAddress                   string `annotation:"dapr.io/app-channel-address"`
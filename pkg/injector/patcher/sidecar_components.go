@@ -50,9 +50,11 @@ func (c *SidecarConfig) splitContainers() (appContainers map[int]corev1.Containe
 }
 
 // componentsPatchOps returns the patch operations required to properly bootstrap the pluggable component and the respective volume mount for the sidecar.
-func (c *SidecarConfig) componentsPatchOps(componentContainers map[int]corev1.Container, injectedContainers []corev1.Container) (jsonpatch.Patch, *corev1.VolumeMount) {
+// Each socket volume class is added as its own volume, mounted on every pluggable component container.
+// An error is returned when the environment variables of a container would exceed the limits, see ValidateEnvLimits.
+func (c *SidecarConfig) componentsPatchOps(componentContainers map[int]corev1.Container, injectedContainers []corev1.Container, socketVolumeClasses []socketVolumeClass) (jsonpatch.Patch, *corev1.VolumeMount, error) {
 	if len(componentContainers) == 0 && len(injectedContainers) == 0 {
-		return jsonpatch.Patch{}, nil
+		return jsonpatch.Patch{}, nil, nil
 	}
 
 	patches := make(jsonpatch.Patch, 0, (len(injectedContainers)+len(componentContainers)+1)*2)
@@ -62,37 +64,49 @@ func (c *SidecarConfig) componentsPatchOps(componentContainers map[int]corev1.Co
 		mountPath = utils.GetEnvOrElse(injectorConsts.ComponentsUDSMountPathEnvVar, injectorConsts.ComponentsUDSDefaultFolder)
 	}
 
-	sharedSocketVolume, sharedSocketVolumeMount, volumePatch := c.addSharedSocketVolume(mountPath)
+	_, sharedSocketVolumeMount, volumePatch := c.addSharedSocketVolume(mountPath)
 	patches = append(patches, volumePatch)
-	componentsEnvVars := []corev1.EnvVar{{
+	classesPatches, classesMounts, classesEnvVars := socketVolumeClassesPatchOps(socketVolumeClasses, mountPath)
+	patches = append(patches, classesPatches...)
+
+	componentsEnvVars := append([]corev1.EnvVar{{
 		Name:  injectorConsts.ComponentsUDSMountPathEnvVar,
 		Value: sharedSocketVolumeMount.MountPath,
-	}}
+	}}, classesEnvVars...)
+	componentsVolumeMounts := append([]corev1.VolumeMount{sharedSocketVolumeMount}, classesMounts...)
 
 	for idx, container := range componentContainers {
+		if err := c.validateEnvLimits(container, componentsEnvVars); err != nil {
+			return nil, nil, err
+		}
 		patches = append(patches, GetEnvPatchOperations(container.Env, componentsEnvVars, idx)...)
-		patches = append(patches, GetVolumeMountPatchOperations(container.VolumeMounts, []corev1.VolumeMount{sharedSocketVolumeMount}, idx)...)
+		patches = append(patches, GetVolumeMountPatchOperations(container.VolumeMounts, componentsVolumeMounts, idx)...)
 	}
 
-	podVolumes := make(map[string]bool, len(c.pod.Spec.Volumes)+1)
-	podVolumes[sharedSocketVolume.Name] = true
+	podVolumes := make(map[string]bool, len(c.pod.Spec.Volumes)+len(componentsVolumeMounts))
+	for _, mount := range componentsVolumeMounts {
+		podVolumes[mount.Name] = true
+	}
 	for _, volume := range c.pod.Spec.Volumes {
 		podVolumes[volume.Name] = true
 	}
 
 	for _, container := range injectedContainers {
+		if err := c.validateEnvLimits(container, componentsEnvVars); err != nil {
+			return nil, nil, err
+		}
 		container.Env = append(container.Env, componentsEnvVars...)
 		// mount volume as empty dir by default.
 		_, patch := emptyVolumePatches(container, podVolumes)
 		patches = append(patches, patch...)
-		container.VolumeMounts = append(container.VolumeMounts, sharedSocketVolumeMount)
+		container.VolumeMounts = append(container.VolumeMounts, componentsVolumeMounts...)
 
 		patches = append(patches,
 			NewPatchOperation("add", PatchPathContainers+"/-", container),
 		)
 	}
 
-	return patches, &sharedSocketVolumeMount
+	return patches, &sharedSocketVolumeMount, nil
 }
 
 // Injectable parses the container definition from components annotations returning them as a list. Uses the appID to filter
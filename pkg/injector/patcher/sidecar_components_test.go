@@ -14,19 +14,21 @@ limitations under the License.
 package patcher
 
 import (
-	"encoding/json"
 	"fmt"
 	"testing"
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	commonapi "github.com/dapr/dapr/pkg/apis/common"
 	componentsapi "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
 	"github.com/dapr/dapr/pkg/injector/annotations"
 	injectorConsts "github.com/dapr/dapr/pkg/injector/consts"
+	"github.com/dapr/kit/ptr"
 )
 
 func TestComponentsPatch(t *testing.T) {
@@ -218,6 +220,68 @@ func TestComponentsPatch(t *testing.T) {
 			},
 			&socketSharedVolumeMount,
 		},
+		{
+			"patch should add a volume, a volume mount and an env var for each socket volume class",
+			"",
+			[]componentsapi.Component{},
+			&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotations.KeyPluggableComponents:              "component",
+						annotations.KeyPluggableComponentsVolumeClasses: "fast:Memory:64Mi,standard",
+					},
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{appContainer, {
+						Name: "component",
+					}},
+				},
+			},
+			jsonpatch.Patch{
+				NewPatchOperation("add", PatchPathVolumes, []corev1.Volume{sharedComponentsSocketVolume()}),
+				NewPatchOperation("add", PatchPathVolumes+"/-", corev1.Volume{
+					Name: injectorConsts.ComponentsUDSVolumeName + "-fast",
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{
+							Medium:    corev1.StorageMediumMemory,
+							SizeLimit: ptr.Of(resource.MustParse("64Mi")),
+						},
+					},
+				}),
+				NewPatchOperation("add", PatchPathVolumes+"/-", corev1.Volume{
+					Name: injectorConsts.ComponentsUDSVolumeName + "-standard",
+					VolumeSource: corev1.VolumeSource{
+						EmptyDir: &corev1.EmptyDirVolumeSource{},
+					},
+				}),
+				NewPatchOperation("add", PatchPathContainers+"/1/env", []corev1.EnvVar{
+					{
+						Name:  injectorConsts.ComponentsUDSMountPathEnvVar,
+						Value: socketSharedVolumeMount.MountPath,
+					},
+					{
+						Name:  "DAPR_PLUGGABLE_SOCKETS_FOLDER_FAST",
+						Value: socketSharedVolumeMount.MountPath + "-fast",
+					},
+					{
+						Name:  "DAPR_PLUGGABLE_SOCKETS_FOLDER_STANDARD",
+						Value: socketSharedVolumeMount.MountPath + "-standard",
+					},
+				}),
+				NewPatchOperation("add", PatchPathContainers+"/1/volumeMounts", []corev1.VolumeMount{
+					socketSharedVolumeMount,
+					{
+						Name:      injectorConsts.ComponentsUDSVolumeName + "-fast",
+						MountPath: socketSharedVolumeMount.MountPath + "-fast",
+					},
+					{
+						Name:      injectorConsts.ComponentsUDSVolumeName + "-standard",
+						MountPath: socketSharedVolumeMount.MountPath + "-standard",
+					},
+				}),
+			},
+			&socketSharedVolumeMount,
+		},
 	}
 
 	for _, test := range testCases {
@@ -225,10 +289,11 @@ func TestComponentsPatch(t *testing.T) {
 			c := NewSidecarConfig(test.pod)
 			c.SetFromPodAnnotations()
 			_, componentContainers := c.splitContainers()
-			patch, volumeMount := c.componentsPatchOps(componentContainers, Injectable(test.appID, test.componentsList))
-			patchJSON, _ := json.Marshal(patch)
-			expPatchJSON, _ := json.Marshal(test.expPatch)
-			assert.Equal(t, string(expPatchJSON), string(patchJSON))
+			socketVolumeClasses, err := parseSocketVolumeClasses(c.PluggableComponentsVolumeClasses)
+			require.NoError(t, err)
+			patch, volumeMount, err := c.componentsPatchOps(componentContainers, Injectable(test.appID, test.componentsList), socketVolumeClasses)
+			require.NoError(t, err)
+			AssertPatchEqual(t, test.expPatch, patch)
 			assert.Equal(t, test.expMount, volumeMount)
 		})
 	}
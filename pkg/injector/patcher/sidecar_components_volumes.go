@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patcher
+
+import (
+	"fmt"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	"github.com/dapr/dapr/pkg/injector/annotations"
+	injectorConsts "github.com/dapr/dapr/pkg/injector/consts"
+)
+
+// socketVolumeClass is a class of pluggable components socket volume, each class is mounted as its own emptyDir volume.
+type socketVolumeClass struct {
+	name      string
+	medium    corev1.StorageMedium
+	sizeLimit *resource.Quantity
+}
+
+// parseSocketVolumeClasses parses the socket volume classes annotation.
+// The annotation is a comma-separated list of classes in the 'name[:medium[:sizeLimit]]' format, e.g. 'fast:Memory:64Mi,standard'.
+func parseSocketVolumeClasses(value string) ([]socketVolumeClass, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	classes := make([]socketVolumeClass, 0)
+	seen := make(map[string]bool)
+	for _, classStr := range strings.Split(value, ",") {
+		parts := strings.Split(strings.TrimSpace(classStr), ":")
+		if len(parts) > 3 {
+			return nil, fmt.Errorf("invalid socket volume class '%s' in annotation '%s': must be in the 'name[:medium[:sizeLimit]]' format", classStr, annotations.KeyPluggableComponentsVolumeClasses)
+		}
+
+		class := socketVolumeClass{name: parts[0]}
+		if errs := validation.IsDNS1123Label(class.volumeName()); class.name == "" || len(errs) > 0 {
+			return nil, fmt.Errorf("invalid socket volume class name '%s' in annotation '%s': %s", class.name, annotations.KeyPluggableComponentsVolumeClasses, strings.Join(errs, ", "))
+		}
+		if seen[class.name] {
+			return nil, fmt.Errorf("duplicated socket volume class '%s' in annotation '%s'", class.name, annotations.KeyPluggableComponentsVolumeClasses)
+		}
+		seen[class.name] = true
+
+		if len(parts) > 1 {
+			class.medium = corev1.StorageMedium(parts[1])
+			switch class.medium {
+			case corev1.StorageMediumDefault, corev1.StorageMediumMemory:
+			default:
+				return nil, fmt.Errorf("invalid medium '%s' for socket volume class '%s' in annotation '%s': must be empty or '%s'", parts[1], class.name, annotations.KeyPluggableComponentsVolumeClasses, corev1.StorageMediumMemory)
+			}
+		}
+		if len(parts) > 2 && parts[2] != "" {
+			sizeLimit, err := resource.ParseQuantity(parts[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid size limit '%s' for socket volume class '%s' in annotation '%s': %w", parts[2], class.name, annotations.KeyPluggableComponentsVolumeClasses, err)
+			}
+			class.sizeLimit = &sizeLimit
+		}
+		classes = append(classes, class)
+	}
+	return classes, nil
+}
+
+// volumeName returns the name of the class volume.
+func (s socketVolumeClass) volumeName() string {
+	return injectorConsts.ComponentsUDSVolumeName + "-" + s.name
+}
+
+// volume returns the emptyDir volume of the class.
+func (s socketVolumeClass) volume() corev1.Volume {
+	return corev1.Volume{
+		Name: s.volumeName(),
+		VolumeSource: corev1.VolumeSource{
+			EmptyDir: &corev1.EmptyDirVolumeSource{
+				Medium:    s.medium,
+				SizeLimit: s.sizeLimit,
+			},
+		},
+	}
+}
+
+// volumeMount returns the class volume mount, mounted next to the shared sockets folder.
+func (s socketVolumeClass) volumeMount(socketsFolder string) corev1.VolumeMount {
+	return corev1.VolumeMount{
+		Name:      s.volumeName(),
+		MountPath: socketsFolder + "-" + s.name,
+	}
+}
+
+// envVar returns the environment variable holding the class sockets folder.
+func (s socketVolumeClass) envVar(socketsFolder string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name:  injectorConsts.ComponentsUDSClassEnvVarPrefix + strings.ToUpper(strings.ReplaceAll(s.name, "-", "_")),
+		Value: s.volumeMount(socketsFolder).MountPath,
+	}
+}
+
+// socketVolumeClassesPatchOps returns the patch operations that add the volume of each class to the pod,
+// along with the volume mounts and environment variables to be added to the containers that use them.
+// the pod volumes are expected to exist already, as the shared sockets volume is always added first.
+func socketVolumeClassesPatchOps(classes []socketVolumeClass, socketsFolder string) (jsonpatch.Patch, []corev1.VolumeMount, []corev1.EnvVar) {
+	patches := make(jsonpatch.Patch, 0, len(classes))
+	mounts := make([]corev1.VolumeMount, 0, len(classes))
+	envVars := make([]corev1.EnvVar, 0, len(classes))
+	for _, class := range classes {
+		patches = append(patches, NewPatchOperation("add", PatchPathVolumes+"/-", class.volume()))
+		mounts = append(mounts, class.volumeMount(socketsFolder))
+		envVars = append(envVars, class.envVar(socketsFolder))
+	}
+	return patches, mounts, envVars
+}
@@ -0,0 +1,57 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/dapr/kit/ptr"
+)
+
+func TestParseSocketVolumeClasses(t *testing.T) {
+	t.Run("empty annotation should have no classes", func(t *testing.T) {
+		classes, err := parseSocketVolumeClasses("")
+		require.NoError(t, err)
+		assert.Empty(t, classes)
+	})
+
+	t.Run("classes should be parsed with their medium and size limit", func(t *testing.T) {
+		classes, err := parseSocketVolumeClasses("fast:Memory:64Mi, standard, sized::1Gi")
+		require.NoError(t, err)
+		assert.Equal(t, []socketVolumeClass{
+			{name: "fast", medium: corev1.StorageMediumMemory, sizeLimit: ptr.Of(resource.MustParse("64Mi"))},
+			{name: "standard"},
+			{name: "sized", sizeLimit: ptr.Of(resource.MustParse("1Gi"))},
+		}, classes)
+	})
+
+	t.Run("invalid classes should return an error", func(t *testing.T) {
+		for _, value := range []string{
+			"Fast",
+			"fast,,standard",
+			"fast,fast",
+			"fast:Disk",
+			"fast:Memory:lots",
+			"fast:Memory:64Mi:extra",
+		} {
+			_, err := parseSocketVolumeClasses(value)
+			assert.Error(t, err, value)
+		}
+	})
+}
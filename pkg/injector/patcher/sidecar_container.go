@@ -34,6 +34,8 @@ import (
 type getSidecarContainerOpts struct {
 	VolumeMounts                 []corev1.VolumeMount
 	ComponentsSocketsVolumeMount *corev1.VolumeMount
+	// ComponentsSocketVolumeClasses are mounted next to the components sockets volume mount.
+	ComponentsSocketVolumeClasses []socketVolumeClass
 }
 
 // getSidecarContainer returns the Container object for the sidecar.
@@ -245,6 +247,14 @@ func (c *SidecarConfig) getSidecarContainer(opts getSidecarContainerOpts) (*core
 			PeriodSeconds:       c.SidecarLivenessProbePeriodSeconds,
 			FailureThreshold:    c.SidecarLivenessProbeThreshold,
 		},
+		// The startup probe holds the liveness and readiness probes until the required pluggable components are initialized,
+		// so that long legitimate component inits don't get the sidecar restarted.
+		StartupProbe: &corev1.Probe{
+			ProbeHandler:     getProbeHTTPHandler(c.SidecarPublicPort, injectorConsts.APIVersionV1, injectorConsts.SidecarStartupHealthzPath),
+			TimeoutSeconds:   c.SidecarStartupProbeTimeoutSeconds,
+			PeriodSeconds:    c.SidecarStartupProbePeriodSeconds,
+			FailureThreshold: c.SidecarStartupProbeThreshold,
+		},
 	}
 
 	// If the pod contains any of the tolerations specified by the configuration,
@@ -288,12 +298,21 @@ func (c *SidecarConfig) getSidecarContainer(opts getSidecarContainerOpts) (*core
 		}
 	}
 
+	// The security context annotations are applied last so they take precedence, they can only tighten the injector configuration
+	if err := c.applySecurityContextAnnotations(container.SecurityContext); err != nil {
+		return nil, err
+	}
+
 	if opts.ComponentsSocketsVolumeMount != nil {
 		container.VolumeMounts = append(container.VolumeMounts, *opts.ComponentsSocketsVolumeMount)
 		container.Env = append(container.Env, corev1.EnvVar{
 			Name:  injectorConsts.ComponentsUDSMountPathEnvVar,
 			Value: opts.ComponentsSocketsVolumeMount.MountPath,
 		})
+		for _, class := range opts.ComponentsSocketVolumeClasses {
+			container.VolumeMounts = append(container.VolumeMounts, class.volumeMount(opts.ComponentsSocketsVolumeMount.MountPath))
+			container.Env = append(container.Env, class.envVar(opts.ComponentsSocketsVolumeMount.MountPath))
+		}
 	}
 
 	container.Env = append(container.Env,
@@ -387,6 +406,13 @@ func (c *SidecarConfig) getResourceRequirements() (*corev1.ResourceRequirements,
 		}
 		r.Limits[corev1.ResourceMemory] = q
 	}
+	if c.SidecarMemoryPerComponent != "" && c.SidecarComponentCount > 0 {
+		q, err := resource.ParseQuantity(c.SidecarMemoryPerComponent)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing sidecar memory per component: %w", err)
+		}
+		addComponentsMemory(&r, q, c.SidecarComponentCount)
+	}
 
 	if len(r.Limits) == 0 && len(r.Requests) == 0 {
 		return nil, nil
@@ -394,6 +420,17 @@ func (c *SidecarConfig) getResourceRequirements() (*corev1.ResourceRequirements,
 	return &r, nil
 }
 
+// addComponentsMemory adds the memory of the given number of components to the memory request.
+// The memory request is capped to the memory limit, when set, so the pod spec stays valid.
+func addComponentsMemory(r *corev1.ResourceRequirements, perComponent resource.Quantity, count int) {
+	memory := r.Requests[corev1.ResourceMemory]
+	memory.Add(*resource.NewQuantity(perComponent.Value()*int64(count), perComponent.Format))
+	if limit, ok := r.Limits[corev1.ResourceMemory]; ok && memory.Cmp(limit) > 0 {
+		memory = limit.DeepCopy()
+	}
+	r.Requests[corev1.ResourceMemory] = memory
+}
+
 // GetAppID returns the AppID property, fallinb back to the name of the pod.
 func (c *SidecarConfig) GetAppID() string {
 	if c.AppID == "" {
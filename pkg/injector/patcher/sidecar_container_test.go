@@ -214,6 +214,77 @@ func TestGetResourceRequirements(t *testing.T) {
 		assert.Equal(t, "100m", r.Requests.Cpu().String())
 		assert.Equal(t, "1Gi", r.Requests.Memory().String())
 	})
+
+	t.Run("memory request grows with the component count", func(t *testing.T) {
+		requestFor := func(count string) string {
+			c := NewSidecarConfig(&corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						annotations.KeyMemoryRequest:      "128Mi",
+						annotations.KeyMemoryPerComponent: "16Mi",
+						annotations.KeyComponentCount:     count,
+					},
+				},
+			})
+			c.SetFromPodAnnotations()
+			r, err := c.getResourceRequirements()
+			require.NoError(t, err)
+			return r.Requests.Memory().String()
+		}
+
+		assert.Equal(t, "128Mi", requestFor("0"))
+		assert.Equal(t, "144Mi", requestFor("1"))
+		assert.Equal(t, "288Mi", requestFor("10"))
+	})
+
+	t.Run("memory per component without a memory request", func(t *testing.T) {
+		c := NewSidecarConfig(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					annotations.KeyMemoryPerComponent: "16Mi",
+					annotations.KeyComponentCount:     "4",
+				},
+			},
+		})
+		c.SetFromPodAnnotations()
+		r, err := c.getResourceRequirements()
+		require.NoError(t, err)
+		assert.Equal(t, "64Mi", r.Requests.Memory().String())
+		assert.Empty(t, r.Limits)
+	})
+
+	t.Run("memory request capped to the memory limit", func(t *testing.T) {
+		c := NewSidecarConfig(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					annotations.KeyMemoryRequest:      "128Mi",
+					annotations.KeyMemoryLimit:        "256Mi",
+					annotations.KeyMemoryPerComponent: "16Mi",
+					annotations.KeyComponentCount:     "20",
+				},
+			},
+		})
+		c.SetFromPodAnnotations()
+		r, err := c.getResourceRequirements()
+		require.NoError(t, err)
+		assert.Equal(t, "256Mi", r.Requests.Memory().String())
+		assert.Equal(t, "256Mi", r.Limits.Memory().String())
+	})
+
+	t.Run("invalid memory per component", func(t *testing.T) {
+		c := NewSidecarConfig(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					annotations.KeyMemoryPerComponent: "invalid",
+					annotations.KeyComponentCount:     "4",
+				},
+			},
+		})
+		c.SetFromPodAnnotations()
+		r, err := c.getResourceRequirements()
+		require.Error(t, err)
+		assert.Nil(t, r)
+	})
 }
 
 func TestGetProbeHttpHandler(t *testing.T) {
@@ -839,6 +910,35 @@ func TestGetSidecarContainer(t *testing.T) {
 		},
 	}))
 
+	t.Run("startup probe", testSuiteGenerator([]testCase{
+		{
+			name:        "defaults",
+			annotations: map[string]string{},
+			assertFn: func(t *testing.T, container *corev1.Container) {
+				require.NotNil(t, container.StartupProbe)
+				assert.Equal(t, "/v1.0/healthz/startup", container.StartupProbe.HTTPGet.Path)
+				assert.Equal(t, 3501, container.StartupProbe.HTTPGet.Port.IntValue())
+				assert.Equal(t, int32(3), container.StartupProbe.TimeoutSeconds)
+				assert.Equal(t, int32(5), container.StartupProbe.PeriodSeconds)
+				assert.Equal(t, int32(60), container.StartupProbe.FailureThreshold)
+			},
+		},
+		{
+			name: "custom options",
+			annotations: map[string]string{
+				annotations.KeyStartupProbeTimeoutSeconds: "2",
+				annotations.KeyStartupProbePeriodSeconds:  "10",
+				annotations.KeyStartupProbeThreshold:      "30",
+			},
+			assertFn: func(t *testing.T, container *corev1.Container) {
+				require.NotNil(t, container.StartupProbe)
+				assert.Equal(t, int32(2), container.StartupProbe.TimeoutSeconds)
+				assert.Equal(t, int32(10), container.StartupProbe.PeriodSeconds)
+				assert.Equal(t, int32(30), container.StartupProbe.FailureThreshold)
+			},
+		},
+	}))
+
 	t.Run("app health checks", testSuiteGenerator([]testCase{
 		{
 			name:        "disabled by default",
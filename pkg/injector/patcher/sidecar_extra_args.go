@@ -0,0 +1,106 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patcher
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+
+	"github.com/dapr/dapr/pkg/injector/annotations"
+)
+
+// extraArgPattern is the format of a sidecar extra arg, a flag with an optional value, e.g. --log-level=debug.
+var extraArgPattern = regexp.MustCompile(`^--([a-z0-9][a-z0-9-]*)(=.*)?$`)
+
+// allowedExtraArgs are the sidecar flags that can be set through the extra args annotation.
+// Flags affecting the sidecar identity, security or connectivity to the control plane are not allowed.
+var allowedExtraArgs = map[string]struct{}{
+	"log-level":                      {},
+	"log-as-json":                    {},
+	"enable-api-logging":             {},
+	"enable-profiling":               {},
+	"profile-port":                   {},
+	"enable-metrics":                 {},
+	"metrics-port":                   {},
+	"app-max-concurrency":            {},
+	"dapr-http-max-request-size":     {},
+	"dapr-http-read-buffer-size":     {},
+	"dapr-graceful-shutdown-seconds": {},
+}
+
+// getExtraArgsPatchOperations returns the patch operations that append the extra args set through the annotation to the args of the sidecar container,
+// at the given container index. Args already present with the same value in the given sidecar args, or repeated in the annotation, are skipped.
+// Args setting a flag already present with a different value are appended, overriding it as the last value of a flag wins.
+// It returns an error when an arg is malformed or its flag is not allowed.
+func (c *SidecarConfig) getExtraArgsPatchOperations(containerIdx int, sidecarArgs []string) (jsonpatch.Patch, error) {
+	if strings.TrimSpace(c.SidecarExtraArgs) == "" {
+		return nil, nil
+	}
+
+	present := flagValues(sidecarArgs)
+	path := fmt.Sprintf("%s/%d/args/-", PatchPathContainers, containerIdx)
+	patchOps := jsonpatch.Patch{}
+	for _, arg := range strings.Split(c.SidecarExtraArgs, ",") {
+		arg = strings.TrimSpace(arg)
+		if arg == "" {
+			continue
+		}
+		match := extraArgPattern.FindStringSubmatch(arg)
+		if match == nil {
+			return nil, fmt.Errorf("invalid value '%s' for annotation '%s': args must be in the --flag or --flag=value format", arg, annotations.KeySidecarExtraArgs)
+		}
+		flag, value := match[1], flagValue(match[2])
+		if _, ok := allowedExtraArgs[flag]; !ok {
+			return nil, fmt.Errorf("invalid value '%s' for annotation '%s': flag --%s is not allowed", arg, annotations.KeySidecarExtraArgs, flag)
+		}
+		if current, ok := present[flag]; ok && current == value {
+			continue
+		}
+		present[flag] = value
+		patchOps = append(patchOps, NewPatchOperation("add", path, arg))
+	}
+
+	return patchOps, nil
+}
+
+// flagValues returns the value of each flag set in the given args, either as --flag=value, --flag value or --flag for boolean flags.
+func flagValues(args []string) map[string]string {
+	values := make(map[string]string, len(args))
+	for i, arg := range args {
+		match := extraArgPattern.FindStringSubmatch(arg)
+		if match == nil {
+			continue
+		}
+		switch {
+		case match[2] != "":
+			values[match[1]] = flagValue(match[2])
+		case i+1 < len(args) && !strings.HasPrefix(args[i+1], "--"):
+			values[match[1]] = args[i+1]
+		default:
+			values[match[1]] = "true"
+		}
+	}
+	return values
+}
+
+// flagValue returns the value of the given "=value" flag suffix, flags without value are boolean flags set to true.
+func flagValue(suffix string) string {
+	if suffix == "" {
+		return "true"
+	}
+	return strings.TrimPrefix(suffix, "=")
+}
@@ -0,0 +1,106 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/dapr/dapr/pkg/injector/annotations"
+)
+
+func TestSidecarExtraArgsAnnotation(t *testing.T) {
+	patchedSidecarArgs := func(t *testing.T, podAnnotations map[string]string) ([]string, error) {
+		t.Helper()
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "myapp",
+				Annotations: map[string]string{
+					annotations.KeyEnabled: "true",
+					annotations.KeyAppID:   "myapp",
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "appcontainer", Image: "container:1.0"},
+				},
+			},
+		}
+		for k, v := range podAnnotations {
+			pod.Annotations[k] = v
+		}
+
+		c := NewSidecarConfig(pod)
+		c.Namespace = "testns"
+		c.SetFromPodAnnotations()
+
+		patch, err := c.GetPatch()
+		if err != nil {
+			return nil, err
+		}
+		newPod, err := PatchPod(pod, patch)
+		require.NoError(t, err)
+		require.Len(t, newPod.Spec.Containers, 2)
+		return newPod.Spec.Containers[1].Args, nil
+	}
+
+	defaultArgs, err := patchedSidecarArgs(t, nil)
+	require.NoError(t, err)
+
+	t.Run("extra args should be appended to the sidecar args", func(t *testing.T) {
+		args, err := patchedSidecarArgs(t, map[string]string{annotations.KeySidecarExtraArgs: "--log-level=debug, --enable-profiling"})
+		require.NoError(t, err)
+
+		assert.Equal(t, append(defaultArgs, "--log-level=debug", "--enable-profiling"), args)
+	})
+
+	t.Run("extra args already present with the same value should be skipped", func(t *testing.T) {
+		args, err := patchedSidecarArgs(t, map[string]string{
+			annotations.KeyEnableProfiling:  "true",
+			annotations.KeyLogLevel:         "debug",
+			annotations.KeySidecarExtraArgs: "--enable-profiling,--log-level=debug,--log-as-json,--log-as-json=true",
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"--log-as-json"}, args[len(args)-1:])
+		assert.Len(t, args, len(defaultArgs)+2, "only --enable-profiling, set by its annotation, and --log-as-json should be added")
+	})
+
+	t.Run("no extra args should be appended when the annotation is empty", func(t *testing.T) {
+		args, err := patchedSidecarArgs(t, map[string]string{annotations.KeySidecarExtraArgs: " , "})
+		require.NoError(t, err)
+		assert.Equal(t, defaultArgs, args)
+	})
+
+	t.Run("malformed extra args should fail the patch", func(t *testing.T) {
+		for _, arg := range []string{"log-level=debug", "-log-level=debug", "--log-level debug", "--", "--Log-Level=debug"} {
+			_, err := patchedSidecarArgs(t, map[string]string{annotations.KeySidecarExtraArgs: arg})
+			require.Error(t, err, arg)
+			assert.Contains(t, err.Error(), annotations.KeySidecarExtraArgs)
+		}
+	})
+
+	t.Run("flags not allowed should fail the patch", func(t *testing.T) {
+		for _, arg := range []string{"--enable-mtls=false", "--sentry-address=evil:50001", "--app-id=other", "--mode=standalone", "--not-a-flag"} {
+			_, err := patchedSidecarArgs(t, map[string]string{annotations.KeySidecarExtraArgs: "--log-level=debug," + arg})
+			require.Error(t, err, arg)
+			assert.Contains(t, err.Error(), "is not allowed")
+		}
+	})
+}
@@ -14,6 +14,7 @@ limitations under the License.
 package patcher
 
 import (
+	"fmt"
 	"strconv"
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
@@ -74,7 +75,14 @@ func (c *SidecarConfig) GetPatch() (patchOps jsonpatch.Patch, err error) {
 			return nil, err
 		}
 	}
-	componentPatchOps, componentsSocketVolumeMount := c.componentsPatchOps(componentContainers, injectedComponentContainers)
+	socketVolumeClasses, err := parseSocketVolumeClasses(c.PluggableComponentsVolumeClasses)
+	if err != nil {
+		return nil, err
+	}
+	componentPatchOps, componentsSocketVolumeMount, err := c.componentsPatchOps(componentContainers, injectedComponentContainers, socketVolumeClasses)
+	if err != nil {
+		return nil, err
+	}
 
 	// Projected volume with the token
 	if !c.DisableTokenVolume {
@@ -93,12 +101,22 @@ func (c *SidecarConfig) GetPatch() (patchOps jsonpatch.Patch, err error) {
 
 	// Get the sidecar container
 	sidecarContainer, err := c.getSidecarContainer(getSidecarContainerOpts{
-		ComponentsSocketsVolumeMount: componentsSocketVolumeMount,
-		VolumeMounts:                 volumeMounts,
+		ComponentsSocketsVolumeMount:  componentsSocketVolumeMount,
+		ComponentsSocketVolumeClasses: socketVolumeClasses,
+		VolumeMounts:                  volumeMounts,
 	})
 	if err != nil {
 		return nil, err
 	}
+	if err = c.validateEnvLimits(*sidecarContainer, nil); err != nil {
+		return nil, err
+	}
+
+	// The sidecar container is appended after the existing containers
+	extraArgsPatchOps, err := c.getExtraArgsPatchOperations(len(c.pod.Spec.Containers), sidecarContainer.Args)
+	if err != nil {
+		return nil, err
+	}
 
 	// Create the list of patch operations
 	if len(c.pod.Spec.Containers) == 0 {
@@ -126,14 +144,17 @@ func (c *SidecarConfig) GetPatch() (patchOps jsonpatch.Patch, err error) {
 		NewPatchOperation("add", PatchPathLabels+"/dapr.io~1app-id", c.GetAppID()),
 		NewPatchOperation("add", PatchPathLabels+"/dapr.io~1metrics-enabled", strconv.FormatBool(c.EnableMetrics)),
 	)
-	patchOps = append(patchOps,
-		c.addDaprEnvVarsToContainers(appContainers, c.GetAppProtocol())...,
-	)
+	envPatchOps, err := c.addDaprEnvVarsToContainers(appContainers, c.GetAppProtocol())
+	if err != nil {
+		return nil, err
+	}
+	patchOps = append(patchOps, envPatchOps...)
 	for _, vm := range containerVolumeMounts {
 		patchOps = append(patchOps,
 			addVolumeMountToContainers(appContainers, vm)...,
 		)
 	}
+	patchOps = append(patchOps, extraArgsPatchOps...)
 	patchOps = append(patchOps, componentPatchOps...)
 
 	return patchOps, nil
@@ -151,7 +172,8 @@ func (c *SidecarConfig) podContainsSidecarContainer() bool {
 
 // addDaprEnvVarsToContainers adds Dapr environment variables to all the containers in any Dapr-enabled pod.
 // The containers can be injected or user-defined.
-func (c *SidecarConfig) addDaprEnvVarsToContainers(containers map[int]corev1.Container, appProtocol string) jsonpatch.Patch {
+// An error is returned when the environment variables of a container would exceed the limits, see ValidateEnvLimits.
+func (c *SidecarConfig) addDaprEnvVarsToContainers(containers map[int]corev1.Container, appProtocol string) (jsonpatch.Patch, error) {
 	envPatchOps := make(jsonpatch.Patch, 0, len(containers)*2)
 	envVars := []corev1.EnvVar{
 		{
@@ -170,8 +192,24 @@ func (c *SidecarConfig) addDaprEnvVarsToContainers(containers map[int]corev1.Con
 		})
 	}
 	for i, container := range containers {
+		if err := c.validateEnvLimits(container, envVars); err != nil {
+			return nil, err
+		}
 		patchOps := GetEnvPatchOperations(container.Env, envVars, i)
 		envPatchOps = append(envPatchOps, patchOps...)
 	}
-	return envPatchOps
+	return envPatchOps, nil
+}
+
+// envLimits returns the limits the environment variables of the pod containers must fit in once patched.
+func (c *SidecarConfig) envLimits() EnvLimits {
+	return EnvLimits{MaxCount: c.MaxEnvVars, MaxSize: c.MaxEnvVarsSize}
+}
+
+// validateEnvLimits returns an error when the environment variables of the given container exceed the limits once the given ones are added.
+func (c *SidecarConfig) validateEnvLimits(container corev1.Container, addEnv []corev1.EnvVar) error {
+	if err := ValidateEnvLimits(container.Env, addEnv, c.envLimits()); err != nil {
+		return fmt.Errorf("invalid environment of container '%s': %w", container.Name, err)
+	}
+	return nil
 }
@@ -147,7 +147,8 @@ func TestAddDaprEnvVarsToContainers(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.testName, func(t *testing.T) {
 			c := NewSidecarConfig(&corev1.Pod{})
-			patchEnv := c.addDaprEnvVarsToContainers(map[int]corev1.Container{0: tc.mockContainer}, tc.appProtocol)
+			patchEnv, err := c.addDaprEnvVarsToContainers(map[int]corev1.Container{0: tc.mockContainer}, tc.appProtocol)
+			require.NoError(t, err)
 			assert.Equal(t, tc.expOpsLen, len(patchEnv))
 			assert.Equal(t, tc.expOps, patchEnv)
 		})
@@ -372,3 +373,57 @@ func TestPatching(t *testing.T) {
 		t.Run(tc.name, testCaseFn(tc))
 	}
 }
+
+func TestGetPatchEnvLimits(t *testing.T) {
+	newConfig := func(appEnv []corev1.EnvVar) *SidecarConfig {
+		c := NewSidecarConfig(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "myapp",
+				Annotations: map[string]string{
+					"dapr.io/enabled": "true",
+					"dapr.io/app-id":  "myapp",
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "appcontainer", Image: "container:1.0", Env: appEnv},
+				},
+			},
+		})
+		c.Namespace = "testns"
+		c.Identity = "pod:identity"
+		c.SetFromPodAnnotations()
+		return c
+	}
+
+	t.Run("the dapr env vars should be rejected when an app container would exceed the limits", func(t *testing.T) {
+		c := newConfig([]corev1.EnvVar{{Name: "CIAO", Value: "mondo"}})
+		c.MaxEnvVars = 3
+
+		_, err := c.addDaprEnvVarsToContainers(map[int]corev1.Container{0: c.pod.Spec.Containers[0]}, "http")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "container 'appcontainer'")
+	})
+
+	t.Run("the patch should be rejected when the sidecar container would exceed the limits", func(t *testing.T) {
+		c := newConfig(nil)
+		c.Env = "LARGE=" + strings.Repeat("x", 64)
+		c.MaxEnvVarsSize = 64
+
+		_, err := c.GetPatch()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "container 'daprd'")
+	})
+
+	t.Run("the patch should be accepted within the limits", func(t *testing.T) {
+		c := newConfig([]corev1.EnvVar{{Name: "CIAO", Value: "mondo"}})
+		c.Env = "LARGE=" + strings.Repeat("x", 64)
+
+		_, err := c.GetPatch()
+		require.NoError(t, err)
+
+		c.MaxEnvVars = 4
+		_, err = c.addDaprEnvVarsToContainers(map[int]corev1.Container{0: c.pod.Spec.Containers[0]}, "http")
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,111 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patcher
+
+import (
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/dapr/dapr/pkg/injector/annotations"
+)
+
+// applySecurityContextAnnotations sets the security context of the sidecar container with the values set through the pod annotations.
+// Annotations can only tighten the security context configured on the injector: values that would weaken it are rejected.
+// It returns an error when an annotation value is not valid.
+func (c *SidecarConfig) applySecurityContextAnnotations(securityContext *corev1.SecurityContext) error {
+	runAsNonRoot, err := parseBoolAnnotation(annotations.KeySidecarRunAsNonRoot, c.SidecarRunAsNonRoot)
+	if err != nil {
+		return err
+	}
+	if runAsNonRoot != nil {
+		if !*runAsNonRoot && c.RunAsNonRoot {
+			return fmt.Errorf("annotation '%s' cannot be false when the injector requires the sidecar to run as non root", annotations.KeySidecarRunAsNonRoot)
+		}
+		securityContext.RunAsNonRoot = runAsNonRoot
+	}
+
+	readOnlyRootFilesystem, err := parseBoolAnnotation(annotations.KeySidecarReadOnlyRootFilesystem, c.SidecarReadOnlyRootFilesystem)
+	if err != nil {
+		return err
+	}
+	if readOnlyRootFilesystem != nil {
+		if !*readOnlyRootFilesystem && c.ReadOnlyRootFilesystem {
+			return fmt.Errorf("annotation '%s' cannot be false when the injector requires a read-only root filesystem", annotations.KeySidecarReadOnlyRootFilesystem)
+		}
+		securityContext.ReadOnlyRootFilesystem = readOnlyRootFilesystem
+	}
+
+	dropAllCapabilities, err := parseBoolAnnotation(annotations.KeySidecarDropAllCapabilities, c.SidecarDropAllCapabilities)
+	if err != nil {
+		return err
+	}
+	if dropAllCapabilities != nil {
+		if !*dropAllCapabilities && c.SidecarDropALLCapabilities {
+			return fmt.Errorf("annotation '%s' cannot be false when the injector drops all the capabilities", annotations.KeySidecarDropAllCapabilities)
+		}
+		if *dropAllCapabilities {
+			securityContext.Capabilities = &corev1.Capabilities{
+				Drop: []corev1.Capability{"ALL"},
+			}
+		}
+	}
+
+	runAsUser, err := parseIDAnnotation(annotations.KeySidecarRunAsUser, c.SidecarRunAsUser)
+	if err != nil {
+		return err
+	}
+	if runAsUser != nil {
+		// The check is made against the effective value, which can come from either the injector or the annotation.
+		if *runAsUser == 0 && securityContext.RunAsNonRoot != nil && *securityContext.RunAsNonRoot {
+			return fmt.Errorf("annotation '%s' cannot be 0 when the sidecar runs as non root", annotations.KeySidecarRunAsUser)
+		}
+		securityContext.RunAsUser = runAsUser
+	}
+
+	runAsGroup, err := parseIDAnnotation(annotations.KeySidecarRunAsGroup, c.SidecarRunAsGroup)
+	if err != nil {
+		return err
+	}
+	if runAsGroup != nil {
+		securityContext.RunAsGroup = runAsGroup
+	}
+
+	return nil
+}
+
+// parseBoolAnnotation parses the value of a boolean annotation, returning nil when it is not set.
+func parseBoolAnnotation(key, value string) (*bool, error) {
+	if value == "" {
+		return nil, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value '%s' for annotation '%s': must be a boolean", value, key)
+	}
+	return &b, nil
+}
+
+// parseIDAnnotation parses the value of a user or group ID annotation, returning nil when it is not set.
+func parseIDAnnotation(key, value string) (*int64, error) {
+	if value == "" {
+		return nil, nil
+	}
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 0 {
+		return nil, fmt.Errorf("invalid value '%s' for annotation '%s': must be a non-negative integer", value, key)
+	}
+	return &id, nil
+}
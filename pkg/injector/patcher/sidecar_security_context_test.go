@@ -0,0 +1,193 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/dapr/dapr/pkg/injector/annotations"
+	"github.com/dapr/kit/ptr"
+)
+
+func TestSidecarSecurityContextAnnotations(t *testing.T) {
+	patchedSidecar := func(t *testing.T, podAnnotations map[string]string, configure ...func(c *SidecarConfig)) (*corev1.Container, error) {
+		t.Helper()
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "myapp",
+				Annotations: map[string]string{
+					annotations.KeyEnabled: "true",
+					annotations.KeyAppID:   "myapp",
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{
+					{Name: "appcontainer", Image: "container:1.0"},
+				},
+			},
+		}
+		for k, v := range podAnnotations {
+			pod.Annotations[k] = v
+		}
+
+		c := NewSidecarConfig(pod)
+		c.Namespace = "testns"
+		c.SetFromPodAnnotations()
+		for _, fn := range configure {
+			fn(c)
+		}
+
+		patch, err := c.GetPatch()
+		if err != nil {
+			return nil, err
+		}
+		newPod, err := PatchPod(pod, patch)
+		require.NoError(t, err)
+		require.Len(t, newPod.Spec.Containers, 2)
+		return &newPod.Spec.Containers[1], nil
+	}
+
+	t.Run("security context should keep the defaults when no annotation is set", func(t *testing.T) {
+		sidecar, err := patchedSidecar(t, nil)
+		require.NoError(t, err)
+
+		assert.Equal(t, ptr.Of(false), sidecar.SecurityContext.RunAsNonRoot)
+		assert.Equal(t, ptr.Of(false), sidecar.SecurityContext.ReadOnlyRootFilesystem)
+		assert.Nil(t, sidecar.SecurityContext.Capabilities)
+		assert.Nil(t, sidecar.SecurityContext.RunAsUser)
+		assert.Nil(t, sidecar.SecurityContext.RunAsGroup)
+		assert.Equal(t, ptr.Of(false), sidecar.SecurityContext.AllowPrivilegeEscalation)
+	})
+
+	t.Run("run as non root should be set from the annotation", func(t *testing.T) {
+		sidecar, err := patchedSidecar(t, map[string]string{annotations.KeySidecarRunAsNonRoot: "true"})
+		require.NoError(t, err)
+		assert.Equal(t, ptr.Of(true), sidecar.SecurityContext.RunAsNonRoot)
+	})
+
+	t.Run("read only root filesystem should be set from the annotation", func(t *testing.T) {
+		sidecar, err := patchedSidecar(t, map[string]string{annotations.KeySidecarReadOnlyRootFilesystem: "true"})
+		require.NoError(t, err)
+		assert.Equal(t, ptr.Of(true), sidecar.SecurityContext.ReadOnlyRootFilesystem)
+	})
+
+	t.Run("all capabilities should be dropped from the annotation", func(t *testing.T) {
+		sidecar, err := patchedSidecar(t, map[string]string{annotations.KeySidecarDropAllCapabilities: "true"})
+		require.NoError(t, err)
+		require.NotNil(t, sidecar.SecurityContext.Capabilities)
+		assert.Equal(t, []corev1.Capability{"ALL"}, sidecar.SecurityContext.Capabilities.Drop)
+	})
+
+	t.Run("run as user and group should be set from the annotations", func(t *testing.T) {
+		sidecar, err := patchedSidecar(t, map[string]string{
+			annotations.KeySidecarRunAsUser:  "1000",
+			annotations.KeySidecarRunAsGroup: "2000",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, ptr.Of(int64(1000)), sidecar.SecurityContext.RunAsUser)
+		assert.Equal(t, ptr.Of(int64(2000)), sidecar.SecurityContext.RunAsGroup)
+	})
+
+	t.Run("invalid annotation values should fail the patch", func(t *testing.T) {
+		invalid := map[string]string{
+			annotations.KeySidecarRunAsNonRoot:           "yes please",
+			annotations.KeySidecarReadOnlyRootFilesystem: "maybe",
+			annotations.KeySidecarDropAllCapabilities:    "2",
+			annotations.KeySidecarRunAsUser:              "root",
+			annotations.KeySidecarRunAsGroup:             "-1",
+		}
+		for key, value := range invalid {
+			_, err := patchedSidecar(t, map[string]string{key: value})
+			require.Error(t, err, key)
+			assert.Contains(t, err.Error(), key)
+		}
+	})
+
+	t.Run("run as user 0 should fail the patch when running as non root", func(t *testing.T) {
+		_, err := patchedSidecar(t, map[string]string{
+			annotations.KeySidecarRunAsNonRoot: "true",
+			annotations.KeySidecarRunAsUser:    "0",
+		})
+		assert.Error(t, err)
+	})
+
+	t.Run("run as user 0 should fail the patch when the injector runs the sidecar as non root", func(t *testing.T) {
+		_, err := patchedSidecar(t, map[string]string{
+			annotations.KeySidecarRunAsUser: "0",
+		}, func(c *SidecarConfig) {
+			c.RunAsNonRoot = true
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), annotations.KeySidecarRunAsUser)
+	})
+
+	t.Run("annotations should not weaken the injector security context", func(t *testing.T) {
+		strict := func(c *SidecarConfig) {
+			c.RunAsNonRoot = true
+			c.ReadOnlyRootFilesystem = true
+			c.SidecarDropALLCapabilities = true
+		}
+		for _, key := range []string{
+			annotations.KeySidecarRunAsNonRoot,
+			annotations.KeySidecarReadOnlyRootFilesystem,
+			annotations.KeySidecarDropAllCapabilities,
+		} {
+			_, err := patchedSidecar(t, map[string]string{key: "false"}, strict)
+			require.Error(t, err, key)
+			assert.Contains(t, err.Error(), key)
+		}
+
+		sidecar, err := patchedSidecar(t, map[string]string{
+			annotations.KeySidecarRunAsNonRoot:           "true",
+			annotations.KeySidecarReadOnlyRootFilesystem: "true",
+			annotations.KeySidecarDropAllCapabilities:    "true",
+		}, strict)
+		require.NoError(t, err)
+		assert.Equal(t, ptr.Of(true), sidecar.SecurityContext.RunAsNonRoot)
+		assert.Equal(t, ptr.Of(true), sidecar.SecurityContext.ReadOnlyRootFilesystem)
+		require.NotNil(t, sidecar.SecurityContext.Capabilities)
+		assert.Equal(t, []corev1.Capability{"ALL"}, sidecar.SecurityContext.Capabilities.Drop)
+	})
+
+	t.Run("annotations should be set on the sidecar container rather than patched", func(t *testing.T) {
+		c := NewSidecarConfig(&corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					annotations.KeySidecarRunAsNonRoot: "true",
+					annotations.KeySidecarRunAsUser:    "1000",
+				},
+			},
+		})
+		c.SetFromPodAnnotations()
+
+		container, err := c.getSidecarContainer(getSidecarContainerOpts{})
+		require.NoError(t, err)
+		assert.Equal(t, ptr.Of(true), container.SecurityContext.RunAsNonRoot)
+		assert.Equal(t, ptr.Of(int64(1000)), container.SecurityContext.RunAsUser)
+
+		patch, err := c.GetPatch()
+		require.NoError(t, err)
+		for _, op := range patch {
+			path, err := op.Path()
+			require.NoError(t, err)
+			assert.NotContains(t, path, "/securityContext")
+		}
+	})
+}
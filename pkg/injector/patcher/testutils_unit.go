@@ -0,0 +1,72 @@
+//go:build unit
+
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patcher
+
+import (
+	"sort"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+// AssertPatchEqual asserts that the given patches hold the same operations regardless of their order.
+// Operations are sorted by path and op, and their values are compared by their canonical JSON encoding, see MarshalPatch,
+// so the failure message shows a readable diff of the operations.
+func AssertPatchEqual(t assert.TestingT, expected, actual jsonpatch.Patch, msgAndArgs ...any) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	expectedOps, err := normalizePatch(expected)
+	if err != nil {
+		return assert.Fail(t, "invalid expected patch: "+err.Error(), msgAndArgs...)
+	}
+	actualOps, err := normalizePatch(actual)
+	if err != nil {
+		return assert.Fail(t, "invalid actual patch: "+err.Error(), msgAndArgs...)
+	}
+	return assert.Equal(t, expectedOps, actualOps, msgAndArgs...)
+}
+
+// normalizePatch returns the canonical JSON encoding of each operation of the given patch, sorted by path and op.
+func normalizePatch(patch jsonpatch.Patch) ([]string, error) {
+	type normalizedOp struct {
+		path, kind, json string
+	}
+	ops := make([]normalizedOp, len(patch))
+	for i, op := range patch {
+		canonical, err := MarshalPatch(jsonpatch.Patch{op})
+		if err != nil {
+			return nil, err
+		}
+		path, _ := op.Path()
+		// strip the enclosing array, each operation is encoded on its own.
+		ops[i] = normalizedOp{path: path, kind: op.Kind(), json: string(canonical[1 : len(canonical)-1])}
+	}
+	sort.SliceStable(ops, func(i, j int) bool {
+		if ops[i].path != ops[j].path {
+			return ops[i].path < ops[j].path
+		}
+		if ops[i].kind != ops[j].kind {
+			return ops[i].kind < ops[j].kind
+		}
+		return ops[i].json < ops[j].json
+	})
+	normalized := make([]string, len(ops))
+	for i, op := range ops {
+		normalized[i] = op.json
+	}
+	return normalized, nil
+}
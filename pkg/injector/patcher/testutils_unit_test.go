@@ -0,0 +1,89 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package patcher
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/dapr/kit/ptr"
+)
+
+// recordingT records the failures reported by the assertions instead of failing the test.
+type recordingT struct {
+	errors []string
+}
+
+func (r *recordingT) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertPatchEqual(t *testing.T) {
+	envOp := NewPatchOperation("add", "/spec/containers/0/env", []corev1.EnvVar{{Name: "DAPR_HTTP_PORT", Value: "3500"}})
+	labelsOp := NewPatchOperation("add", PatchPathLabels, map[string]string{"dapr.io/sidecar-injected": "true", "app": "my-app"})
+	volumesOp := NewPatchOperation("add", PatchPathVolumes, []corev1.Volume{{Name: "dapr-unix-domain-socket"}})
+
+	t.Run("patches with the same operations in a different order should be equal", func(t *testing.T) {
+		rec := &recordingT{}
+		assert.True(t, AssertPatchEqual(rec, jsonpatch.Patch{envOp, labelsOp, volumesOp}, jsonpatch.Patch{volumesOp, envOp, labelsOp}))
+		assert.Empty(t, rec.errors)
+	})
+
+	t.Run("operation values should be compared by their canonical encoding", func(t *testing.T) {
+		rawLabelsOp := jsonpatch.Operation{
+			"value": ptr.Of(json.RawMessage(`{ "app": "my-app", "dapr.io/sidecar-injected": "true" }`)),
+			"path":  ptr.Of(json.RawMessage(`"` + PatchPathLabels + `"`)),
+			"op":    ptr.Of(json.RawMessage(`"add"`)),
+		}
+
+		rec := &recordingT{}
+		assert.True(t, AssertPatchEqual(rec, jsonpatch.Patch{labelsOp}, jsonpatch.Patch{rawLabelsOp}))
+		assert.Empty(t, rec.errors)
+	})
+
+	t.Run("different values should be reported", func(t *testing.T) {
+		otherEnvOp := NewPatchOperation("add", "/spec/containers/0/env", []corev1.EnvVar{{Name: "DAPR_HTTP_PORT", Value: "3501"}})
+
+		rec := &recordingT{}
+		assert.False(t, AssertPatchEqual(rec, jsonpatch.Patch{envOp, labelsOp}, jsonpatch.Patch{labelsOp, otherEnvOp}))
+		assert.Len(t, rec.errors, 1)
+		assert.Contains(t, rec.errors[0], "3501")
+	})
+
+	t.Run("different ops on the same path should be reported", func(t *testing.T) {
+		rec := &recordingT{}
+		assert.False(t, AssertPatchEqual(rec, jsonpatch.Patch{labelsOp}, jsonpatch.Patch{NewPatchOperation("replace", PatchPathLabels, map[string]string{"dapr.io/sidecar-injected": "true", "app": "my-app"})}))
+		assert.Len(t, rec.errors, 1)
+	})
+
+	t.Run("missing or extra operations should be reported", func(t *testing.T) {
+		rec := &recordingT{}
+		assert.False(t, AssertPatchEqual(rec, jsonpatch.Patch{envOp, labelsOp}, jsonpatch.Patch{envOp}))
+		assert.False(t, AssertPatchEqual(rec, jsonpatch.Patch{envOp}, jsonpatch.Patch{envOp, envOp}))
+		assert.Len(t, rec.errors, 2)
+	})
+
+	t.Run("invalid operations should be reported", func(t *testing.T) {
+		invalidOp := jsonpatch.Operation{"op": ptr.Of(json.RawMessage(`"add`))}
+
+		rec := &recordingT{}
+		assert.False(t, AssertPatchEqual(rec, jsonpatch.Patch{envOp}, jsonpatch.Patch{invalidOp}))
+		assert.Len(t, rec.errors, 1)
+	})
+}
@@ -37,6 +37,8 @@ type Config struct {
 	RunAsNonRoot                      string `envconfig:"SIDECAR_RUN_AS_NON_ROOT"`
 	ReadOnlyRootFilesystem            string `envconfig:"SIDECAR_READ_ONLY_ROOT_FILESYSTEM"`
 	SidecarDropALLCapabilities        string `envconfig:"SIDECAR_DROP_ALL_CAPABILITIES"`
+	MaxEnvVars                        int    `envconfig:"MAX_ENV_VARS"`
+	MaxEnvVarsSize                    int    `envconfig:"MAX_ENV_VARS_SIZE"`
 
 	parsedEntrypointTolerations []corev1.Toleration
 }
@@ -24,6 +24,7 @@ import (
 	admissionv1 "k8s.io/api/admission/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	"github.com/dapr/dapr/pkg/injector/patcher"
 	"github.com/dapr/dapr/utils"
 )
 
@@ -88,7 +89,7 @@ func (i *injector) handleRequest(w http.ResponseWriter, r *http.Request) {
 		}
 	} else {
 		var patchBytes []byte
-		patchBytes, err = json.Marshal(patchOps)
+		patchBytes, err = patcher.MarshalPatch(patchOps)
 		if err != nil {
 			admissionResponse = errorToAdmissionResponse(err)
 		} else {
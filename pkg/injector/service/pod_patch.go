@@ -73,6 +73,8 @@ func (i *injector) getPodPatchOperations(ctx context.Context, ar *admissionv1.Ad
 	sidecar.RunAsNonRoot = i.config.GetRunAsNonRoot()
 	sidecar.ReadOnlyRootFilesystem = i.config.GetReadOnlyRootFilesystem()
 	sidecar.SidecarDropALLCapabilities = i.config.GetDropCapabilities()
+	sidecar.MaxEnvVars = i.config.MaxEnvVars
+	sidecar.MaxEnvVarsSize = i.config.MaxEnvVarsSize
 
 	// Set the placement address unless it's skipped
 	// Even if the placement is skipped, however,the placement address will still be included if explicitly set in the annotations
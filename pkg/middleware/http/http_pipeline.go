@@ -15,6 +15,7 @@ package http
 
 import (
 	"net/http"
+	"sort"
 )
 
 type Middleware = func(next http.Handler) http.Handler
@@ -30,3 +31,30 @@ func (p Pipeline) Apply(handler http.Handler) http.Handler {
 	}
 	return handler
 }
+
+// OrderedMiddleware is a middleware with an explicit position in the pipeline.
+// Middleware with a lower Order run first; ties are broken by Name.
+type OrderedMiddleware struct {
+	Name       string
+	Order      int
+	Middleware Middleware
+}
+
+// WithOrderedHTTPMiddleware builds a pipeline from middleware coming from
+// different sources, sorting them so the resulting order is deterministic.
+func WithOrderedHTTPMiddleware(middlewares []OrderedMiddleware) Pipeline {
+	sorted := make([]OrderedMiddleware, len(middlewares))
+	copy(sorted, middlewares)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Order != sorted[j].Order {
+			return sorted[i].Order < sorted[j].Order
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	handlers := make([]Middleware, len(sorted))
+	for i, m := range sorted {
+		handlers[i] = m.Middleware
+	}
+	return Pipeline{Handlers: handlers}
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func recordingMiddleware(name string, calls *[]string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			*calls = append(*calls, name)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func TestWithOrderedHTTPMiddleware(t *testing.T) {
+	t.Run("sorts by order then name", func(t *testing.T) {
+		calls := []string{}
+		pipeline := WithOrderedHTTPMiddleware([]OrderedMiddleware{
+			{Name: "ratelimit", Order: 10, Middleware: recordingMiddleware("ratelimit", &calls)},
+			{Name: "tracing", Order: 0, Middleware: recordingMiddleware("tracing", &calls)},
+			{Name: "oauth2", Order: 1, Middleware: recordingMiddleware("oauth2", &calls)},
+			{Name: "bearer", Order: 1, Middleware: recordingMiddleware("bearer", &calls)},
+			{Name: "uppercase", Order: 20, Middleware: recordingMiddleware("uppercase", &calls)},
+		})
+
+		handler := pipeline.Apply(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls = append(calls, "app")
+		}))
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+		assert.Equal(t, []string{"tracing", "bearer", "oauth2", "ratelimit", "uppercase", "app"}, calls)
+	})
+
+	t.Run("does not modify the input", func(t *testing.T) {
+		calls := []string{}
+		middlewares := []OrderedMiddleware{
+			{Name: "b", Order: 2, Middleware: recordingMiddleware("b", &calls)},
+			{Name: "a", Order: 1, Middleware: recordingMiddleware("a", &calls)},
+		}
+		pipeline := WithOrderedHTTPMiddleware(middlewares)
+
+		assert.Len(t, pipeline.Handlers, 2)
+		assert.Equal(t, "b", middlewares[0].Name)
+		assert.Equal(t, "a", middlewares[1].Name)
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Empty(t, WithOrderedHTTPMiddleware(nil).Handlers)
+	})
+}
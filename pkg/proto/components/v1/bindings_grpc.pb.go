@@ -31,6 +31,18 @@ type InputBindingClient interface {
 	Read(ctx context.Context, opts ...grpc.CallOption) (InputBinding_ReadClient, error)
 	// Ping the InputBinding. Used for liveness porpuses.
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	// Optional. Shutdown signals the component that the runtime is closing its
+	// connection, e.g. to flush buffers or checkpoint. Components that don't
+	// implement it return Unimplemented.
+	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
+	// Optional. Schema returns the metadata accepted by the component, used to
+	// validate the component metadata before Init. Components that don't
+	// implement it return Unimplemented.
+	Schema(ctx context.Context, in *SchemaRequest, opts ...grpc.CallOption) (*SchemaResponse, error)
+	// Optional. Warmup lets the component pre-establish its backend connections
+	// or prime its caches after Init, before serving traffic. Failures are not
+	// fatal. Components that don't implement it return Unimplemented.
+	Warmup(ctx context.Context, in *WarmupRequest, opts ...grpc.CallOption) (*WarmupResponse, error)
 }
 
 type inputBindingClient struct {
@@ -90,6 +102,33 @@ func (c *inputBindingClient) Ping(ctx context.Context, in *PingRequest, opts ...
 	return out, nil
 }
 
+func (c *inputBindingClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
+	out := new(ShutdownResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.InputBinding/Shutdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inputBindingClient) Schema(ctx context.Context, in *SchemaRequest, opts ...grpc.CallOption) (*SchemaResponse, error) {
+	out := new(SchemaResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.InputBinding/Schema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *inputBindingClient) Warmup(ctx context.Context, in *WarmupRequest, opts ...grpc.CallOption) (*WarmupResponse, error) {
+	out := new(WarmupResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.InputBinding/Warmup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // InputBindingServer is the server API for InputBinding service.
 // All implementations should embed UnimplementedInputBindingServer
 // for forward compatibility
@@ -103,6 +142,18 @@ type InputBindingServer interface {
 	Read(InputBinding_ReadServer) error
 	// Ping the InputBinding. Used for liveness porpuses.
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	// Optional. Shutdown signals the component that the runtime is closing its
+	// connection, e.g. to flush buffers or checkpoint. Components that don't
+	// implement it return Unimplemented.
+	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
+	// Optional. Schema returns the metadata accepted by the component, used to
+	// validate the component metadata before Init. Components that don't
+	// implement it return Unimplemented.
+	Schema(context.Context, *SchemaRequest) (*SchemaResponse, error)
+	// Optional. Warmup lets the component pre-establish its backend connections
+	// or prime its caches after Init, before serving traffic. Failures are not
+	// fatal. Components that don't implement it return Unimplemented.
+	Warmup(context.Context, *WarmupRequest) (*WarmupResponse, error)
 }
 
 // UnimplementedInputBindingServer should be embedded to have forward compatible implementations.
@@ -118,6 +169,15 @@ func (UnimplementedInputBindingServer) Read(InputBinding_ReadServer) error {
 func (UnimplementedInputBindingServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
+func (UnimplementedInputBindingServer) Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
+func (UnimplementedInputBindingServer) Schema(context.Context, *SchemaRequest) (*SchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Schema not implemented")
+}
+func (UnimplementedInputBindingServer) Warmup(context.Context, *WarmupRequest) (*WarmupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Warmup not implemented")
+}
 
 // UnsafeInputBindingServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to InputBindingServer will
@@ -192,6 +252,60 @@ func _InputBinding_Ping_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _InputBinding_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShutdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputBindingServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.InputBinding/Shutdown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputBindingServer).Shutdown(ctx, req.(*ShutdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InputBinding_Schema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputBindingServer).Schema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.InputBinding/Schema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputBindingServer).Schema(ctx, req.(*SchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _InputBinding_Warmup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WarmupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(InputBindingServer).Warmup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.InputBinding/Warmup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(InputBindingServer).Warmup(ctx, req.(*WarmupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // InputBinding_ServiceDesc is the grpc.ServiceDesc for InputBinding service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -207,6 +321,18 @@ var InputBinding_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _InputBinding_Ping_Handler,
 		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _InputBinding_Shutdown_Handler,
+		},
+		{
+			MethodName: "Schema",
+			Handler:    _InputBinding_Schema_Handler,
+		},
+		{
+			MethodName: "Warmup",
+			Handler:    _InputBinding_Warmup_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -231,6 +357,18 @@ type OutputBindingClient interface {
 	ListOperations(ctx context.Context, in *ListOperationsRequest, opts ...grpc.CallOption) (*ListOperationsResponse, error)
 	// Ping the OutputBinding. Used for liveness porpuses.
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	// Optional. Shutdown signals the component that the runtime is closing its
+	// connection, e.g. to flush buffers or checkpoint. Components that don't
+	// implement it return Unimplemented.
+	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
+	// Optional. Schema returns the metadata accepted by the component, used to
+	// validate the component metadata before Init. Components that don't
+	// implement it return Unimplemented.
+	Schema(ctx context.Context, in *SchemaRequest, opts ...grpc.CallOption) (*SchemaResponse, error)
+	// Optional. Warmup lets the component pre-establish its backend connections
+	// or prime its caches after Init, before serving traffic. Failures are not
+	// fatal. Components that don't implement it return Unimplemented.
+	Warmup(ctx context.Context, in *WarmupRequest, opts ...grpc.CallOption) (*WarmupResponse, error)
 }
 
 type outputBindingClient struct {
@@ -277,6 +415,33 @@ func (c *outputBindingClient) Ping(ctx context.Context, in *PingRequest, opts ..
 	return out, nil
 }
 
+func (c *outputBindingClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
+	out := new(ShutdownResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.OutputBinding/Shutdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outputBindingClient) Schema(ctx context.Context, in *SchemaRequest, opts ...grpc.CallOption) (*SchemaResponse, error) {
+	out := new(SchemaResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.OutputBinding/Schema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *outputBindingClient) Warmup(ctx context.Context, in *WarmupRequest, opts ...grpc.CallOption) (*WarmupResponse, error) {
+	out := new(WarmupResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.OutputBinding/Warmup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // OutputBindingServer is the server API for OutputBinding service.
 // All implementations should embed UnimplementedOutputBindingServer
 // for forward compatibility
@@ -289,6 +454,18 @@ type OutputBindingServer interface {
 	ListOperations(context.Context, *ListOperationsRequest) (*ListOperationsResponse, error)
 	// Ping the OutputBinding. Used for liveness porpuses.
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	// Optional. Shutdown signals the component that the runtime is closing its
+	// connection, e.g. to flush buffers or checkpoint. Components that don't
+	// implement it return Unimplemented.
+	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
+	// Optional. Schema returns the metadata accepted by the component, used to
+	// validate the component metadata before Init. Components that don't
+	// implement it return Unimplemented.
+	Schema(context.Context, *SchemaRequest) (*SchemaResponse, error)
+	// Optional. Warmup lets the component pre-establish its backend connections
+	// or prime its caches after Init, before serving traffic. Failures are not
+	// fatal. Components that don't implement it return Unimplemented.
+	Warmup(context.Context, *WarmupRequest) (*WarmupResponse, error)
 }
 
 // UnimplementedOutputBindingServer should be embedded to have forward compatible implementations.
@@ -307,6 +484,15 @@ func (UnimplementedOutputBindingServer) ListOperations(context.Context, *ListOpe
 func (UnimplementedOutputBindingServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
+func (UnimplementedOutputBindingServer) Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
+func (UnimplementedOutputBindingServer) Schema(context.Context, *SchemaRequest) (*SchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Schema not implemented")
+}
+func (UnimplementedOutputBindingServer) Warmup(context.Context, *WarmupRequest) (*WarmupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Warmup not implemented")
+}
 
 // UnsafeOutputBindingServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to OutputBindingServer will
@@ -391,6 +577,60 @@ func _OutputBinding_Ping_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OutputBinding_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShutdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputBindingServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.OutputBinding/Shutdown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputBindingServer).Shutdown(ctx, req.(*ShutdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutputBinding_Schema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputBindingServer).Schema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.OutputBinding/Schema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputBindingServer).Schema(ctx, req.(*SchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OutputBinding_Warmup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WarmupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OutputBindingServer).Warmup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.OutputBinding/Warmup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OutputBindingServer).Warmup(ctx, req.(*WarmupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // OutputBinding_ServiceDesc is the grpc.ServiceDesc for OutputBinding service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -414,6 +654,18 @@ var OutputBinding_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _OutputBinding_Ping_Handler,
 		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _OutputBinding_Shutdown_Handler,
+		},
+		{
+			MethodName: "Schema",
+			Handler:    _OutputBinding_Schema_Handler,
+		},
+		{
+			MethodName: "Warmup",
+			Handler:    _OutputBinding_Warmup_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "dapr/proto/components/v1/bindings.proto",
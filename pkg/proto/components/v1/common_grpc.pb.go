@@ -0,0 +1,132 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.21.12
+// source: dapr/proto/components/v1/common.proto
+
+package components
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ComponentLogsClient is the client API for ComponentLogs service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ComponentLogsClient interface {
+	// Log streams the component log entries to the sidecar until the connection is closed.
+	Log(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (ComponentLogs_LogClient, error)
+}
+
+type componentLogsClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewComponentLogsClient(cc grpc.ClientConnInterface) ComponentLogsClient {
+	return &componentLogsClient{cc}
+}
+
+func (c *componentLogsClient) Log(ctx context.Context, in *LogRequest, opts ...grpc.CallOption) (ComponentLogs_LogClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ComponentLogs_ServiceDesc.Streams[0], "/dapr.proto.components.v1.ComponentLogs/Log", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &componentLogsLogClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ComponentLogs_LogClient interface {
+	Recv() (*LogEntry, error)
+	grpc.ClientStream
+}
+
+type componentLogsLogClient struct {
+	grpc.ClientStream
+}
+
+func (x *componentLogsLogClient) Recv() (*LogEntry, error) {
+	m := new(LogEntry)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ComponentLogsServer is the server API for ComponentLogs service.
+// All implementations should embed UnimplementedComponentLogsServer
+// for forward compatibility
+type ComponentLogsServer interface {
+	// Log streams the component log entries to the sidecar until the connection is closed.
+	Log(*LogRequest, ComponentLogs_LogServer) error
+}
+
+// UnimplementedComponentLogsServer should be embedded to have forward compatible implementations.
+type UnimplementedComponentLogsServer struct {
+}
+
+func (UnimplementedComponentLogsServer) Log(*LogRequest, ComponentLogs_LogServer) error {
+	return status.Errorf(codes.Unimplemented, "method Log not implemented")
+}
+
+// UnsafeComponentLogsServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ComponentLogsServer will
+// result in compilation errors.
+type UnsafeComponentLogsServer interface {
+	mustEmbedUnimplementedComponentLogsServer()
+}
+
+func RegisterComponentLogsServer(s grpc.ServiceRegistrar, srv ComponentLogsServer) {
+	s.RegisterService(&ComponentLogs_ServiceDesc, srv)
+}
+
+func _ComponentLogs_Log_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LogRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ComponentLogsServer).Log(m, &componentLogsLogServer{stream})
+}
+
+type ComponentLogs_LogServer interface {
+	Send(*LogEntry) error
+	grpc.ServerStream
+}
+
+type componentLogsLogServer struct {
+	grpc.ServerStream
+}
+
+func (x *componentLogsLogServer) Send(m *LogEntry) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// ComponentLogs_ServiceDesc is the grpc.ServiceDesc for ComponentLogs service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ComponentLogs_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "dapr.proto.components.v1.ComponentLogs",
+	HandlerType: (*ComponentLogsServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Log",
+			Handler:       _ComponentLogs_Log_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "dapr/proto/components/v1/common.proto",
+}
@@ -38,6 +38,18 @@ type PubSubClient interface {
 	PullMessages(ctx context.Context, opts ...grpc.CallOption) (PubSub_PullMessagesClient, error)
 	// Ping the pubsub. Used for liveness porpuses.
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	// Optional. Shutdown signals the component that the runtime is closing its
+	// connection, e.g. to flush buffers or checkpoint. Components that don't
+	// implement it return Unimplemented.
+	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
+	// Optional. Schema returns the metadata accepted by the component, used to
+	// validate the component metadata before Init. Components that don't
+	// implement it return Unimplemented.
+	Schema(ctx context.Context, in *SchemaRequest, opts ...grpc.CallOption) (*SchemaResponse, error)
+	// Optional. Warmup lets the component pre-establish its backend connections
+	// or prime its caches after Init, before serving traffic. Failures are not
+	// fatal. Components that don't implement it return Unimplemented.
+	Warmup(ctx context.Context, in *WarmupRequest, opts ...grpc.CallOption) (*WarmupResponse, error)
 }
 
 type pubSubClient struct {
@@ -124,6 +136,33 @@ func (c *pubSubClient) Ping(ctx context.Context, in *PingRequest, opts ...grpc.C
 	return out, nil
 }
 
+func (c *pubSubClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
+	out := new(ShutdownResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.PubSub/Shutdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pubSubClient) Schema(ctx context.Context, in *SchemaRequest, opts ...grpc.CallOption) (*SchemaResponse, error) {
+	out := new(SchemaResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.PubSub/Schema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pubSubClient) Warmup(ctx context.Context, in *WarmupRequest, opts ...grpc.CallOption) (*WarmupResponse, error) {
+	out := new(WarmupResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.PubSub/Warmup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // PubSubServer is the server API for PubSub service.
 // All implementations should embed UnimplementedPubSubServer
 // for forward compatibility
@@ -144,6 +183,18 @@ type PubSubServer interface {
 	PullMessages(PubSub_PullMessagesServer) error
 	// Ping the pubsub. Used for liveness porpuses.
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	// Optional. Shutdown signals the component that the runtime is closing its
+	// connection, e.g. to flush buffers or checkpoint. Components that don't
+	// implement it return Unimplemented.
+	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
+	// Optional. Schema returns the metadata accepted by the component, used to
+	// validate the component metadata before Init. Components that don't
+	// implement it return Unimplemented.
+	Schema(context.Context, *SchemaRequest) (*SchemaResponse, error)
+	// Optional. Warmup lets the component pre-establish its backend connections
+	// or prime its caches after Init, before serving traffic. Failures are not
+	// fatal. Components that don't implement it return Unimplemented.
+	Warmup(context.Context, *WarmupRequest) (*WarmupResponse, error)
 }
 
 // UnimplementedPubSubServer should be embedded to have forward compatible implementations.
@@ -168,6 +219,15 @@ func (UnimplementedPubSubServer) PullMessages(PubSub_PullMessagesServer) error {
 func (UnimplementedPubSubServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
+func (UnimplementedPubSubServer) Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
+func (UnimplementedPubSubServer) Schema(context.Context, *SchemaRequest) (*SchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Schema not implemented")
+}
+func (UnimplementedPubSubServer) Warmup(context.Context, *WarmupRequest) (*WarmupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Warmup not implemented")
+}
 
 // UnsafePubSubServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to PubSubServer will
@@ -296,6 +356,60 @@ func _PubSub_Ping_Handler(srv interface{}, ctx context.Context, dec func(interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PubSub_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShutdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PubSubServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.PubSub/Shutdown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PubSubServer).Shutdown(ctx, req.(*ShutdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PubSub_Schema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PubSubServer).Schema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.PubSub/Schema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PubSubServer).Schema(ctx, req.(*SchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PubSub_Warmup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WarmupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PubSubServer).Warmup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.PubSub/Warmup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PubSubServer).Warmup(ctx, req.(*WarmupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // PubSub_ServiceDesc is the grpc.ServiceDesc for PubSub service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -323,6 +437,18 @@ var PubSub_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _PubSub_Ping_Handler,
 		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _PubSub_Shutdown_Handler,
+		},
+		{
+			MethodName: "Schema",
+			Handler:    _PubSub_Schema_Handler,
+		},
+		{
+			MethodName: "Warmup",
+			Handler:    _PubSub_Warmup_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
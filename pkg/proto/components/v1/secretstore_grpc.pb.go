@@ -32,6 +32,18 @@ type SecretStoreClient interface {
 	BulkGet(ctx context.Context, in *BulkGetSecretRequest, opts ...grpc.CallOption) (*BulkGetSecretResponse, error)
 	// Ping the pubsub. Used for liveness porpuses.
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	// Optional. Shutdown signals the component that the runtime is closing its
+	// connection, e.g. to flush buffers or checkpoint. Components that don't
+	// implement it return Unimplemented.
+	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
+	// Optional. Schema returns the metadata accepted by the component, used to
+	// validate the component metadata before Init. Components that don't
+	// implement it return Unimplemented.
+	Schema(ctx context.Context, in *SchemaRequest, opts ...grpc.CallOption) (*SchemaResponse, error)
+	// Optional. Warmup lets the component pre-establish its backend connections
+	// or prime its caches after Init, before serving traffic. Failures are not
+	// fatal. Components that don't implement it return Unimplemented.
+	Warmup(ctx context.Context, in *WarmupRequest, opts ...grpc.CallOption) (*WarmupResponse, error)
 }
 
 type secretStoreClient struct {
@@ -87,6 +99,33 @@ func (c *secretStoreClient) Ping(ctx context.Context, in *PingRequest, opts ...g
 	return out, nil
 }
 
+func (c *secretStoreClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
+	out := new(ShutdownResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.SecretStore/Shutdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretStoreClient) Schema(ctx context.Context, in *SchemaRequest, opts ...grpc.CallOption) (*SchemaResponse, error) {
+	out := new(SchemaResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.SecretStore/Schema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *secretStoreClient) Warmup(ctx context.Context, in *WarmupRequest, opts ...grpc.CallOption) (*WarmupResponse, error) {
+	out := new(WarmupResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.SecretStore/Warmup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SecretStoreServer is the server API for SecretStore service.
 // All implementations should embed UnimplementedSecretStoreServer
 // for forward compatibility
@@ -101,6 +140,18 @@ type SecretStoreServer interface {
 	BulkGet(context.Context, *BulkGetSecretRequest) (*BulkGetSecretResponse, error)
 	// Ping the pubsub. Used for liveness porpuses.
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	// Optional. Shutdown signals the component that the runtime is closing its
+	// connection, e.g. to flush buffers or checkpoint. Components that don't
+	// implement it return Unimplemented.
+	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
+	// Optional. Schema returns the metadata accepted by the component, used to
+	// validate the component metadata before Init. Components that don't
+	// implement it return Unimplemented.
+	Schema(context.Context, *SchemaRequest) (*SchemaResponse, error)
+	// Optional. Warmup lets the component pre-establish its backend connections
+	// or prime its caches after Init, before serving traffic. Failures are not
+	// fatal. Components that don't implement it return Unimplemented.
+	Warmup(context.Context, *WarmupRequest) (*WarmupResponse, error)
 }
 
 // UnimplementedSecretStoreServer should be embedded to have forward compatible implementations.
@@ -122,6 +173,15 @@ func (UnimplementedSecretStoreServer) BulkGet(context.Context, *BulkGetSecretReq
 func (UnimplementedSecretStoreServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
+func (UnimplementedSecretStoreServer) Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
+func (UnimplementedSecretStoreServer) Schema(context.Context, *SchemaRequest) (*SchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Schema not implemented")
+}
+func (UnimplementedSecretStoreServer) Warmup(context.Context, *WarmupRequest) (*WarmupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Warmup not implemented")
+}
 
 // UnsafeSecretStoreServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to SecretStoreServer will
@@ -224,6 +284,60 @@ func _SecretStore_Ping_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _SecretStore_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShutdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretStoreServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.SecretStore/Shutdown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretStoreServer).Shutdown(ctx, req.(*ShutdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretStore_Schema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretStoreServer).Schema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.SecretStore/Schema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretStoreServer).Schema(ctx, req.(*SchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SecretStore_Warmup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WarmupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SecretStoreServer).Warmup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.SecretStore/Warmup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SecretStoreServer).Warmup(ctx, req.(*WarmupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SecretStore_ServiceDesc is the grpc.ServiceDesc for SecretStore service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -251,6 +365,18 @@ var SecretStore_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _SecretStore_Ping_Handler,
 		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _SecretStore_Shutdown_Handler,
+		},
+		{
+			MethodName: "Schema",
+			Handler:    _SecretStore_Schema_Handler,
+		},
+		{
+			MethodName: "Warmup",
+			Handler:    _SecretStore_Warmup_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "dapr/proto/components/v1/secretstore.proto",
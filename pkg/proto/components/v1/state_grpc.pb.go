@@ -201,11 +201,25 @@ type StateStoreClient interface {
 	// Deletes the specified key from the state store.
 	Delete(ctx context.Context, in *DeleteRequest, opts ...grpc.CallOption) (*DeleteResponse, error)
 	// Get data from the given key.
+	// Missing keys must be reported with an empty GetResponse instead of an
+	// error, errors are reserved for actual failures.
 	Get(ctx context.Context, in *GetRequest, opts ...grpc.CallOption) (*GetResponse, error)
 	// Sets the value of the specified key.
 	Set(ctx context.Context, in *SetRequest, opts ...grpc.CallOption) (*SetResponse, error)
 	// Ping the state store. Used for liveness porpuses.
 	Ping(ctx context.Context, in *PingRequest, opts ...grpc.CallOption) (*PingResponse, error)
+	// Optional. Shutdown signals the component that the runtime is closing its
+	// connection, e.g. to flush buffers or checkpoint. Components that don't
+	// implement it return Unimplemented.
+	Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error)
+	// Optional. Schema returns the metadata accepted by the component, used to
+	// validate the component metadata before Init. Components that don't
+	// implement it return Unimplemented.
+	Schema(ctx context.Context, in *SchemaRequest, opts ...grpc.CallOption) (*SchemaResponse, error)
+	// Optional. Warmup lets the component pre-establish its backend connections
+	// or prime its caches after Init, before serving traffic. Failures are not
+	// fatal. Components that don't implement it return Unimplemented.
+	Warmup(ctx context.Context, in *WarmupRequest, opts ...grpc.CallOption) (*WarmupResponse, error)
 	// Deletes many keys at once.
 	BulkDelete(ctx context.Context, in *BulkDeleteRequest, opts ...grpc.CallOption) (*BulkDeleteResponse, error)
 	// Retrieves many keys at once.
@@ -276,6 +290,33 @@ func (c *stateStoreClient) Ping(ctx context.Context, in *PingRequest, opts ...gr
 	return out, nil
 }
 
+func (c *stateStoreClient) Shutdown(ctx context.Context, in *ShutdownRequest, opts ...grpc.CallOption) (*ShutdownResponse, error) {
+	out := new(ShutdownResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/Shutdown", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) Schema(ctx context.Context, in *SchemaRequest, opts ...grpc.CallOption) (*SchemaResponse, error) {
+	out := new(SchemaResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/Schema", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stateStoreClient) Warmup(ctx context.Context, in *WarmupRequest, opts ...grpc.CallOption) (*WarmupResponse, error) {
+	out := new(WarmupResponse)
+	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/Warmup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *stateStoreClient) BulkDelete(ctx context.Context, in *BulkDeleteRequest, opts ...grpc.CallOption) (*BulkDeleteResponse, error) {
 	out := new(BulkDeleteResponse)
 	err := c.cc.Invoke(ctx, "/dapr.proto.components.v1.StateStore/BulkDelete", in, out, opts...)
@@ -314,11 +355,25 @@ type StateStoreServer interface {
 	// Deletes the specified key from the state store.
 	Delete(context.Context, *DeleteRequest) (*DeleteResponse, error)
 	// Get data from the given key.
+	// Missing keys must be reported with an empty GetResponse instead of an
+	// error, errors are reserved for actual failures.
 	Get(context.Context, *GetRequest) (*GetResponse, error)
 	// Sets the value of the specified key.
 	Set(context.Context, *SetRequest) (*SetResponse, error)
 	// Ping the state store. Used for liveness porpuses.
 	Ping(context.Context, *PingRequest) (*PingResponse, error)
+	// Optional. Shutdown signals the component that the runtime is closing its
+	// connection, e.g. to flush buffers or checkpoint. Components that don't
+	// implement it return Unimplemented.
+	Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error)
+	// Optional. Schema returns the metadata accepted by the component, used to
+	// validate the component metadata before Init. Components that don't
+	// implement it return Unimplemented.
+	Schema(context.Context, *SchemaRequest) (*SchemaResponse, error)
+	// Optional. Warmup lets the component pre-establish its backend connections
+	// or prime its caches after Init, before serving traffic. Failures are not
+	// fatal. Components that don't implement it return Unimplemented.
+	Warmup(context.Context, *WarmupRequest) (*WarmupResponse, error)
 	// Deletes many keys at once.
 	BulkDelete(context.Context, *BulkDeleteRequest) (*BulkDeleteResponse, error)
 	// Retrieves many keys at once.
@@ -349,6 +404,15 @@ func (UnimplementedStateStoreServer) Set(context.Context, *SetRequest) (*SetResp
 func (UnimplementedStateStoreServer) Ping(context.Context, *PingRequest) (*PingResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
+func (UnimplementedStateStoreServer) Shutdown(context.Context, *ShutdownRequest) (*ShutdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Shutdown not implemented")
+}
+func (UnimplementedStateStoreServer) Schema(context.Context, *SchemaRequest) (*SchemaResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Schema not implemented")
+}
+func (UnimplementedStateStoreServer) Warmup(context.Context, *WarmupRequest) (*WarmupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Warmup not implemented")
+}
 func (UnimplementedStateStoreServer) BulkDelete(context.Context, *BulkDeleteRequest) (*BulkDeleteResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method BulkDelete not implemented")
 }
@@ -478,6 +542,60 @@ func _StateStore_Ping_Handler(srv interface{}, ctx context.Context, dec func(int
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StateStore_Shutdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ShutdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).Shutdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/Shutdown",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).Shutdown(ctx, req.(*ShutdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_Schema_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SchemaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).Schema(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/Schema",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).Schema(ctx, req.(*SchemaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StateStore_Warmup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WarmupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StateStoreServer).Warmup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/dapr.proto.components.v1.StateStore/Warmup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StateStoreServer).Warmup(ctx, req.(*WarmupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StateStore_BulkDelete_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(BulkDeleteRequest)
 	if err := dec(in); err != nil {
@@ -563,6 +681,18 @@ var StateStore_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Ping",
 			Handler:    _StateStore_Ping_Handler,
 		},
+		{
+			MethodName: "Shutdown",
+			Handler:    _StateStore_Shutdown_Handler,
+		},
+		{
+			MethodName: "Schema",
+			Handler:    _StateStore_Schema_Handler,
+		},
+		{
+			MethodName: "Warmup",
+			Handler:    _StateStore_Warmup_Handler,
+		},
 		{
 			MethodName: "BulkDelete",
 			Handler:    _StateStore_BulkDelete_Handler,
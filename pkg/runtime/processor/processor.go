@@ -15,6 +15,7 @@ package processor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"sync"
@@ -23,6 +24,7 @@ import (
 	contribpubsub "github.com/dapr/components-contrib/pubsub"
 	compapi "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
 	"github.com/dapr/dapr/pkg/components"
+	"github.com/dapr/dapr/pkg/components/pluggable"
 	"github.com/dapr/dapr/pkg/config"
 	configmodes "github.com/dapr/dapr/pkg/config/modes"
 	"github.com/dapr/dapr/pkg/modes"
@@ -44,8 +46,11 @@ import (
 	"github.com/dapr/dapr/pkg/runtime/processor/state"
 	"github.com/dapr/dapr/pkg/runtime/processor/workflow"
 	"github.com/dapr/dapr/pkg/runtime/registry"
+	"github.com/dapr/kit/logger"
 )
 
+var log = logger.NewLogger("dapr.runtime.processor")
+
 type Options struct {
 	// ID is the ID of this Dapr instance.
 	ID string
@@ -90,12 +95,20 @@ type Options struct {
 	OperatorClient operatorv1.OperatorClient
 }
 
+// ErrComponentRemoved is returned by Init when the component is removed while it is being initialized.
+var ErrComponentRemoved = errors.New("component removed during init")
+
 // manager implements the life cycle events of a component category.
 type manager interface {
 	Init(context.Context, compapi.Component) error
 	Close(compapi.Component) error
 }
 
+// reinitializer is implemented by the managers whose components can be re-initialized in place.
+type reinitializer interface {
+	Reinit(context.Context, compapi.Component) error
+}
+
 type StateManager interface {
 	ActorStateStoreName() (string, bool)
 	manager
@@ -128,6 +141,10 @@ type Processor struct {
 	binding   BindingManager
 
 	lock sync.RWMutex
+
+	// pendingInits holds the cancel functions of the components being initialized, see CancelInit.
+	pendingInits     map[string]context.CancelCauseFunc
+	pendingInitsLock sync.Mutex
 }
 
 func New(opts Options) *Processor {
@@ -168,10 +185,11 @@ func New(opts Options) *Processor {
 	})
 
 	return &Processor{
-		compStore: opts.ComponentStore,
-		state:     state,
-		pubsub:    ps,
-		binding:   binding,
+		compStore:    opts.ComponentStore,
+		state:        state,
+		pubsub:       ps,
+		binding:      binding,
+		pendingInits: make(map[string]context.CancelCauseFunc),
 		managers: map[components.Category]manager{
 			components.CategoryBindings: binding,
 			components.CategoryConfiguration: configuration.New(configuration.Options{
@@ -207,7 +225,12 @@ func New(opts Options) *Processor {
 }
 
 // Init initializes a component of a category.
+// The init context is cancelled when the component is removed while it is being initialized, see CancelInit,
+// the component is then closed instead of being registered and an ErrComponentRemoved error is returned.
 func (p *Processor) Init(ctx context.Context, comp compapi.Component) error {
+	ctx, done := p.trackInit(ctx, comp)
+	defer done()
+
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
@@ -216,7 +239,77 @@ func (p *Processor) Init(ctx context.Context, comp compapi.Component) error {
 		return err
 	}
 
-	if err := m.Init(ctx, comp); err != nil {
+	err = m.Init(ctx, comp)
+	if errors.Is(context.Cause(ctx), ErrComponentRemoved) {
+		// components that complete their init regardless of the context are closed as they are no longer wanted.
+		if closeErr := m.Close(comp); closeErr != nil {
+			log.Warnf("Failed to close component %s removed during init: %s", comp.LogName(), closeErr)
+		}
+		return fmt.Errorf("%w: %s", ErrComponentRemoved, comp.LogName())
+	}
+	if err != nil {
+		return err
+	}
+
+	p.compStore.AddComponent(comp)
+
+	return nil
+}
+
+// trackInit returns the context used to initialize the given component, which is cancelled by CancelInit.
+// The returned function must be called once the init completes.
+func (p *Processor) trackInit(ctx context.Context, comp compapi.Component) (context.Context, func()) {
+	ctx, cancel := context.WithCancelCause(ctx)
+	key := pendingInitKey(comp)
+
+	p.pendingInitsLock.Lock()
+	p.pendingInits[key] = cancel
+	p.pendingInitsLock.Unlock()
+
+	return ctx, func() {
+		p.pendingInitsLock.Lock()
+		delete(p.pendingInits, key)
+		p.pendingInitsLock.Unlock()
+		cancel(nil)
+	}
+}
+
+// CancelInit cancels the in-flight init of the given component, if any, returning true when an init was cancelled.
+// It is meant for components removed while they are still being initialized, e.g. during startup.
+func (p *Processor) CancelInit(comp compapi.Component) bool {
+	p.pendingInitsLock.Lock()
+	defer p.pendingInitsLock.Unlock()
+
+	cancel, ok := p.pendingInits[pendingInitKey(comp)]
+	if ok {
+		cancel(ErrComponentRemoved)
+	}
+	return ok
+}
+
+// pendingInitKey returns the key identifying the init of the given component.
+func pendingInitKey(comp compapi.Component) string {
+	return comp.Spec.Type + "/" + comp.Name
+}
+
+// Reinit re-initializes an already initialized component with its updated spec, keeping the component instance.
+// It returns a pluggable.ErrReinitUnsupported error when the component cannot be re-initialized in place,
+// callers should then fall back to closing and initializing the component again.
+func (p *Processor) Reinit(ctx context.Context, comp compapi.Component) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	m, err := p.managerFromComp(comp)
+	if err != nil {
+		return err
+	}
+
+	r, ok := m.(reinitializer)
+	if !ok {
+		return fmt.Errorf("%w: %s", pluggable.ErrReinitUnsupported, comp.LogName())
+	}
+
+	if err := r.Reinit(ctx, comp); err != nil {
 		return err
 	}
 
@@ -226,7 +319,12 @@ func (p *Processor) Init(ctx context.Context, comp compapi.Component) error {
 }
 
 // Close closes the component.
+// A component removed while it is still being initialized has its init cancelled first, see CancelInit.
 func (p *Processor) Close(comp compapi.Component) error {
+	if p.CancelInit(comp) {
+		log.Infof("Cancelled the init of component %s being removed", comp.LogName())
+	}
+
 	p.lock.Lock()
 	defer p.lock.Unlock()
 
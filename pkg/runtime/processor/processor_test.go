@@ -14,13 +14,24 @@ limitations under the License.
 package processor
 
 import (
+	"context"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	contribstate "github.com/dapr/components-contrib/state"
 	compapi "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	stateLoader "github.com/dapr/dapr/pkg/components/state"
 	"github.com/dapr/dapr/pkg/config"
+	"github.com/dapr/dapr/pkg/modes"
+	"github.com/dapr/dapr/pkg/runtime/compstore"
+	"github.com/dapr/dapr/pkg/runtime/meta"
 	"github.com/dapr/dapr/pkg/runtime/registry"
+	"github.com/dapr/kit/logger"
 )
 
 func TestExtractComponentCategory(t *testing.T) {
@@ -56,3 +67,76 @@ func TestExtractComponentCategory(t *testing.T) {
 		})
 	}
 }
+
+// slowInitStore is a state store whose init blocks until released, regardless of the init context.
+type slowInitStore struct {
+	contribstate.Store
+	initStarted chan struct{}
+	release     chan struct{}
+	closed      atomic.Bool
+	// initCtx is the context the init was called with.
+	initCtx context.Context
+}
+
+func (s *slowInitStore) Init(ctx context.Context, _ contribstate.Metadata) error {
+	s.initCtx = ctx
+	close(s.initStarted)
+	<-s.release
+	return nil
+}
+
+func (s *slowInitStore) Close() error {
+	s.closed.Store(true)
+	return nil
+}
+
+func TestInitCancelledOnRemoval(t *testing.T) {
+	newProcessor := func(store contribstate.Store) (*Processor, *compstore.ComponentStore) {
+		reg := registry.New(registry.NewOptions().WithStateStores(stateLoader.NewRegistry()))
+		reg.StateStores().RegisterComponent(func(logger.Logger) contribstate.Store { return store }, "slow")
+		compStore := compstore.New()
+		return New(Options{
+			Registry:       reg,
+			ComponentStore: compStore,
+			GlobalConfig:   new(config.Configuration),
+			Meta:           meta.New(meta.Options{Mode: modes.StandaloneMode}),
+		}), compStore
+	}
+	comp := compapi.Component{
+		ObjectMeta: metav1.ObjectMeta{Name: "slowstore"},
+		Spec:       compapi.ComponentSpec{Type: "state.slow", Version: "v1"},
+	}
+
+	t.Run("removing a component mid-init should cancel its init and leave no registered component", func(t *testing.T) {
+		store := &slowInitStore{initStarted: make(chan struct{}), release: make(chan struct{})}
+		p, compStore := newProcessor(store)
+
+		initErr := make(chan error, 1)
+		go func() {
+			initErr <- p.Init(context.Background(), comp)
+		}()
+		<-store.initStarted
+
+		closeErr := make(chan error, 1)
+		go func() {
+			closeErr <- p.Close(comp)
+		}()
+		assert.Eventually(t, func() bool {
+			return store.initCtx.Err() != nil
+		}, time.Second, 10*time.Millisecond, "the init context should be cancelled")
+		close(store.release)
+
+		require.ErrorIs(t, <-initErr, ErrComponentRemoved)
+		require.NoError(t, <-closeErr)
+		assert.True(t, store.closed.Load(), "the half-initialized component should be closed")
+		_, ok := compStore.GetStateStore(comp.Name)
+		assert.False(t, ok)
+		_, ok = compStore.GetComponent(comp.Spec.Type, comp.Name)
+		assert.False(t, ok)
+	})
+
+	t.Run("cancelling the init of a component that is not being initialized should be a no-op", func(t *testing.T) {
+		p, _ := newProcessor(&slowInitStore{})
+		assert.False(t, p.CancelInit(comp))
+	})
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/dapr/components-contrib/contenttype"
 	contribpubsub "github.com/dapr/components-contrib/pubsub"
 	compapi "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/components/pluggable"
 	comppubsub "github.com/dapr/dapr/pkg/components/pubsub"
 	"github.com/dapr/dapr/pkg/config"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
@@ -180,6 +181,49 @@ func (p *pubsub) Init(ctx context.Context, comp compapi.Component) error {
 	return nil
 }
 
+// reinitPubSub is implemented by the pubsubs that can be re-initialized in place, such as pluggable pubsubs.
+type reinitPubSub interface {
+	Reinit(context.Context, contribpubsub.Metadata) error
+}
+
+// Reinit re-initializes the pubsub with the updated component metadata, active subscriptions are kept.
+func (p *pubsub) Reinit(ctx context.Context, comp compapi.Component) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	fName := comp.LogName()
+	ps, _ := p.compStore.GetPubSub(comp.ObjectMeta.Name)
+	r, ok := ps.Component.(reinitPubSub)
+	if !ok {
+		return fmt.Errorf("%w: %s", pluggable.ErrReinitUnsupported, fName)
+	}
+
+	baseMetadata, err := p.meta.ToBaseMetadata(comp)
+	if err != nil {
+		return rterrors.NewInit(rterrors.InitComponentFailure, fName, err)
+	}
+
+	properties := baseMetadata.Properties
+	consumerID := strings.TrimSpace(properties["consumerID"])
+	if consumerID == "" {
+		consumerID = p.id
+	}
+	properties["consumerID"] = consumerID
+
+	if err = r.Reinit(ctx, contribpubsub.Metadata{Base: baseMetadata}); err != nil {
+		return rterrors.NewInit(rterrors.InitComponentFailure, fName, err)
+	}
+
+	ps.ScopedSubscriptions = scopes.GetScopedTopics(scopes.SubscriptionScopes, p.id, properties)
+	ps.ScopedPublishings = scopes.GetScopedTopics(scopes.PublishingScopes, p.id, properties)
+	ps.AllowedTopics = scopes.GetAllowedTopics(properties)
+	ps.ProtectedTopics = scopes.GetProtectedTopics(properties)
+	ps.NamespaceScoped = meta.ContainsNamespace(comp.Spec.Metadata)
+	p.compStore.AddPubSub(comp.ObjectMeta.Name, ps)
+
+	return nil
+}
+
 func (p *pubsub) Close(comp compapi.Component) error {
 	p.lock.Lock()
 	defer p.lock.Unlock()
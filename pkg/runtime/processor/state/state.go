@@ -22,6 +22,7 @@ import (
 
 	contribstate "github.com/dapr/components-contrib/state"
 	compapi "github.com/dapr/dapr/pkg/apis/components/v1alpha1"
+	"github.com/dapr/dapr/pkg/components/pluggable"
 	compstate "github.com/dapr/dapr/pkg/components/state"
 	diag "github.com/dapr/dapr/pkg/diagnostics"
 	"github.com/dapr/dapr/pkg/encryption"
@@ -80,29 +81,17 @@ func (s *state) Init(ctx context.Context, comp compapi.Component) error {
 	}
 
 	if store != nil {
-		secretStoreName := s.meta.AuthSecretStoreOrDefault(&comp)
-
-		secretStore, _ := s.compStore.GetSecretStore(secretStoreName)
-		encKeys, encErr := encryption.ComponentEncryptionKey(comp, secretStore)
-		if encErr != nil {
+		if err = s.addEncryptionKeys(comp); err != nil {
 			diag.DefaultMonitoring.ComponentInitFailed(comp.Spec.Type, "creation", comp.ObjectMeta.Name)
 			return rterrors.NewInit(rterrors.CreateComponentFailure, fName, err)
 		}
 
-		if encKeys.Primary.Key != "" {
-			ok := encryption.AddEncryptedStateStore(comp.ObjectMeta.Name, encKeys)
-			if ok {
-				log.Infof("automatic encryption enabled for state store %s", comp.ObjectMeta.Name)
-			}
-		}
-
 		meta, err := s.meta.ToBaseMetadata(comp)
 		if err != nil {
 			diag.DefaultMonitoring.ComponentInitFailed(comp.Spec.Type, "init", comp.ObjectMeta.Name)
 			return rterrors.NewInit(rterrors.InitComponentFailure, fName, err)
 		}
 
-		props := meta.Properties
 		err = store.Init(ctx, contribstate.Metadata{Base: meta})
 		if err != nil {
 			diag.DefaultMonitoring.ComponentInitFailed(comp.Spec.Type, "init", comp.ObjectMeta.Name)
@@ -110,43 +99,105 @@ func (s *state) Init(ctx context.Context, comp compapi.Component) error {
 		}
 
 		s.compStore.AddStateStore(comp.ObjectMeta.Name, store)
-		err = compstate.SaveStateConfiguration(comp.ObjectMeta.Name, props)
-		if err != nil {
+		if err = s.afterInit(comp, meta.Properties); err != nil {
 			diag.DefaultMonitoring.ComponentInitFailed(comp.Spec.Type, "init", comp.ObjectMeta.Name)
-			wrapError := fmt.Errorf("failed to save lock keyprefix: %s", err.Error())
-			return rterrors.NewInit(rterrors.InitComponentFailure, fName, wrapError)
+			return err
 		}
 
-		s.outbox.AddOrUpdateOutbox(comp)
-
-		// when placement address list is not empty, set specified actor store.
-		if s.placementEnabled {
-			// set specified actor store if "actorStateStore" is true in the spec.
-			actorStoreSpecified := false
-			for k, v := range props {
-				//nolint:gocritic
-				if strings.ToLower(k) == propertyKeyActorStateStore {
-					actorStoreSpecified = utils.IsTruthy(v)
-					break
-				}
-			}
-
-			if actorStoreSpecified {
-				if s.actorStateStoreName == nil {
-					log.Info("Using '" + comp.ObjectMeta.Name + "' as actor state store")
-					s.actorStateStoreName = &comp.ObjectMeta.Name
-				} else if *s.actorStateStoreName != comp.ObjectMeta.Name {
-					return fmt.Errorf("detected duplicate actor state store: %s and %s", *s.actorStateStoreName, comp.ObjectMeta.Name)
-				}
-			}
+		diag.DefaultMonitoring.ComponentInitialized(comp.Spec.Type)
+	}
+
+	return nil
+}
+
+// addEncryptionKeys derives the encryption keys of the given state store from its spec and enables the automatic encryption when set.
+// keys already set for the state store are replaced, so that rotated keys are taken into account on reinit.
+func (s *state) addEncryptionKeys(comp compapi.Component) error {
+	secretStore, _ := s.compStore.GetSecretStore(s.meta.AuthSecretStoreOrDefault(&comp))
+	encKeys, err := encryption.ComponentEncryptionKey(comp, secretStore)
+	if err != nil {
+		return err
+	}
+
+	if encKeys.Primary.Key != "" {
+		if encryption.UpdateEncryptedStateStore(comp.ObjectMeta.Name, encKeys) {
+			log.Infof("automatic encryption enabled for state store %s", comp.ObjectMeta.Name)
 		}
+	}
+	return nil
+}
 
-		diag.DefaultMonitoring.ComponentInitialized(comp.Spec.Type)
+// afterInit applies the component properties once the state store is initialized: its configuration, its outbox and its actor state store flag.
+func (s *state) afterInit(comp compapi.Component, props map[string]string) error {
+	fName := comp.LogName()
+	if err := compstate.SaveStateConfiguration(comp.ObjectMeta.Name, props); err != nil {
+		wrapError := fmt.Errorf("failed to save lock keyprefix: %s", err.Error())
+		return rterrors.NewInit(rterrors.InitComponentFailure, fName, wrapError)
+	}
+
+	s.outbox.AddOrUpdateOutbox(comp)
+
+	// when placement address list is not empty, set specified actor store.
+	if !s.placementEnabled {
+		return nil
 	}
 
+	// set specified actor store if "actorStateStore" is true in the spec.
+	actorStoreSpecified := false
+	for k, v := range props {
+		//nolint:gocritic
+		if strings.ToLower(k) == propertyKeyActorStateStore {
+			actorStoreSpecified = utils.IsTruthy(v)
+			break
+		}
+	}
+
+	switch {
+	case actorStoreSpecified && s.actorStateStoreName == nil:
+		log.Info("Using '" + comp.ObjectMeta.Name + "' as actor state store")
+		s.actorStateStoreName = &comp.ObjectMeta.Name
+	case actorStoreSpecified && *s.actorStateStoreName != comp.ObjectMeta.Name:
+		return fmt.Errorf("detected duplicate actor state store: %s and %s", *s.actorStateStoreName, comp.ObjectMeta.Name)
+	case !actorStoreSpecified && s.actorStateStoreName != nil && *s.actorStateStoreName == comp.ObjectMeta.Name:
+		log.Warn("'" + comp.ObjectMeta.Name + "' is no longer the actor state store")
+		s.actorStateStoreName = nil
+	}
 	return nil
 }
 
+// reinitStore is implemented by the state stores that can be re-initialized in place, such as pluggable state stores.
+type reinitStore interface {
+	Reinit(context.Context, contribstate.Metadata) error
+}
+
+// Reinit re-initializes the state store with the updated component metadata, applying the same steps as Init around the store init.
+func (s *state) Reinit(ctx context.Context, comp compapi.Component) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	fName := comp.LogName()
+	store, _ := s.compStore.GetStateStore(comp.ObjectMeta.Name)
+	r, ok := store.(reinitStore)
+	if !ok {
+		return fmt.Errorf("%w: %s", pluggable.ErrReinitUnsupported, fName)
+	}
+
+	if err := s.addEncryptionKeys(comp); err != nil {
+		return rterrors.NewInit(rterrors.CreateComponentFailure, fName, err)
+	}
+
+	meta, err := s.meta.ToBaseMetadata(comp)
+	if err != nil {
+		return rterrors.NewInit(rterrors.InitComponentFailure, fName, err)
+	}
+
+	if err = r.Reinit(ctx, contribstate.Metadata{Base: meta}); err != nil {
+		return rterrors.NewInit(rterrors.InitComponentFailure, fName, err)
+	}
+
+	return s.afterInit(comp, meta.Properties)
+}
+
 func (s *state) Close(comp compapi.Component) error {
 	s.lock.Lock()
 	defer s.lock.Unlock()
@@ -94,12 +94,14 @@ func (p *defaultBulkSubscriber) BulkSubscribe(ctx context.Context, req contribPu
 }
 
 // processBulkMessages reads messages from msgChan and publishes them to a BulkHandler.
-// It buffers messages in memory and publishes them in bulk.
+// It buffers messages in memory and publishes them in bulk, either when MaxMessagesCount messages
+// are buffered or when MaxAwaitDurationMs elapses with a partial batch.
 func processBulkMessages(ctx context.Context, topic string, msgCbChan <-chan msgWithCallback, cfg contribPubsub.BulkSubscribeConfig, handler contribPubsub.BulkHandler) {
 	messages := make([]contribPubsub.BulkMessageEntry, cfg.MaxMessagesCount)
 	msgCbMap := make(map[string]func(error), cfg.MaxMessagesCount)
 
-	ticker := time.NewTicker(time.Duration(cfg.MaxAwaitDurationMs) * time.Millisecond)
+	maxAwaitDuration := time.Duration(cfg.MaxAwaitDurationMs) * time.Millisecond
+	ticker := time.NewTicker(maxAwaitDuration)
 	defer ticker.Stop()
 
 	n := 0
@@ -116,6 +118,8 @@ func processBulkMessages(ctx context.Context, topic string, msgCbChan <-chan msg
 				flushMessages(ctx, topic, messages[:n], msgCbMap, handler)
 				n = 0
 				maps.Clear(msgCbMap)
+				// restart the await duration so the next batch is not flushed early.
+				ticker.Reset(maxAwaitDuration)
 			}
 		case <-ticker.C:
 			flushMessages(ctx, topic, messages[:n], msgCbMap, handler)
@@ -16,9 +16,12 @@ package pubsub
 import (
 	"context"
 	"errors"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	contribPubsub "github.com/dapr/components-contrib/pubsub"
 )
@@ -153,3 +156,70 @@ func TestFlushMessages(t *testing.T) {
 		}
 	})
 }
+
+func TestProcessBulkMessages(t *testing.T) {
+	// startProcessing runs processBulkMessages in background and returns the channel used to send messages
+	// and the channel where the delivered batches are sent.
+	startProcessing := func(t *testing.T, cfg contribPubsub.BulkSubscribeConfig) (chan<- msgWithCallback, <-chan []contribPubsub.BulkMessageEntry) {
+		ctx, cancel := context.WithCancel(context.Background())
+		t.Cleanup(cancel)
+
+		msgCbChan := make(chan msgWithCallback, cfg.MaxMessagesCount)
+		batches := make(chan []contribPubsub.BulkMessageEntry, 10)
+		handler := func(ctx context.Context, msg *contribPubsub.BulkMessage) ([]contribPubsub.BulkSubscribeResponseEntry, error) {
+			entries := make([]contribPubsub.BulkMessageEntry, len(msg.Entries))
+			copy(entries, msg.Entries)
+			batches <- entries
+			return nil, nil
+		}
+		go processBulkMessages(ctx, "topic", msgCbChan, cfg, handler)
+		return msgCbChan, batches
+	}
+
+	send := func(msgCbChan chan<- msgWithCallback, count int) <-chan error {
+		errs := make(chan error, count)
+		for i := 0; i < count; i++ {
+			msgCbChan <- msgWithCallback{
+				msg: contribPubsub.BulkMessageEntry{EntryId: strconv.Itoa(i)},
+				cb:  func(err error) { errs <- err },
+			}
+		}
+		return errs
+	}
+
+	t.Run("messages should be flushed when max messages count is reached", func(t *testing.T) {
+		msgCbChan, batches := startProcessing(t, contribPubsub.BulkSubscribeConfig{
+			MaxMessagesCount:   3,
+			MaxAwaitDurationMs: int(time.Hour.Milliseconds()),
+		})
+		errs := send(msgCbChan, 3)
+
+		select {
+		case batch := <-batches:
+			assert.Len(t, batch, 3)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "expected the batch to be flushed when full")
+		}
+		for i := 0; i < 3; i++ {
+			assert.NoError(t, <-errs)
+		}
+	})
+
+	t.Run("partial batches should be flushed when max await duration elapses", func(t *testing.T) {
+		msgCbChan, batches := startProcessing(t, contribPubsub.BulkSubscribeConfig{
+			MaxMessagesCount:   100,
+			MaxAwaitDurationMs: 50,
+		})
+		errs := send(msgCbChan, 2)
+
+		select {
+		case batch := <-batches:
+			assert.Len(t, batch, 2)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "expected the partial batch to be flushed by the timer")
+		}
+		for i := 0; i < 2; i++ {
+			assert.NoError(t, <-errs)
+		}
+	})
+}
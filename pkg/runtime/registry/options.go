@@ -14,6 +14,8 @@ limitations under the License.
 package registry
 
 import (
+	"time"
+
 	"github.com/dapr/dapr/pkg/components/bindings"
 	"github.com/dapr/dapr/pkg/components/configuration"
 	"github.com/dapr/dapr/pkg/components/crypto"
@@ -28,17 +30,22 @@ import (
 
 // Options is the options to configure the registries
 type Options struct {
-	secret             *secretstores.Registry
-	state              *state.Registry
-	config             *configuration.Registry
-	lock               *lock.Registry
-	pubsub             *pubsub.Registry
-	nameResolution     *nameresolution.Registry
-	binding            *bindings.Registry
-	httpMiddleware     *http.Registry
-	workflow           *workflows.Registry
-	crypto             *crypto.Registry
-	componentsCallback ComponentsCallback
+	secret              *secretstores.Registry
+	state               *state.Registry
+	config              *configuration.Registry
+	lock                *lock.Registry
+	pubsub              *pubsub.Registry
+	nameResolution      *nameresolution.Registry
+	binding             *bindings.Registry
+	httpMiddleware      *http.Registry
+	workflow            *workflows.Registry
+	crypto              *crypto.Registry
+	componentsCallback  ComponentsCallback
+	requiredPluggables  []string
+	maxConcurrentDials  int
+	schemaValidation    bool
+	healthCheckInterval time.Duration
+	evictionGracePeriod time.Duration
 }
 
 func NewOptions() *Options {
@@ -120,3 +127,29 @@ func (o *Options) WithComponentsCallback(componentsCallback ComponentsCallback)
 	o.componentsCallback = componentsCallback
 	return o
 }
+
+// WithRequiredPluggables sets the pluggable components that must be ready before the runtime completes its startup.
+func (o *Options) WithRequiredPluggables(names ...string) *Options {
+	o.requiredPluggables = append(o.requiredPluggables, names...)
+	return o
+}
+
+// WithMaxConcurrentDials limits the number of pluggable components being dialed at the same time during the runtime startup.
+func (o *Options) WithMaxConcurrentDials(n int) *Options {
+	o.maxConcurrentDials = n
+	return o
+}
+
+// WithSchemaValidation validates the pluggable components metadata against the schema they advertise before their init.
+func (o *Options) WithSchemaValidation(enabled bool) *Options {
+	o.schemaValidation = enabled
+	return o
+}
+
+// WithPluggableHealthMonitor enables the pluggable components health monitor, checking them every interval and evicting those
+// unhealthy beyond the grace period, a zero grace period uses the default one. The monitor is disabled by default.
+func (o *Options) WithPluggableHealthMonitor(interval, gracePeriod time.Duration) *Options {
+	o.healthCheckInterval = interval
+	o.evictionGracePeriod = gracePeriod
+	return o
+}
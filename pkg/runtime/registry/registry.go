@@ -14,6 +14,8 @@ limitations under the License.
 package registry
 
 import (
+	"time"
+
 	"github.com/dapr/dapr/pkg/components/bindings"
 	"github.com/dapr/dapr/pkg/components/configuration"
 	"github.com/dapr/dapr/pkg/components/crypto"
@@ -48,21 +50,36 @@ type Registry struct {
 	workflow       *workflows.Registry
 	crypto         *crypto.Registry
 	componentCb    ComponentsCallback
+	// requiredPluggables are the pluggable components awaited during the runtime startup.
+	requiredPluggables []string
+	// maxConcurrentDials is the max number of pluggable components dialed at the same time, zero means unlimited.
+	maxConcurrentDials int
+	// schemaValidation validates the pluggable components metadata against their schema before their init.
+	schemaValidation bool
+	// healthCheckInterval is the interval between the pluggable components health checks, zero disables the health monitor.
+	healthCheckInterval time.Duration
+	// evictionGracePeriod is the amount of time a pluggable component can be unhealthy before being evicted.
+	evictionGracePeriod time.Duration
 }
 
 func New(opts *Options) *Registry {
 	return &Registry{
-		secret:         opts.secret,
-		state:          opts.state,
-		config:         opts.config,
-		lock:           opts.lock,
-		pubsub:         opts.pubsub,
-		nameResolution: opts.nameResolution,
-		binding:        opts.binding,
-		httpMiddleware: opts.httpMiddleware,
-		workflow:       opts.workflow,
-		crypto:         opts.crypto,
-		componentCb:    opts.componentsCallback,
+		secret:              opts.secret,
+		state:               opts.state,
+		config:              opts.config,
+		lock:                opts.lock,
+		pubsub:              opts.pubsub,
+		nameResolution:      opts.nameResolution,
+		binding:             opts.binding,
+		httpMiddleware:      opts.httpMiddleware,
+		workflow:            opts.workflow,
+		crypto:              opts.crypto,
+		componentCb:         opts.componentsCallback,
+		requiredPluggables:  opts.requiredPluggables,
+		maxConcurrentDials:  opts.maxConcurrentDials,
+		schemaValidation:    opts.schemaValidation,
+		healthCheckInterval: opts.healthCheckInterval,
+		evictionGracePeriod: opts.evictionGracePeriod,
 	}
 }
 
@@ -109,3 +126,23 @@ func (r *Registry) Crypto() *crypto.Registry {
 func (r *Registry) ComponentsCallback() ComponentsCallback {
 	return r.componentCb
 }
+
+func (r *Registry) RequiredPluggables() []string {
+	return r.requiredPluggables
+}
+
+func (r *Registry) MaxConcurrentDials() int {
+	return r.maxConcurrentDials
+}
+
+func (r *Registry) SchemaValidation() bool {
+	return r.schemaValidation
+}
+
+func (r *Registry) HealthCheckInterval() time.Duration {
+	return r.healthCheckInterval
+}
+
+func (r *Registry) EvictionGracePeriod() time.Duration {
+	return r.evictionGracePeriod
+}
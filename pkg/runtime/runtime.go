@@ -427,7 +427,9 @@ func (a *DaprRuntime) initRuntime(ctx context.Context) error {
 
 	a.initDirectMessaging(a.nameResolver)
 
-	a.initPluggableComponents(ctx)
+	if err = a.initPluggableComponents(ctx); err != nil {
+		return err
+	}
 
 	if _, ok := os.LookupEnv(hotReloadingEnvVar); ok {
 		log.Debug("starting to watch component updates")
@@ -619,14 +621,48 @@ func (a *DaprRuntime) initWorkflowEngine(ctx context.Context) {
 }
 
 // initPluggableComponents discover pluggable components and initialize with their respective registries.
-func (a *DaprRuntime) initPluggableComponents(ctx context.Context) {
+// It waits for the required pluggable components to be ready before discovering them, failing when they are not ready in time.
+func (a *DaprRuntime) initPluggableComponents(ctx context.Context) error {
 	if runtime.GOOS == "windows" {
 		log.Debugf("the current OS does not support pluggable components feature, skipping initialization")
-		return
+		return nil
+	}
+	pluggable.SetAppIdentity(a.runtimeConfig.id, a.namespace)
+	unsubscribe := pluggable.DefaultEventBus.Subscribe(logPluggableConnectionEvent)
+	go func() {
+		<-ctx.Done()
+		unsubscribe()
+	}()
+	pluggable.SetMaxConcurrentDials(a.runtimeConfig.registry.MaxConcurrentDials())
+	pluggable.SetSchemaValidation(a.runtimeConfig.registry.SchemaValidation())
+	required := a.runtimeConfig.registry.RequiredPluggables()
+	pluggable.SetRequiredForStartup(required)
+	if len(required) > 0 {
+		if err := pluggable.WaitForRequired(ctx, required, pluggable.DefaultRequiredComponentsTimeout); err != nil {
+			return fmt.Errorf("failed to wait for required pluggable components: %w", err)
+		}
 	}
 	if err := pluggable.Discover(ctx); err != nil {
 		log.Errorf("could not initialize pluggable components %v", err)
+		return nil
+	}
+	if interval := a.runtimeConfig.registry.HealthCheckInterval(); interval > 0 {
+		gracePeriod := a.runtimeConfig.registry.EvictionGracePeriod()
+		if gracePeriod <= 0 {
+			gracePeriod = pluggable.DefaultEvictionGracePeriod
+		}
+		go pluggable.MonitorHealth(ctx, interval, gracePeriod)
 	}
+	return nil
+}
+
+// logPluggableConnectionEvent logs the connection transitions of the pluggable components.
+func logPluggableConnectionEvent(event pluggable.ConnectionEvent) {
+	if event.Type == pluggable.EventDisconnected {
+		log.Warnf("pluggable component '%s' of type %s disconnected", event.Name, event.Pluggable.Type)
+		return
+	}
+	log.Infof("pluggable component '%s' of type %s %s", event.Name, event.Pluggable.Type, event.Type)
 }
 
 // Sets the status of the app to healthy or un-healthy
@@ -799,9 +835,29 @@ func (a *DaprRuntime) onComponentUpdated(ctx context.Context, component componen
 		return false
 	}
 
+	// updated components go through the pending queue as well, so they are never re-initialized concurrently with
+	// the init or close of the same component.
 	return a.addPendingComponent(ctx, component)
 }
 
+// reinitComponent re-initializes in place an already loaded component whose metadata changed, returning true on
+// success. It returns false when the component must be initialized again instead.
+func (a *DaprRuntime) reinitComponent(ctx context.Context, comp componentsV1alpha1.Component) bool {
+	oldComp, exists := a.compStore.GetComponent(comp.Spec.Type, comp.Name)
+	if !exists || oldComp.Spec.Version != comp.Spec.Version {
+		return false
+	}
+
+	err := a.processor.Reinit(ctx, comp)
+	if err == nil {
+		return true
+	}
+	if !errors.Is(err, pluggable.ErrReinitUnsupported) {
+		log.Warnf("Failed to reinit component %s, reconnecting: %s", comp.LogName(), err)
+	}
+	return false
+}
+
 // begin http endpoint updates for kubernetes mode.
 func (a *DaprRuntime) beginHTTPEndpointsUpdates(ctx context.Context) error {
 	if a.operatorClient == nil {
@@ -1338,6 +1394,11 @@ func (a *DaprRuntime) processComponentAndDependents(ctx context.Context, comp co
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	if a.reinitComponent(ctx, comp) {
+		log.Info("Component reinitialized: " + comp.LogName())
+		return nil
+	}
+
 	err = a.processor.Init(ctx, comp)
 	// If the context is canceled, we want  to return an init error.
 	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
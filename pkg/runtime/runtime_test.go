@@ -29,6 +29,7 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"runtime"
 	"strconv"
 	"strings"
 	"sync"
@@ -67,6 +68,7 @@ import (
 	lockLoader "github.com/dapr/dapr/pkg/components/lock"
 	httpMiddlewareLoader "github.com/dapr/dapr/pkg/components/middleware/http"
 	nrLoader "github.com/dapr/dapr/pkg/components/nameresolution"
+	"github.com/dapr/dapr/pkg/components/pluggable"
 	pubsubLoader "github.com/dapr/dapr/pkg/components/pubsub"
 	secretstoresLoader "github.com/dapr/dapr/pkg/components/secretstores"
 	"github.com/dapr/dapr/pkg/config/protocol"
@@ -80,6 +82,7 @@ import (
 	pb "github.com/dapr/dapr/pkg/grpc/proxy/testservice"
 	invokev1 "github.com/dapr/dapr/pkg/messaging/v1"
 	"github.com/dapr/dapr/pkg/modes"
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
 	operatorv1pb "github.com/dapr/dapr/pkg/proto/operator/v1"
 	"github.com/dapr/dapr/pkg/resiliency"
 	rterrors "github.com/dapr/dapr/pkg/runtime/errors"
@@ -1487,6 +1490,95 @@ func TestOnComponentUpdated(t *testing.T) {
 
 		assert.False(t, updated)
 	})
+
+	reinitComponent := func(value string) componentsV1alpha1.Component {
+		return componentsV1alpha1.Component{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "test",
+			},
+			Spec: componentsV1alpha1.ComponentSpec{
+				Type:    "state.mockState",
+				Version: "v1",
+				Metadata: []commonapi.NameValuePair{
+					{
+						Name: "connectionString",
+						Value: commonapi.DynamicValue{
+							JSON: v1.JSON{
+								Raw: []byte(value),
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	t.Run("updated component is reinitialized through the pending queue", func(t *testing.T) {
+		rt, _ := NewTestDaprRuntime(modes.KubernetesMode)
+		store := &reinitStateStore{FakeStateStore: daprt.NewFakeStateStore()}
+		rt.compStore.AddStateStore("test", store)
+		rt.compStore.AddComponent(reinitComponent("old"))
+
+		pending := make(chan componentsV1alpha1.Component, 1)
+		go func() {
+			pending <- <-rt.pendingComponents
+		}()
+
+		updated := rt.onComponentUpdated(context.Background(), reinitComponent("new"))
+
+		assert.True(t, updated)
+		assert.Empty(t, store.reinitCalls)
+		select {
+		case comp := <-pending:
+			assert.Equal(t, reinitComponent("new").Spec, comp.Spec)
+			assert.True(t, rt.reinitComponent(context.Background(), comp))
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for the component to be queued")
+		}
+
+		require.Len(t, store.reinitCalls, 1)
+		assert.Equal(t, "new", store.reinitCalls[0].Properties["connectionString"])
+		comp, ok := rt.compStore.GetComponent("state.mockState", "test")
+		require.True(t, ok)
+		assert.Equal(t, reinitComponent("new").Spec, comp.Spec)
+	})
+
+	t.Run("component not supporting reinit falls back to reconnect", func(t *testing.T) {
+		rt, _ := NewTestDaprRuntime(modes.KubernetesMode)
+		store := &reinitStateStore{
+			FakeStateStore: daprt.NewFakeStateStore(),
+			reinitErr:      pluggable.ErrReinitUnsupported,
+		}
+		rt.compStore.AddStateStore("test", store)
+		rt.compStore.AddComponent(reinitComponent("old"))
+
+		assert.False(t, rt.reinitComponent(context.Background(), reinitComponent("new")))
+		assert.Len(t, store.reinitCalls, 1)
+		comp, ok := rt.compStore.GetComponent("state.mockState", "test")
+		require.True(t, ok)
+		assert.Equal(t, reinitComponent("old").Spec, comp.Spec)
+	})
+
+	t.Run("component not loaded yet is not reinitialized", func(t *testing.T) {
+		rt, _ := NewTestDaprRuntime(modes.KubernetesMode)
+		store := &reinitStateStore{FakeStateStore: daprt.NewFakeStateStore()}
+		rt.compStore.AddStateStore("test", store)
+
+		assert.False(t, rt.reinitComponent(context.Background(), reinitComponent("new")))
+		assert.Empty(t, store.reinitCalls)
+	})
+}
+
+// reinitStateStore is a state store that supports being re-initialized in place.
+type reinitStateStore struct {
+	*daprt.FakeStateStore
+	reinitErr   error
+	reinitCalls []state.Metadata
+}
+
+func (s *reinitStateStore) Reinit(_ context.Context, metadata state.Metadata) error {
+	s.reinitCalls = append(s.reinitCalls, metadata)
+	return s.reinitErr
 }
 
 func TestPopulateSecretsConfiguration(t *testing.T) {
@@ -1516,6 +1608,139 @@ func TestPopulateSecretsConfiguration(t *testing.T) {
 	})
 }
 
+// pluggableStateStoreServer is a fake pluggable state store that records the received init requests.
+type pluggableStateStoreServer struct {
+	proto.UnimplementedStateStoreServer
+	initRequests chan *proto.InitRequest
+}
+
+func (s *pluggableStateStoreServer) Init(_ context.Context, req *proto.InitRequest) (*proto.InitResponse, error) {
+	s.initRequests <- req
+	return &proto.InitResponse{}, nil
+}
+
+func (s *pluggableStateStoreServer) Features(context.Context, *proto.FeaturesRequest) (*proto.FeaturesResponse, error) {
+	return &proto.FeaturesResponse{}, nil
+}
+
+func TestSupportedPluggableServices(t *testing.T) {
+	assert.Equal(t, []string{
+		"dapr.proto.components.v1.InputBinding",
+		"dapr.proto.components.v1.OutputBinding",
+		"dapr.proto.components.v1.PubSub",
+		"dapr.proto.components.v1.SecretStore",
+		"dapr.proto.components.v1.StateStore",
+	}, pluggable.SupportedServices())
+}
+
+func TestInitPluggableComponentsRequired(t *testing.T) {
+	// gRPC Pluggable component requires Unix Domain Socket to work, I'm skipping this test when running on windows.
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	t.Setenv(pluggable.SocketFolderEnvVar, t.TempDir())
+
+	t.Run("missing required component should fail startup", func(t *testing.T) {
+		rt, err := NewTestDaprRuntime(modes.StandaloneMode)
+		require.NoError(t, err)
+		defer stopRuntime(t, rt)
+		rt.runtimeConfig.registry = registry.New(registry.NewOptions().WithRequiredPluggables("missing-comp"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		err = rt.initPluggableComponents(ctx)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, pluggable.ErrRequiredComponentNotReady)
+		assert.Contains(t, err.Error(), "missing-comp")
+	})
+
+	t.Run("no required components should not block startup", func(t *testing.T) {
+		rt, err := NewTestDaprRuntime(modes.StandaloneMode)
+		require.NoError(t, err)
+		defer stopRuntime(t, rt)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		assert.NoError(t, rt.initPluggableComponents(ctx))
+	})
+}
+
+func TestPluggableComponentSecretReferences(t *testing.T) {
+	// gRPC Pluggable component requires Unix Domain Socket to work, I'm skipping this test when running on windows.
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	rt, err := NewTestDaprRuntime(modes.StandaloneMode)
+	require.NoError(t, err)
+	defer stopRuntime(t, rt)
+
+	m := NewMockKubernetesStore()
+	rt.runtimeConfig.registry.SecretStores().RegisterComponent(
+		func(_ logger.Logger) secretstores.SecretStore {
+			return m
+		},
+		secretstoresLoader.BuiltinKubernetesSecretStore,
+	)
+	require.NoError(t, rt.processComponentAndDependents(context.Background(), componentsV1alpha1.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: secretstoresLoader.BuiltinKubernetesSecretStore,
+		},
+		Spec: componentsV1alpha1.ComponentSpec{
+			Type:    "secretstores.kubernetes",
+			Version: "v1",
+		},
+	}))
+
+	socket := filepath.Join(t.TempDir(), "pluggable-state.sock")
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+	defer listener.Close()
+	srv := &pluggableStateStoreServer{initRequests: make(chan *proto.InitRequest, 1)}
+	s := grpc.NewServer()
+	proto.RegisterStateStoreServer(s, srv)
+	go s.Serve(listener)
+	defer s.Stop()
+
+	rt.runtimeConfig.registry.StateStores().RegisterComponent(
+		func(l logger.Logger) state.Store {
+			return stateLoader.NewGRPCStateStore(l, socket)
+		},
+		"pluggable",
+	)
+
+	err = rt.processComponentAndDependents(context.Background(), componentsV1alpha1.Component{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pluggableStore",
+		},
+		Spec: componentsV1alpha1.ComponentSpec{
+			Type:    "state.pluggable",
+			Version: "v1",
+			Metadata: []commonapi.NameValuePair{
+				{
+					Name: "a",
+					SecretKeyRef: commonapi.SecretKeyRef{
+						Key:  "key1",
+						Name: "name1",
+					},
+				},
+			},
+		},
+		Auth: componentsV1alpha1.Auth{
+			SecretStore: secretstoresLoader.BuiltinKubernetesSecretStore,
+		},
+	})
+	require.NoError(t, err)
+
+	select {
+	case req := <-srv.initRequests:
+		assert.Equal(t, "value1", req.Metadata.Properties["a"])
+	case <-time.After(time.Second):
+		require.Fail(t, "pluggable component init was not called")
+	}
+}
+
 func TestProcessResourceSecrets(t *testing.T) {
 	createMockBinding := func() *componentsV1alpha1.Component {
 		return &componentsV1alpha1.Component{
@@ -3183,6 +3408,81 @@ func TestGracefulShutdownPubSub(t *testing.T) {
 	}
 }
 
+// subscriptionRecordingPubSub is a pubsub that records the context of its active subscriptions.
+type subscriptionRecordingPubSub struct {
+	mockPubSub
+	lock sync.Mutex
+	subs []context.Context
+}
+
+func (p *subscriptionRecordingPubSub) Subscribe(ctx context.Context, _ pubsub.SubscribeRequest, _ pubsub.Handler) error {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.subs = append(p.subs, ctx)
+	return nil
+}
+
+func (p *subscriptionRecordingPubSub) subscriptions() []context.Context {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	return append([]context.Context(nil), p.subs...)
+}
+
+func TestAppHealthChangedPausesSubscriptions(t *testing.T) {
+	rt, err := NewTestDaprRuntime(modes.StandaloneMode)
+	require.NoError(t, err)
+	defer stopRuntime(t, rt)
+
+	recordingPubSub := &subscriptionRecordingPubSub{}
+	rt.runtimeConfig.registry.PubSubs().RegisterComponent(
+		func(_ logger.Logger) pubsub.PubSub {
+			return recordingPubSub
+		},
+		"recordingPubSub",
+	)
+
+	cPubSub := componentsV1alpha1.Component{}
+	cPubSub.ObjectMeta.Name = "recordingPubSub"
+	cPubSub.Spec.Type = "pubsub.recordingPubSub"
+
+	sub, _ := json.Marshal([]runtimePubsub.SubscriptionJSON{
+		{PubsubName: "recordingPubSub", Topic: "topic0", Route: "health"},
+	})
+	mockAppChannel := new(channelt.MockAppChannel)
+	rt.channels.WithAppChannel(mockAppChannel)
+	mockAppChannel.On("InvokeMethod", mock.MatchedBy(daprt.MatchContextInterface), matchDaprRequestMethod("dapr/subscribe")).Return(func(context.Context, *invokev1.InvokeMethodRequest, string) *invokev1.InvokeMethodResponse {
+		return invokev1.NewInvokeMethodResponse(200, "OK", nil).WithRawDataBytes(sub).WithContentType("application/json")
+	}, nil)
+
+	rt.processor = processor.New(processor.Options{
+		ID:             rt.runtimeConfig.id,
+		IsHTTP:         rt.runtimeConfig.appConnectionConfig.Protocol.IsHTTP(),
+		Registry:       rt.runtimeConfig.registry,
+		ComponentStore: rt.compStore,
+		Meta:           rt.meta,
+		GlobalConfig:   rt.globalConfig,
+		Resiliency:     rt.resiliency,
+		Mode:           rt.runtimeConfig.mode,
+		Standalone:     rt.runtimeConfig.standalone,
+		Channels:       rt.channels,
+		GRPC:           rt.grpc,
+	})
+	require.NoError(t, rt.processor.Init(context.Background(), cPubSub))
+
+	rt.appHealthChanged(context.Background(), apphealth.AppStatusHealthy)
+	subs := recordingPubSub.subscriptions()
+	require.Len(t, subs, 1)
+	require.NoError(t, subs[0].Err(), "subscription should be delivering while the app is healthy")
+
+	rt.appHealthChanged(context.Background(), apphealth.AppStatusUnhealthy)
+	assert.Error(t, subs[0].Err(), "subscription should be paused while the app is unhealthy")
+
+	rt.appHealthChanged(context.Background(), apphealth.AppStatusHealthy)
+	subs = recordingPubSub.subscriptions()
+	require.Len(t, subs, 2)
+	assert.NoError(t, subs[1].Err(), "subscription should resume when the app recovers")
+}
+
 func TestGracefulShutdownActors(t *testing.T) {
 	rt, err := NewTestDaprRuntime(modes.StandaloneMode)
 	require.NoError(t, err)
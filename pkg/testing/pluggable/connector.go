@@ -0,0 +1,67 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/dapr/dapr/pkg/components/pluggable"
+	"github.com/dapr/kit/logger"
+)
+
+const bufSize = 1024 * 1024
+
+// TestConnectorFor returns a connector factory that bootstraps a grpc server backed by a buf connection (in memory) and returns a connector connected to it.
+// it also provides a cleanup function for closing the grpc server and the connector.
+//
+//	usage,
+//
+//		connectorFactory := testingPluggable.TestConnectorFor(testLogger, func(s *grpc.Server, svc *your_service_goes_here) {
+//				proto.RegisterStateStoreServer(s, svc) // your service
+//		}, proto.NewStateStoreClient)
+//
+//		connector, cleanup, err := connectorFactory(&your_service{})
+//		require.NoError(t, err)
+//		defer cleanup()
+func TestConnectorFor[TServer any, TClient pluggable.GRPCClient](logger logger.Logger, registersvc func(*grpc.Server, TServer), clientFactory func(grpc.ClientConnInterface) TClient, opts ...pluggable.Option) func(svc TServer) (connector *pluggable.GRPCConnector[TClient], cleanup func(), err error) {
+	return func(srv TServer) (*pluggable.GRPCConnector[TClient], func(), error) {
+		lis := bufconn.Listen(bufSize)
+		s := grpc.NewServer()
+		registersvc(s, srv)
+		go func() {
+			if serveErr := s.Serve(lis); serveErr != nil {
+				logger.Debugf("Server exited with error: %v", serveErr)
+			}
+		}()
+
+		conn, err := grpc.DialContext(context.Background(), "bufnet", grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) {
+			return lis.Dial()
+		}), grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			s.Stop()
+			return nil, nil, err
+		}
+
+		connector := pluggable.NewGRPCConnectorWithConn(clientFactory(conn), conn, opts...)
+		return connector, func() {
+			connector.Close()
+			s.Stop()
+		}, nil
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+	"github.com/dapr/kit/logger"
+)
+
+var testLogger = logger.NewLogger("pluggable-test")
+
+type pingServer struct {
+	proto.UnimplementedPubSubServer
+	pingCalled atomic.Int64
+}
+
+func (s *pingServer) Ping(context.Context, *proto.PingRequest) (*proto.PingResponse, error) {
+	s.pingCalled.Add(1)
+	return &proto.PingResponse{}, nil
+}
+
+func TestTestConnectorFor(t *testing.T) {
+	t.Run("test connector should be connected to the in-memory server", func(t *testing.T) {
+		svc := &pingServer{}
+		connector, cleanup, err := TestConnectorFor(testLogger, func(s *grpc.Server, svc *pingServer) {
+			proto.RegisterPubSubServer(s, svc)
+		}, proto.NewPubSubClient)(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		require.NoError(t, connector.Dial("my-component"))
+		require.NoError(t, connector.Ping())
+		assert.Equal(t, int64(1), svc.pingCalled.Load())
+	})
+}
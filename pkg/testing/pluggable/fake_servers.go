@@ -0,0 +1,311 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+// FakeServerOption configures the behavior of a fake component server.
+type FakeServerOption func(*fakeServer)
+
+// WithFakeError makes the given method return the given error, use status errors to emulate component errors.
+func WithFakeError(method string, err error) FakeServerOption {
+	return func(f *fakeServer) {
+		f.errs[method] = err
+	}
+}
+
+// WithFakeDelay makes the given method wait for the given duration, or until the call is cancelled, before returning.
+func WithFakeDelay(method string, delay time.Duration) FakeServerOption {
+	return func(f *fakeServer) {
+		f.delays[method] = delay
+	}
+}
+
+// WithFakeResponse makes the given method return the given response, it must be of the method response type.
+// methods without a configured response return an empty one.
+func WithFakeResponse(method string, resp any) FakeServerOption {
+	return func(f *fakeServer) {
+		f.responses[method] = resp
+	}
+}
+
+// fakeServer holds the configured behaviors and the requests received by a fake component server.
+type fakeServer struct {
+	errs      map[string]error
+	delays    map[string]time.Duration
+	responses map[string]any
+
+	lock     sync.Mutex
+	requests map[string][]any
+}
+
+func newFakeServer(opts ...FakeServerOption) *fakeServer {
+	f := &fakeServer{
+		errs:      make(map[string]error),
+		delays:    make(map[string]time.Duration),
+		responses: make(map[string]any),
+		requests:  make(map[string][]any),
+	}
+	for _, opt := range opts {
+		opt(f)
+	}
+	return f
+}
+
+// Requests returns the requests received by the given method in the order they arrived.
+func (f *fakeServer) Requests(method string) []any {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return append([]any{}, f.requests[method]...)
+}
+
+// handle records the request and applies the configured behaviors of the given method.
+func (f *fakeServer) handle(ctx context.Context, method string, req any) (any, error) {
+	f.lock.Lock()
+	f.requests[method] = append(f.requests[method], req)
+	f.lock.Unlock()
+
+	if delay, ok := f.delays[method]; ok {
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if err := f.errs[method]; err != nil {
+		return nil, err
+	}
+	return f.responses[method], nil
+}
+
+// fakeCall handles a fake call returning the configured response or an empty one.
+func fakeCall[TResp any](ctx context.Context, f *fakeServer, method string, req any) (*TResp, error) {
+	resp, err := f.handle(ctx, method, req)
+	if err != nil {
+		return nil, err
+	}
+	if r, ok := resp.(*TResp); ok {
+		return r, nil
+	}
+	return new(TResp), nil
+}
+
+// FakeStateServer is an in-memory state store server with configurable behaviors, meant for adapter tests.
+//
+//	usage,
+//
+//		connectorFor := testingPluggable.TestConnectorFor(testLogger, func(s *grpc.Server, svc *testingPluggable.FakeStateServer) {
+//			svc.Register(s)
+//		}, newStateStoreClient)
+//		connector, cleanup, err := connectorFor(testingPluggable.NewFakeStateServer(testingPluggable.WithFakeError("Set", err)))
+type FakeStateServer struct {
+	proto.UnimplementedStateStoreServer
+	proto.UnimplementedTransactionalStateStoreServer
+	proto.UnimplementedQueriableStateStoreServer
+	*fakeServer
+}
+
+// NewFakeStateServer creates a new fake state store server.
+func NewFakeStateServer(opts ...FakeServerOption) *FakeStateServer {
+	return &FakeStateServer{fakeServer: newFakeServer(opts...)}
+}
+
+// Register registers the state store services on the given server.
+func (s *FakeStateServer) Register(server *grpc.Server) {
+	proto.RegisterStateStoreServer(server, s)
+	proto.RegisterTransactionalStateStoreServer(server, s)
+	proto.RegisterQueriableStateStoreServer(server, s)
+}
+
+func (s *FakeStateServer) Init(ctx context.Context, req *proto.InitRequest) (*proto.InitResponse, error) {
+	return fakeCall[proto.InitResponse](ctx, s.fakeServer, "Init", req)
+}
+
+func (s *FakeStateServer) Features(ctx context.Context, req *proto.FeaturesRequest) (*proto.FeaturesResponse, error) {
+	return fakeCall[proto.FeaturesResponse](ctx, s.fakeServer, "Features", req)
+}
+
+func (s *FakeStateServer) Delete(ctx context.Context, req *proto.DeleteRequest) (*proto.DeleteResponse, error) {
+	return fakeCall[proto.DeleteResponse](ctx, s.fakeServer, "Delete", req)
+}
+
+func (s *FakeStateServer) Get(ctx context.Context, req *proto.GetRequest) (*proto.GetResponse, error) {
+	return fakeCall[proto.GetResponse](ctx, s.fakeServer, "Get", req)
+}
+
+func (s *FakeStateServer) Set(ctx context.Context, req *proto.SetRequest) (*proto.SetResponse, error) {
+	return fakeCall[proto.SetResponse](ctx, s.fakeServer, "Set", req)
+}
+
+func (s *FakeStateServer) Ping(ctx context.Context, req *proto.PingRequest) (*proto.PingResponse, error) {
+	return fakeCall[proto.PingResponse](ctx, s.fakeServer, "Ping", req)
+}
+
+func (s *FakeStateServer) BulkDelete(ctx context.Context, req *proto.BulkDeleteRequest) (*proto.BulkDeleteResponse, error) {
+	return fakeCall[proto.BulkDeleteResponse](ctx, s.fakeServer, "BulkDelete", req)
+}
+
+func (s *FakeStateServer) BulkGet(ctx context.Context, req *proto.BulkGetRequest) (*proto.BulkGetResponse, error) {
+	return fakeCall[proto.BulkGetResponse](ctx, s.fakeServer, "BulkGet", req)
+}
+
+func (s *FakeStateServer) BulkSet(ctx context.Context, req *proto.BulkSetRequest) (*proto.BulkSetResponse, error) {
+	return fakeCall[proto.BulkSetResponse](ctx, s.fakeServer, "BulkSet", req)
+}
+
+func (s *FakeStateServer) Transact(ctx context.Context, req *proto.TransactionalStateRequest) (*proto.TransactionalStateResponse, error) {
+	return fakeCall[proto.TransactionalStateResponse](ctx, s.fakeServer, "Transact", req)
+}
+
+func (s *FakeStateServer) Query(ctx context.Context, req *proto.QueryRequest) (*proto.QueryResponse, error) {
+	return fakeCall[proto.QueryResponse](ctx, s.fakeServer, "Query", req)
+}
+
+// FakePubSubServer is an in-memory pubsub server with configurable behaviors, meant for adapter tests.
+// PullMessages is not faked and returns an Unimplemented status.
+type FakePubSubServer struct {
+	proto.UnimplementedPubSubServer
+	*fakeServer
+}
+
+// NewFakePubSubServer creates a new fake pubsub server.
+func NewFakePubSubServer(opts ...FakeServerOption) *FakePubSubServer {
+	return &FakePubSubServer{fakeServer: newFakeServer(opts...)}
+}
+
+// Register registers the pubsub service on the given server.
+func (s *FakePubSubServer) Register(server *grpc.Server) {
+	proto.RegisterPubSubServer(server, s)
+}
+
+func (s *FakePubSubServer) Init(ctx context.Context, req *proto.PubSubInitRequest) (*proto.PubSubInitResponse, error) {
+	return fakeCall[proto.PubSubInitResponse](ctx, s.fakeServer, "Init", req)
+}
+
+func (s *FakePubSubServer) Features(ctx context.Context, req *proto.FeaturesRequest) (*proto.FeaturesResponse, error) {
+	return fakeCall[proto.FeaturesResponse](ctx, s.fakeServer, "Features", req)
+}
+
+func (s *FakePubSubServer) Publish(ctx context.Context, req *proto.PublishRequest) (*proto.PublishResponse, error) {
+	return fakeCall[proto.PublishResponse](ctx, s.fakeServer, "Publish", req)
+}
+
+func (s *FakePubSubServer) BulkPublish(ctx context.Context, req *proto.BulkPublishRequest) (*proto.BulkPublishResponse, error) {
+	return fakeCall[proto.BulkPublishResponse](ctx, s.fakeServer, "BulkPublish", req)
+}
+
+func (s *FakePubSubServer) Ping(ctx context.Context, req *proto.PingRequest) (*proto.PingResponse, error) {
+	return fakeCall[proto.PingResponse](ctx, s.fakeServer, "Ping", req)
+}
+
+// FakeSecretStoreServer is an in-memory secret store server with configurable behaviors, meant for adapter tests.
+type FakeSecretStoreServer struct {
+	proto.UnimplementedSecretStoreServer
+	*fakeServer
+}
+
+// NewFakeSecretStoreServer creates a new fake secret store server.
+func NewFakeSecretStoreServer(opts ...FakeServerOption) *FakeSecretStoreServer {
+	return &FakeSecretStoreServer{fakeServer: newFakeServer(opts...)}
+}
+
+// Register registers the secret store service on the given server.
+func (s *FakeSecretStoreServer) Register(server *grpc.Server) {
+	proto.RegisterSecretStoreServer(server, s)
+}
+
+func (s *FakeSecretStoreServer) Init(ctx context.Context, req *proto.SecretStoreInitRequest) (*proto.SecretStoreInitResponse, error) {
+	return fakeCall[proto.SecretStoreInitResponse](ctx, s.fakeServer, "Init", req)
+}
+
+func (s *FakeSecretStoreServer) Features(ctx context.Context, req *proto.FeaturesRequest) (*proto.FeaturesResponse, error) {
+	return fakeCall[proto.FeaturesResponse](ctx, s.fakeServer, "Features", req)
+}
+
+func (s *FakeSecretStoreServer) Get(ctx context.Context, req *proto.GetSecretRequest) (*proto.GetSecretResponse, error) {
+	return fakeCall[proto.GetSecretResponse](ctx, s.fakeServer, "Get", req)
+}
+
+func (s *FakeSecretStoreServer) BulkGet(ctx context.Context, req *proto.BulkGetSecretRequest) (*proto.BulkGetSecretResponse, error) {
+	return fakeCall[proto.BulkGetSecretResponse](ctx, s.fakeServer, "BulkGet", req)
+}
+
+func (s *FakeSecretStoreServer) Ping(ctx context.Context, req *proto.PingRequest) (*proto.PingResponse, error) {
+	return fakeCall[proto.PingResponse](ctx, s.fakeServer, "Ping", req)
+}
+
+// FakeInputBindingServer is an in-memory input binding server with configurable behaviors, meant for adapter tests.
+// Read is not faked and returns an Unimplemented status.
+type FakeInputBindingServer struct {
+	proto.UnimplementedInputBindingServer
+	*fakeServer
+}
+
+// NewFakeInputBindingServer creates a new fake input binding server.
+func NewFakeInputBindingServer(opts ...FakeServerOption) *FakeInputBindingServer {
+	return &FakeInputBindingServer{fakeServer: newFakeServer(opts...)}
+}
+
+// Register registers the input binding service on the given server.
+func (s *FakeInputBindingServer) Register(server *grpc.Server) {
+	proto.RegisterInputBindingServer(server, s)
+}
+
+func (s *FakeInputBindingServer) Init(ctx context.Context, req *proto.InputBindingInitRequest) (*proto.InputBindingInitResponse, error) {
+	return fakeCall[proto.InputBindingInitResponse](ctx, s.fakeServer, "Init", req)
+}
+
+func (s *FakeInputBindingServer) Ping(ctx context.Context, req *proto.PingRequest) (*proto.PingResponse, error) {
+	return fakeCall[proto.PingResponse](ctx, s.fakeServer, "Ping", req)
+}
+
+// FakeOutputBindingServer is an in-memory output binding server with configurable behaviors, meant for adapter tests.
+type FakeOutputBindingServer struct {
+	proto.UnimplementedOutputBindingServer
+	*fakeServer
+}
+
+// NewFakeOutputBindingServer creates a new fake output binding server.
+func NewFakeOutputBindingServer(opts ...FakeServerOption) *FakeOutputBindingServer {
+	return &FakeOutputBindingServer{fakeServer: newFakeServer(opts...)}
+}
+
+// Register registers the output binding service on the given server.
+func (s *FakeOutputBindingServer) Register(server *grpc.Server) {
+	proto.RegisterOutputBindingServer(server, s)
+}
+
+func (s *FakeOutputBindingServer) Init(ctx context.Context, req *proto.OutputBindingInitRequest) (*proto.OutputBindingInitResponse, error) {
+	return fakeCall[proto.OutputBindingInitResponse](ctx, s.fakeServer, "Init", req)
+}
+
+func (s *FakeOutputBindingServer) Invoke(ctx context.Context, req *proto.InvokeRequest) (*proto.InvokeResponse, error) {
+	return fakeCall[proto.InvokeResponse](ctx, s.fakeServer, "Invoke", req)
+}
+
+func (s *FakeOutputBindingServer) ListOperations(ctx context.Context, req *proto.ListOperationsRequest) (*proto.ListOperationsResponse, error) {
+	return fakeCall[proto.ListOperationsResponse](ctx, s.fakeServer, "ListOperations", req)
+}
+
+func (s *FakeOutputBindingServer) Ping(ctx context.Context, req *proto.PingRequest) (*proto.PingResponse, error) {
+	return fakeCall[proto.PingResponse](ctx, s.fakeServer, "Ping", req)
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Dapr Authors
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+    http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pluggable
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	proto "github.com/dapr/dapr/pkg/proto/components/v1"
+)
+
+func TestFakeServers(t *testing.T) {
+	secretStoreConnectorFor := TestConnectorFor(testLogger, func(s *grpc.Server, svc *FakeSecretStoreServer) {
+		svc.Register(s)
+	}, proto.NewSecretStoreClient)
+
+	t.Run("fake server should record the requests and return empty responses by default", func(t *testing.T) {
+		svc := NewFakeSecretStoreServer()
+		connector, cleanup, err := secretStoreConnectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		resp, err := connector.Client.Get(context.Background(), &proto.GetSecretRequest{Key: "my-key"})
+		require.NoError(t, err)
+		assert.Empty(t, resp.Data)
+
+		requests := svc.Requests("Get")
+		require.Len(t, requests, 1)
+		assert.Equal(t, "my-key", requests[0].(*proto.GetSecretRequest).Key)
+		assert.Empty(t, svc.Requests("BulkGet"))
+	})
+
+	t.Run("fake server should return the configured response", func(t *testing.T) {
+		svc := NewFakeSecretStoreServer(WithFakeResponse("Get", &proto.GetSecretResponse{Data: map[string]string{"my-key": "my-value"}}))
+		connector, cleanup, err := secretStoreConnectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		resp, err := connector.Client.Get(context.Background(), &proto.GetSecretRequest{Key: "my-key"})
+		require.NoError(t, err)
+		assert.Equal(t, "my-value", resp.Data["my-key"])
+	})
+
+	t.Run("fake server should return the configured error", func(t *testing.T) {
+		svc := NewFakeSecretStoreServer(WithFakeError("Get", status.Error(codes.NotFound, "secret not found")))
+		connector, cleanup, err := secretStoreConnectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		_, err = connector.Client.Get(context.Background(), &proto.GetSecretRequest{Key: "my-key"})
+		assert.Equal(t, codes.NotFound, status.Code(err))
+		assert.Len(t, svc.Requests("Get"), 1)
+	})
+
+	t.Run("fake server should delay the configured method until the call is cancelled", func(t *testing.T) {
+		svc := NewFakeSecretStoreServer(WithFakeDelay("Get", time.Minute))
+		connector, cleanup, err := secretStoreConnectorFor(svc)
+		require.NoError(t, err)
+		defer cleanup()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err = connector.Client.Get(ctx, &proto.GetSecretRequest{Key: "my-key"})
+		assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+
+		_, err = connector.Client.BulkGet(context.Background(), &proto.BulkGetSecretRequest{})
+		assert.NoError(t, err)
+	})
+}